@@ -0,0 +1,220 @@
+package components
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anurag-roy/bubbletable/table"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CopyFormat selects how copied rows are written to the clipboard
+type CopyFormat int
+
+const (
+	CopyTSV      CopyFormat = iota // Tab-separated, pastes into spreadsheets
+	CopyCSV                        // Comma-separated
+	CopyJSON                       // Array of objects with raw values
+	CopyMarkdown                   // GitHub-flavoured Markdown table
+)
+
+// String returns the format's name
+func (f CopyFormat) String() string {
+	switch f {
+	case CopyCSV:
+		return "CSV"
+	case CopyJSON:
+		return "JSON"
+	case CopyMarkdown:
+		return "Markdown"
+	default:
+		return "TSV"
+	}
+}
+
+// ClipboardHandler puts copied text on the clipboard in place of OSC52
+type ClipboardHandler func(text string) error
+
+// CopiedMsg reports the outcome of a copy to the clipboard
+type CopiedMsg struct {
+	What   string // What was copied, e.g. "3 rows"
+	Format CopyFormat
+	Err    error
+}
+
+// WithCopyFormat sets the clipboard format (builder pattern)
+func (m *TableModel) WithCopyFormat(format CopyFormat) *TableModel {
+	m.copyFormat = format
+	return m
+}
+
+// WithClipboardHandler sets a function that receives copied text instead of
+// it being sent to the terminal with OSC52, for hosts that manage the
+// clipboard themselves or run where OSC52 is unavailable
+func (m *TableModel) WithClipboardHandler(handler ClipboardHandler) *TableModel {
+	m.clipboardHandler = handler
+	return m
+}
+
+// GetCopyFormat returns the current clipboard format
+func (m *TableModel) GetCopyFormat() CopyFormat {
+	return m.copyFormat
+}
+
+// handleCopyKeys handles clipboard key presses, returning the command that
+// performs the copy
+func (m *TableModel) handleCopyKeys(key string) (bool, tea.Cmd) {
+	switch {
+	case m.keyBindings.IsCopyFormat(key):
+		m.copyFormat = (m.copyFormat + 1) % (CopyMarkdown + 1)
+		m.statusMsg = "Copy format: " + m.copyFormat.String()
+		return true, nil
+
+	case m.keyBindings.IsCopyCell(key):
+		cell, col, ok := m.GetSelectedCell()
+		if !ok {
+			return true, nil
+		}
+		text := col.Format(cell.Value)
+		if m.copyFormat == CopyJSON {
+			text = table.JSONValue(cell.Value)
+		}
+		return true, m.copyText("cell", text)
+
+	case m.keyBindings.IsCopyRow(key):
+		row, ok := m.GetSelectedRow()
+		if !ok {
+			return true, nil
+		}
+		return true, m.copyRows("row", []table.Row{row}, nil)
+
+	case m.keyBindings.IsCopyColumn(key):
+		currentTable := m.getCurrentTable()
+		if currentTable == nil || m.selectedCol >= len(currentTable.Columns) {
+			return true, nil
+		}
+		what := "column " + currentTable.Columns[m.selectedCol].Header
+		return true, m.copyRows(what, m.selectionRows(), []int{m.selectedCol})
+
+	case m.keyBindings.IsCopySelection(key):
+		rows := m.selectionRows()
+		what := "rows"
+		if len(rows) == 1 {
+			what = "row"
+		}
+		if len(m.marks) > 0 {
+			what = "marked " + what
+		}
+		what = fmt.Sprintf("%d %s", len(rows), what)
+		return true, m.copyRows(what, rows, nil)
+	}
+
+	return false, nil
+}
+
+// selectionRows returns the rows a selection copy covers: the marked rows,
+// or every row in view if none are marked
+func (m *TableModel) selectionRows() []table.Row {
+	if rows := m.GetMarkedRows(); len(rows) > 0 {
+		return rows
+	}
+	return m.viewRows()
+}
+
+// copyRows returns a command copying rows, limited to the given columns if
+// any, with a header line in the clipboard format
+func (m *TableModel) copyRows(what string, rows []table.Row, columns []int) tea.Cmd {
+	currentTable := m.getCurrentTable()
+	if currentTable == nil {
+		return nil
+	}
+	if columns == nil {
+		columns = make([]int, len(currentTable.Columns))
+		for i := range columns {
+			columns[i] = i
+		}
+	}
+
+	// Copy the chosen cells into a table of their own to export
+	selected := make([]table.Column, len(columns))
+	for i, colIndex := range columns {
+		selected[i] = currentTable.Columns[colIndex]
+		selected[i].Expr = "" // Copy computed values as they are
+	}
+	tbl := table.NewWithColumns(selected)
+	for _, row := range rows {
+		values := make([]interface{}, len(columns))
+		for i, colIndex := range columns {
+			if colIndex < len(row.Cells) {
+				values[i] = row.Cells[colIndex].Value
+			}
+		}
+		_ = tbl.AddRow(values...)
+	}
+
+	var b strings.Builder
+	var err error
+	switch m.copyFormat {
+	case CopyCSV, CopyTSV:
+		opts := table.DefaultCSVOptions()
+		if m.copyFormat == CopyTSV {
+			opts = table.TSVOptions()
+		}
+		opts.Formatted = true
+		err = tbl.WriteCSV(&b, opts)
+	case CopyJSON:
+		err = tbl.WriteJSON(&b, table.ExportOptions{})
+	case CopyMarkdown:
+		err = tbl.WriteMarkdown(&b, table.ExportOptions{})
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("✗ Copy failed: %s", err)
+		return nil
+	}
+	return m.copyText(what, strings.TrimSuffix(b.String(), "\n"))
+}
+
+// copyText returns a command putting text on the clipboard, through the
+// clipboard handler if one is set and with OSC52 otherwise
+func (m *TableModel) copyText(what, text string) tea.Cmd {
+	format, handler, out := m.copyFormat, m.clipboardHandler, m.clipboardOut
+	if out == nil {
+		out = os.Stderr
+	}
+
+	return func() tea.Msg {
+		var err error
+		if handler != nil {
+			err = handler(text)
+		} else {
+			err = writeOSC52(out, text)
+		}
+		return CopiedMsg{What: what, Format: format, Err: err}
+	}
+}
+
+// writeOSC52 writes text as an OSC52 clipboard sequence, wrapped for tmux or
+// GNU screen when running inside them
+func writeOSC52(out io.Writer, text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(out)
+	return err
+}
+
+// handleCopied shows the outcome of a copy in the status bar
+func (m *TableModel) handleCopied(msg CopiedMsg) {
+	if msg.Err != nil {
+		m.statusMsg = fmt.Sprintf("✗ Copy failed: %s", msg.Err)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✓ Copied %s as %s", msg.What, msg.Format)
+}
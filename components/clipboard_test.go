@@ -0,0 +1,147 @@
+package components
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pressKey sends a key to the model and runs the command it returns
+func pressKey(model *TableModel, msg tea.KeyMsg) {
+	_, cmd := model.Update(msg)
+	runCmds(model, cmd)
+}
+
+func TestCopyCellAndRow(t *testing.T) {
+	var copied string
+	model := newReadyModel([]TestEmployee{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob, Jr."}}).
+		WithClipboardHandler(func(text string) error {
+			copied = text
+			return nil
+		})
+	model.selectedCol = 1
+
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if copied != "Alice" {
+		t.Errorf("Expected the focused cell, got %q", copied)
+	}
+	if model.statusMsg != "✓ Copied cell as TSV" {
+		t.Errorf("Expected a confirmation, got %q", model.statusMsg)
+	}
+	if !strings.Contains(model.renderStatusBar(), "✓ Copied cell as TSV") {
+		t.Error("Expected the confirmation in the status bar")
+	}
+
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	if copied != "ID\tName\n1\tAlice" {
+		t.Errorf("Expected the selected row as TSV, got %q", copied)
+	}
+
+	// The confirmation clears on the next key press
+	pressKey(model, tea.KeyMsg{Type: tea.KeyDown})
+	if model.statusMsg != "" {
+		t.Errorf("Expected the status message cleared, got %q", model.statusMsg)
+	}
+}
+
+func TestCopyFormats(t *testing.T) {
+	var copied string
+	model := newReadyModel([]TestEmployee{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob, Jr."}}).
+		WithClipboardHandler(func(text string) error {
+			copied = text
+			return nil
+		})
+
+	tests := []struct {
+		format CopyFormat
+		want   string
+	}{
+		{CopyTSV, "ID\tName\n1\tAlice\n2\tBob, Jr."},
+		{CopyCSV, "ID,Name\n1,Alice\n2,\"Bob, Jr.\""},
+		{CopyJSON, "[\n  {\"ID\": 1, \"Name\": \"Alice\"},\n  {\"ID\": 2, \"Name\": \"Bob, Jr.\"}\n]"},
+		{CopyMarkdown, "|  ID | Name     |\n| --: | -------- |\n|   1 | Alice    |\n|   2 | Bob, Jr. |"},
+	}
+
+	for _, tt := range tests {
+		model.WithCopyFormat(tt.format)
+		pressKey(model, tea.KeyMsg{Type: tea.KeyCtrlY})
+		if copied != tt.want {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", tt.format, tt.want, copied)
+		}
+		if want := "✓ Copied 2 rows as " + tt.format.String(); model.statusMsg != want {
+			t.Errorf("Expected %q, got %q", want, model.statusMsg)
+		}
+	}
+
+	// A JSON cell keeps its type
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if copied != "1" {
+		t.Errorf("Expected the raw JSON value, got %q", copied)
+	}
+
+	// Ctrl+t cycles the format
+	model.WithCopyFormat(CopyMarkdown)
+	pressKey(model, tea.KeyMsg{Type: tea.KeyCtrlT})
+	if model.GetCopyFormat() != CopyTSV || model.statusMsg != "Copy format: TSV" {
+		t.Errorf("Expected the format to wrap around to TSV, got %s (%q)", model.GetCopyFormat(), model.statusMsg)
+	}
+}
+
+func TestCopyColumnOfView(t *testing.T) {
+	var copied string
+	model := newReadyModel([]TestEmployee{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob, Jr."}}).
+		WithClipboardHandler(func(text string) error {
+			copied = text
+			return nil
+		})
+	model.selectedCol = 1
+
+	// Only rows in the current view are copied
+	model.searchTerm = "bob"
+	model.updateSearch()
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}, Alt: true})
+	if copied != "Name\nBob, Jr." {
+		t.Errorf("Expected the focused column of the view, got %q", copied)
+	}
+	if model.statusMsg != "✓ Copied column Name as TSV" {
+		t.Errorf("Unexpected confirmation %q", model.statusMsg)
+	}
+}
+
+func TestCopyErrorAndOSC52(t *testing.T) {
+	model := newReadyModel([]TestEmployee{{ID: 1, Name: "Alice"}}).
+		WithClipboardHandler(func(string) error { return errors.New("no clipboard") })
+
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if model.statusMsg != "✗ Copy failed: no clipboard" {
+		t.Errorf("Expected the handler's error, got %q", model.statusMsg)
+	}
+
+	// Without a handler the text is sent with OSC52
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+	var out strings.Builder
+	model.clipboardHandler = nil
+	model.clipboardOut = &out
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+
+	want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("1")) + "\x07"
+	if out.String() != want {
+		t.Errorf("Expected OSC52 sequence %q, got %q", want, out.String())
+	}
+	if model.statusMsg != "✓ Copied cell as TSV" {
+		t.Errorf("Expected a confirmation, got %q", model.statusMsg)
+	}
+}
+
+func TestCopyFormatString(t *testing.T) {
+	names := map[CopyFormat]string{CopyTSV: "TSV", CopyCSV: "CSV", CopyJSON: "JSON", CopyMarkdown: "Markdown"}
+	for format, want := range names {
+		if got := format.String(); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+}
@@ -67,6 +67,14 @@
 //	    }).
 //	    WithKeyBindings(components.VimKeyBindings())
 //
+// Table paging lazily through a database query:
+//
+//	src := table.NewSQLSource(db, "SELECT * FROM orders")
+//	model := components.NewTableFromSource(src)
+//
+// Pages are fetched by commands returned from Init and Update, and arrive as
+// PageLoadedMsg messages, so a parent model must pass both along.
+//
 // # Builder API
 //
 // The fluent builder API allows for easy configuration:
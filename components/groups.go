@@ -0,0 +1,126 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+// WithGroupBy groups the rows by the columns with the given keys, nested in
+// the order given (builder pattern). Unknown keys are ignored; use GroupBy
+// to check for them.
+func (m *TableModel) WithGroupBy(columnKeys ...string) *TableModel {
+	_ = m.GroupBy(columnKeys...) // Ignore error
+	return m
+}
+
+// GroupBy groups the rows by the columns with the given keys, showing a
+// header row with the count and aggregates of each group. Calling it with no
+// keys removes the grouping.
+func (m *TableModel) GroupBy(columnKeys ...string) error {
+	if m.table == nil {
+		return nil
+	}
+	if err := m.table.GroupBy(columnKeys...); err != nil {
+		return err
+	}
+
+	m.collapsed = nil
+	m.applyFilters()
+	m.currentPage = 0
+	m.selectedRow = 0
+	return nil
+}
+
+// SetGroupCollapsed collapses or expands the group with a path (see
+// table.Group.Path)
+func (m *TableModel) SetGroupCollapsed(path string, collapsed bool) {
+	if !collapsed {
+		delete(m.collapsed, path)
+	} else {
+		if m.collapsed == nil {
+			m.collapsed = make(map[string]bool)
+		}
+		m.collapsed[path] = true
+	}
+	m.regroup()
+}
+
+// IsGroupCollapsed reports whether the group with a path is collapsed
+func (m *TableModel) IsGroupCollapsed(path string) bool {
+	return m.collapsed[path]
+}
+
+// handleGroupKeys handles grouping key presses. Toggle keys only act on a
+// selected group header, so they keep their other meanings on data rows.
+func (m *TableModel) handleGroupKeys(key string) bool {
+	if m.keyBindings.IsGroupByFocused(key) {
+		m.toggleGroupColumn(m.selectedCol)
+		return true
+	}
+
+	if !m.keyBindings.IsToggleGroup(key) {
+		return false
+	}
+	row, ok := m.GetSelectedRow()
+	if !ok || row.Group == nil {
+		return false
+	}
+
+	// The header keeps its place, as only rows after it change
+	m.SetGroupCollapsed(row.Group.Path, !m.IsGroupCollapsed(row.Group.Path))
+	m.paginate()
+	return true
+}
+
+// toggleGroupColumn adds a column to the grouping as the innermost level, or
+// removes it if the rows are already grouped by it
+func (m *TableModel) toggleGroupColumn(colIndex int) {
+	if m.table == nil || colIndex < 0 || colIndex >= len(m.table.Columns) {
+		return
+	}
+
+	var keys []string
+	grouped := false
+	for _, index := range m.table.GroupColumns {
+		if index == colIndex {
+			grouped = true
+			continue
+		}
+		keys = append(keys, m.table.Columns[index].Key)
+	}
+	if !grouped {
+		keys = append(keys, m.table.Columns[colIndex].Key)
+	}
+	_ = m.GroupBy(keys...) // Keys come from the table's own columns
+}
+
+// regroup rebuilds the group view of the filtered rows, or clears it if the
+// table is not grouped. As every view change ends here, it also makes sure
+// the model follows the table's mutations.
+func (m *TableModel) regroup() {
+	m.watchTable()
+	m.groupedTable = nil
+
+	base := m.filteredTable
+	if base == nil {
+		base = m.table
+	}
+	if base == nil || len(base.GroupColumns) == 0 {
+		return
+	}
+	if view := base.GroupView(m.collapsed); view != base {
+		m.groupedTable = view
+	}
+}
+
+// formatGroupColumns describes the grouping, e.g. "Status › Priority"
+func formatGroupColumns(tbl *table.Table) string {
+	var parts []string
+	for _, colIndex := range tbl.GroupColumns {
+		if colIndex < len(tbl.Columns) {
+			parts = append(parts, tbl.Columns[colIndex].Header)
+		}
+	}
+	return strings.Join(parts, " › ")
+}
@@ -0,0 +1,134 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Ticket is a test row with a status to group by
+type Ticket struct {
+	Title  string `table:"Title"`
+	Status string `table:"Status"`
+	Hours  int    `table:"Hours"`
+}
+
+// viewTitles lists the current table's rows, with group headers by label
+func viewTitles(model *TableModel) string {
+	var titles []string
+	for _, row := range model.GetCurrentTable().CurrentRows() {
+		if row.Group != nil {
+			titles = append(titles, "["+row.Group.Label+"]")
+			continue
+		}
+		titles = append(titles, row.Cells[0].Value.(string))
+	}
+	return strings.Join(titles, ",")
+}
+
+func TestGroupByModel(t *testing.T) {
+	model := newReadyModel([]Ticket{
+		{"Login bug", "open", 3},
+		{"Docs", "done", 1},
+		{"Crash", "open", 8},
+		{"Release", "done", 2},
+	}).WithGroupBy("Status")
+
+	if got := viewTitles(model); got != "[Status: done],Docs,Release,[Status: open],Login bug,Crash" {
+		t.Fatalf("Unexpected grouped rows: %s", got)
+	}
+	if err := model.GroupBy("Missing"); err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "▾ Status: open (2)") || !strings.Contains(view, "Grouped: Status") {
+		t.Errorf("Expected group headers and grouping in the status bar, got:\n%s", view)
+	}
+
+	// Sorting applies inside each group
+	model.selectedCol = 2
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if got := viewTitles(model); got != "[Status: done],Release,Docs,[Status: open],Crash,Login bug" {
+		t.Errorf("Expected rows sorted by hours within groups, got %s", got)
+	}
+
+	// Search narrows the groups
+	model.searchMode = true
+	for _, r := range "crash" {
+		model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := viewTitles(model); got != "[Status: open],Crash" {
+		t.Errorf("Expected the search to narrow the groups, got %s", got)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := viewTitles(model); !strings.HasPrefix(got, "[Status: done]") {
+		t.Errorf("Expected every group back after leaving search, got %s", got)
+	}
+}
+
+func TestToggleGroup(t *testing.T) {
+	model := newReadyModel([]Ticket{
+		{"Login bug", "open", 3},
+		{"Docs", "done", 1},
+		{"Crash", "open", 8},
+		{"Release", "done", 2},
+	}).WithGroupBy("Status")
+
+	// Enter collapses the selected group header
+	pressKey(model, tea.KeyMsg{Type: tea.KeyEnter})
+	if got := viewTitles(model); got != "[Status: done],[Status: open],Login bug,Crash" {
+		t.Fatalf("Expected the done group to collapse, got %s", got)
+	}
+	if !strings.Contains(model.View(), "▸ Status: done (2)") {
+		t.Error("Expected a collapsed symbol on the done group")
+	}
+
+	// Space expands it again rather than marking the header
+	pressKey(model, tea.KeyMsg{Type: tea.KeySpace})
+	if got := viewTitles(model); got != "[Status: done],Docs,Release,[Status: open],Login bug,Crash" {
+		t.Errorf("Expected the done group to expand, got %s", got)
+	}
+	if len(model.GetMarkedRows()) != 0 {
+		t.Error("Expected no marks from toggling a group")
+	}
+
+	// Space on a data row still marks it
+	pressKey(model, tea.KeyMsg{Type: tea.KeyDown})
+	pressKey(model, tea.KeyMsg{Type: tea.KeySpace})
+	if marked := model.GetMarkedRows(); len(marked) != 1 || marked[0].Cells[0].Value != "Docs" {
+		t.Errorf("Expected Docs to be marked, got %v", marked)
+	}
+
+	// Mark all skips group headers and includes collapsed rows
+	pressKey(model, tea.KeyMsg{Type: tea.KeyUp})
+	pressKey(model, tea.KeyMsg{Type: tea.KeyEnter})
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if marked := model.GetMarkedRows(); len(marked) != 4 {
+		t.Errorf("Expected every ticket to be marked, got %d", len(marked))
+	}
+}
+
+func TestGroupByFocusedColumn(t *testing.T) {
+	model := newReadyModel([]Ticket{
+		{"Login bug", "open", 3},
+		{"Docs", "done", 1},
+		{"Crash", "open", 8},
+		{"Release", "done", 2},
+	}).WithGroupBy("Status")
+
+	// b on the grouping column removes it
+	model.selectedCol = 1
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if got := viewTitles(model); got != "Login bug,Docs,Crash,Release" {
+		t.Errorf("Expected the grouping to be removed, got %s", got)
+	}
+
+	// and adds it back as the innermost level
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if got := len(model.GetCurrentTable().CurrentRows()); got != 6 {
+		t.Errorf("Expected 2 group headers and 4 rows, got %d rows", got)
+	}
+}
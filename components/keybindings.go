@@ -2,123 +2,235 @@ package components
 
 // KeyBindings represents configurable key bindings for table interactions
 type KeyBindings struct {
-	Up           []string
-	Down         []string
-	Left         []string
-	Right        []string
-	PageUp       []string
-	PageDown     []string
-	Home         []string
-	End          []string
-	Search       []string
-	Quit         []string
-	Help         []string
-	Refresh      []string
-	PageSizeUp   []string
-	PageSizeDown []string
-	ResetPage    []string
-	ClearSort    []string
-	Sort1        []string
-	Sort2        []string
-	Sort3        []string
-	Sort4        []string
-	Sort5        []string
-	Sort6        []string
-	Sort7        []string
-	Sort8        []string
-	Sort9        []string
+	Up              []string
+	Down            []string
+	Left            []string
+	Right           []string
+	PageUp          []string
+	PageDown        []string
+	Home            []string
+	End             []string
+	Search          []string
+	Quit            []string
+	Help            []string
+	Refresh         []string
+	PageSizeUp      []string
+	PageSizeDown    []string
+	ResetPage       []string
+	ClearSort       []string
+	SortFocused     []string
+	ThenSortFocused []string
+	Filter          []string
+	ClearFilters    []string
+	CopyCell        []string
+	CopyRow         []string
+	CopyColumn      []string
+	CopySelection   []string
+	CopyFormat      []string
+	ToggleMark      []string
+	MarkUp          []string
+	MarkDown        []string
+	MarkAll         []string
+	InvertMarks     []string
+	ClearMarks      []string
+	ToggleGroup     []string
+	GroupByFocused  []string
+	Pivot           []string
+	Unpivot         []string
+	Sort1           []string
+	Sort2           []string
+	Sort3           []string
+	Sort4           []string
+	Sort5           []string
+	Sort6           []string
+	Sort7           []string
+	Sort8           []string
+	Sort9           []string
+	ThenSort1       []string
+	ThenSort2       []string
+	ThenSort3       []string
+	ThenSort4       []string
+	ThenSort5       []string
+	ThenSort6       []string
+	ThenSort7       []string
+	ThenSort8       []string
+	ThenSort9       []string
 }
 
 // DefaultKeyBindings returns the default key bindings
 func DefaultKeyBindings() *KeyBindings {
 	return &KeyBindings{
-		Quit:         []string{"q", "esc", "ctrl+c"},
-		Up:           []string{"up", "k"},
-		Down:         []string{"down", "j"},
-		Left:         []string{"left", "h"},
-		Right:        []string{"right", "l"},
-		PageUp:       []string{"pageup", "pgup", "ctrl+b"},
-		PageDown:     []string{"pagedown", "pgdown", "ctrl+f"},
-		Home:         []string{"home", "g"},
-		End:          []string{"end", "G"},
-		Search:       []string{"/"},
-		Help:         []string{"?"},
-		Refresh:      []string{"r"},
-		PageSizeUp:   []string{"+", "="},
-		PageSizeDown: []string{"-", "_"},
-		ResetPage:    []string{"0"},
-		ClearSort:    []string{"c"},
-		Sort1:        []string{"1"},
-		Sort2:        []string{"2"},
-		Sort3:        []string{"3"},
-		Sort4:        []string{"4"},
-		Sort5:        []string{"5"},
-		Sort6:        []string{"6"},
-		Sort7:        []string{"7"},
-		Sort8:        []string{"8"},
-		Sort9:        []string{"9"},
+		Quit:            []string{"q", "esc", "ctrl+c"},
+		Up:              []string{"up", "k"},
+		Down:            []string{"down", "j"},
+		Left:            []string{"left", "h"},
+		Right:           []string{"right", "l"},
+		PageUp:          []string{"pageup", "pgup", "ctrl+b"},
+		PageDown:        []string{"pagedown", "pgdown", "ctrl+f"},
+		Home:            []string{"home", "g"},
+		End:             []string{"end", "G"},
+		Search:          []string{"/"},
+		Help:            []string{"?"},
+		Refresh:         []string{"r"},
+		PageSizeUp:      []string{"+", "="},
+		PageSizeDown:    []string{"-", "_"},
+		ResetPage:       []string{"0"},
+		ClearSort:       []string{"c"},
+		SortFocused:     []string{"s"},
+		ThenSortFocused: []string{"S"},
+		Filter:          []string{"f"},
+		ClearFilters:    []string{"F"},
+		CopyCell:        []string{"y"},
+		CopyRow:         []string{"Y"},
+		CopyColumn:      []string{"alt+y"},
+		CopySelection:   []string{"ctrl+y"},
+		CopyFormat:      []string{"ctrl+t"},
+		ToggleMark:      []string{" "},
+		MarkUp:          []string{"shift+up", "K"},
+		MarkDown:        []string{"shift+down", "J"},
+		MarkAll:         []string{"a"},
+		InvertMarks:     []string{"i"},
+		ClearMarks:      []string{"u"},
+		ToggleGroup:     []string{"enter", " "},
+		GroupByFocused:  []string{"b"},
+		Pivot:           []string{"p"},
+		Unpivot:         []string{"P"},
+		Sort1:           []string{"1"},
+		Sort2:           []string{"2"},
+		Sort3:           []string{"3"},
+		Sort4:           []string{"4"},
+		Sort5:           []string{"5"},
+		Sort6:           []string{"6"},
+		Sort7:           []string{"7"},
+		Sort8:           []string{"8"},
+		Sort9:           []string{"9"},
+		ThenSort1:       []string{"!"},
+		ThenSort2:       []string{"@"},
+		ThenSort3:       []string{"#"},
+		ThenSort4:       []string{"$"},
+		ThenSort5:       []string{"%"},
+		ThenSort6:       []string{"^"},
+		ThenSort7:       []string{"&"},
+		ThenSort8:       []string{"*"},
+		ThenSort9:       []string{"("},
 	}
 }
 
 // VimKeyBindings returns Vim-style key bindings
 func VimKeyBindings() *KeyBindings {
 	return &KeyBindings{
-		Quit:         []string{"q", "esc"},
-		Up:           []string{"k", "up"},
-		Down:         []string{"j", "down"},
-		Left:         []string{"h", "left"},
-		Right:        []string{"l", "right"},
-		PageUp:       []string{"ctrl+u", "pageup", "pgup"},
-		PageDown:     []string{"ctrl+d", "pagedown", "pgdown"},
-		Home:         []string{"gg", "home"},
-		End:          []string{"G", "end"},
-		Search:       []string{"/"},
-		Help:         []string{"?"},
-		Refresh:      []string{"r"},
-		PageSizeUp:   []string{"+"},
-		PageSizeDown: []string{"-"},
-		ResetPage:    []string{"0"},
-		ClearSort:    []string{"c"},
-		Sort1:        []string{"1"},
-		Sort2:        []string{"2"},
-		Sort3:        []string{"3"},
-		Sort4:        []string{"4"},
-		Sort5:        []string{"5"},
-		Sort6:        []string{"6"},
-		Sort7:        []string{"7"},
-		Sort8:        []string{"8"},
-		Sort9:        []string{"9"},
+		Quit:            []string{"q", "esc"},
+		Up:              []string{"k", "up"},
+		Down:            []string{"j", "down"},
+		Left:            []string{"h", "left"},
+		Right:           []string{"l", "right"},
+		PageUp:          []string{"ctrl+u", "pageup", "pgup"},
+		PageDown:        []string{"ctrl+d", "pagedown", "pgdown"},
+		Home:            []string{"gg", "home"},
+		End:             []string{"G", "end"},
+		Search:          []string{"/"},
+		Help:            []string{"?"},
+		Refresh:         []string{"r"},
+		PageSizeUp:      []string{"+"},
+		PageSizeDown:    []string{"-"},
+		ResetPage:       []string{"0"},
+		ClearSort:       []string{"c"},
+		SortFocused:     []string{"s"},
+		ThenSortFocused: []string{"S"},
+		Filter:          []string{"f"},
+		ClearFilters:    []string{"F"},
+		CopyCell:        []string{"y"},
+		CopyRow:         []string{"Y"},
+		CopyColumn:      []string{"alt+y"},
+		CopySelection:   []string{"ctrl+y"},
+		CopyFormat:      []string{"ctrl+t"},
+		ToggleMark:      []string{" "},
+		MarkUp:          []string{"shift+up", "K"},
+		MarkDown:        []string{"shift+down", "J"},
+		MarkAll:         []string{"a"},
+		InvertMarks:     []string{"i"},
+		ClearMarks:      []string{"u"},
+		ToggleGroup:     []string{"enter", " "},
+		GroupByFocused:  []string{"b"},
+		Pivot:           []string{"p"},
+		Unpivot:         []string{"P"},
+		Sort1:           []string{"1"},
+		Sort2:           []string{"2"},
+		Sort3:           []string{"3"},
+		Sort4:           []string{"4"},
+		Sort5:           []string{"5"},
+		Sort6:           []string{"6"},
+		Sort7:           []string{"7"},
+		Sort8:           []string{"8"},
+		Sort9:           []string{"9"},
+		ThenSort1:       []string{"!"},
+		ThenSort2:       []string{"@"},
+		ThenSort3:       []string{"#"},
+		ThenSort4:       []string{"$"},
+		ThenSort5:       []string{"%"},
+		ThenSort6:       []string{"^"},
+		ThenSort7:       []string{"&"},
+		ThenSort8:       []string{"*"},
+		ThenSort9:       []string{"("},
 	}
 }
 
 // EmacsKeyBindings returns Emacs-style key bindings
 func EmacsKeyBindings() *KeyBindings {
 	return &KeyBindings{
-		Quit:         []string{"ctrl+x ctrl+c", "ctrl+g"},
-		Up:           []string{"ctrl+p", "up"},
-		Down:         []string{"ctrl+n", "down"},
-		Left:         []string{"ctrl+b", "left"},
-		Right:        []string{"ctrl+f", "right"},
-		PageUp:       []string{"alt+v", "pageup", "pgup"},
-		PageDown:     []string{"ctrl+v", "pagedown", "pgdown"},
-		Home:         []string{"ctrl+a", "alt+<", "home"},
-		End:          []string{"ctrl+e", "alt+>", "end"},
-		Search:       []string{"ctrl+s"},
-		Help:         []string{"ctrl+h"},
-		Refresh:      []string{"ctrl+l"},
-		PageSizeUp:   []string{"ctrl++"},
-		PageSizeDown: []string{"ctrl+-"},
-		ResetPage:    []string{"ctrl+0"},
-		ClearSort:    []string{"ctrl+c"},
-		Sort1:        []string{"ctrl+1"},
-		Sort2:        []string{"ctrl+2"},
-		Sort3:        []string{"ctrl+3"},
-		Sort4:        []string{"ctrl+4"},
-		Sort5:        []string{"ctrl+5"},
-		Sort6:        []string{"ctrl+6"},
-		Sort7:        []string{"ctrl+7"},
-		Sort8:        []string{"ctrl+8"},
-		Sort9:        []string{"ctrl+9"},
+		Quit:            []string{"ctrl+x ctrl+c", "ctrl+g"},
+		Up:              []string{"ctrl+p", "up"},
+		Down:            []string{"ctrl+n", "down"},
+		Left:            []string{"ctrl+b", "left"},
+		Right:           []string{"ctrl+f", "right"},
+		PageUp:          []string{"alt+v", "pageup", "pgup"},
+		PageDown:        []string{"ctrl+v", "pagedown", "pgdown"},
+		Home:            []string{"ctrl+a", "alt+<", "home"},
+		End:             []string{"ctrl+e", "alt+>", "end"},
+		Search:          []string{"ctrl+s"},
+		Help:            []string{"ctrl+h"},
+		Refresh:         []string{"ctrl+l"},
+		PageSizeUp:      []string{"ctrl++"},
+		PageSizeDown:    []string{"ctrl+-"},
+		ResetPage:       []string{"ctrl+0"},
+		ClearSort:       []string{"ctrl+c"},
+		SortFocused:     []string{"alt+s"},
+		ThenSortFocused: []string{"alt+S"},
+		Filter:          []string{"ctrl+o"},
+		ClearFilters:    []string{"ctrl+k"},
+		CopyCell:        []string{"alt+w"},
+		CopyRow:         []string{"alt+W"},
+		CopyColumn:      []string{"alt+c"},
+		CopySelection:   []string{"ctrl+w"},
+		CopyFormat:      []string{"alt+t"},
+		ToggleMark:      []string{"ctrl+@", " "},
+		MarkUp:          []string{"shift+up"},
+		MarkDown:        []string{"shift+down"},
+		MarkAll:         []string{"alt+a"},
+		InvertMarks:     []string{"alt+i"},
+		ClearMarks:      []string{"alt+u"},
+		ToggleGroup:     []string{"enter", " "},
+		GroupByFocused:  []string{"alt+g"},
+		Pivot:           []string{"alt+p"},
+		Unpivot:         []string{"alt+P"},
+		Sort1:           []string{"ctrl+1"},
+		Sort2:           []string{"ctrl+2"},
+		Sort3:           []string{"ctrl+3"},
+		Sort4:           []string{"ctrl+4"},
+		Sort5:           []string{"ctrl+5"},
+		Sort6:           []string{"ctrl+6"},
+		Sort7:           []string{"ctrl+7"},
+		Sort8:           []string{"ctrl+8"},
+		Sort9:           []string{"ctrl+9"},
+		ThenSort1:       []string{"alt+1"},
+		ThenSort2:       []string{"alt+2"},
+		ThenSort3:       []string{"alt+3"},
+		ThenSort4:       []string{"alt+4"},
+		ThenSort5:       []string{"alt+5"},
+		ThenSort6:       []string{"alt+6"},
+		ThenSort7:       []string{"alt+7"},
+		ThenSort8:       []string{"alt+8"},
+		ThenSort9:       []string{"alt+9"},
 	}
 }
 
@@ -212,6 +324,104 @@ func (kb *KeyBindings) IsClearSort(key string) bool {
 	return kb.matchesKey(key, kb.ClearSort)
 }
 
+// IsSortFocused checks if the key cycles sorting on the focused column
+func (kb *KeyBindings) IsSortFocused(key string) bool {
+	return kb.matchesKey(key, kb.SortFocused)
+}
+
+// IsThenSortFocused checks if the key cycles the focused column as a
+// secondary sort key
+func (kb *KeyBindings) IsThenSortFocused(key string) bool {
+	return kb.matchesKey(key, kb.ThenSortFocused)
+}
+
+// IsFilter checks if the key opens the column filter row
+func (kb *KeyBindings) IsFilter(key string) bool {
+	return kb.matchesKey(key, kb.Filter)
+}
+
+// IsClearFilters checks if the key clears all column filters
+func (kb *KeyBindings) IsClearFilters(key string) bool {
+	return kb.matchesKey(key, kb.ClearFilters)
+}
+
+// IsCopyCell checks if the key copies the focused cell
+func (kb *KeyBindings) IsCopyCell(key string) bool {
+	return kb.matchesKey(key, kb.CopyCell)
+}
+
+// IsCopyRow checks if the key copies the selected row
+func (kb *KeyBindings) IsCopyRow(key string) bool {
+	return kb.matchesKey(key, kb.CopyRow)
+}
+
+// IsCopyColumn checks if the key copies the focused column
+func (kb *KeyBindings) IsCopyColumn(key string) bool {
+	return kb.matchesKey(key, kb.CopyColumn)
+}
+
+// IsCopySelection checks if the key copies the selected rows
+func (kb *KeyBindings) IsCopySelection(key string) bool {
+	return kb.matchesKey(key, kb.CopySelection)
+}
+
+// IsCopyFormat checks if the key cycles the clipboard format
+func (kb *KeyBindings) IsCopyFormat(key string) bool {
+	return kb.matchesKey(key, kb.CopyFormat)
+}
+
+// IsToggleMark checks if the key marks or unmarks the selected row
+func (kb *KeyBindings) IsToggleMark(key string) bool {
+	return kb.matchesKey(key, kb.ToggleMark)
+}
+
+// IsMarkUp checks if the key extends the marked range upwards
+func (kb *KeyBindings) IsMarkUp(key string) bool {
+	return kb.matchesKey(key, kb.MarkUp)
+}
+
+// IsMarkDown checks if the key extends the marked range downwards
+func (kb *KeyBindings) IsMarkDown(key string) bool {
+	return kb.matchesKey(key, kb.MarkDown)
+}
+
+// IsMarkAll checks if the key marks every row in view
+func (kb *KeyBindings) IsMarkAll(key string) bool {
+	return kb.matchesKey(key, kb.MarkAll)
+}
+
+// IsInvertMarks checks if the key inverts the marks of the rows in view
+func (kb *KeyBindings) IsInvertMarks(key string) bool {
+	return kb.matchesKey(key, kb.InvertMarks)
+}
+
+// IsClearMarks checks if the key unmarks every row
+func (kb *KeyBindings) IsClearMarks(key string) bool {
+	return kb.matchesKey(key, kb.ClearMarks)
+}
+
+// IsToggleGroup checks if the key collapses or expands the selected group
+func (kb *KeyBindings) IsToggleGroup(key string) bool {
+	return kb.matchesKey(key, kb.ToggleGroup)
+}
+
+// IsGroupByFocused checks if the key adds the focused column to the grouping,
+// or removes it if the rows are already grouped by it
+func (kb *KeyBindings) IsGroupByFocused(key string) bool {
+	return kb.matchesKey(key, kb.GroupByFocused)
+}
+
+// IsPivot checks if the key picks the focused column for a pivot table
+func (kb *KeyBindings) IsPivot(key string) bool {
+	return kb.matchesKey(key, kb.Pivot)
+}
+
+// IsUnpivot checks if the key restores the table from before a pivot, or
+// cancels a pivot being picked
+func (kb *KeyBindings) IsUnpivot(key string) bool {
+	return kb.matchesKey(key, kb.Unpivot)
+}
+
 // GetSortColumn returns the column index for sorting, or -1 if not a sort key
 func (kb *KeyBindings) GetSortColumn(key string) int {
 	sortKeys := map[int][]string{
@@ -233,3 +443,26 @@ func (kb *KeyBindings) GetSortColumn(key string) int {
 	}
 	return -1
 }
+
+// GetThenSortColumn returns the column index to add as a secondary sort key,
+// or -1 if the key is not a secondary sort key
+func (kb *KeyBindings) GetThenSortColumn(key string) int {
+	thenSortKeys := [][]string{
+		kb.ThenSort1,
+		kb.ThenSort2,
+		kb.ThenSort3,
+		kb.ThenSort4,
+		kb.ThenSort5,
+		kb.ThenSort6,
+		kb.ThenSort7,
+		kb.ThenSort8,
+		kb.ThenSort9,
+	}
+
+	for colIndex, keys := range thenSortKeys {
+		if kb.matchesKey(key, keys) {
+			return colIndex
+		}
+	}
+	return -1
+}
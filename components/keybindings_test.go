@@ -156,6 +156,110 @@ func TestSortColumns(t *testing.T) {
 	}
 }
 
+func TestThenSortColumns(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if kb.GetThenSortColumn("!") != 0 {
+		t.Error("Key '!' should map to column 0")
+	}
+	if kb.GetThenSortColumn("(") != 8 {
+		t.Error("Key '(' should map to column 8")
+	}
+	if kb.GetThenSortColumn("1") != -1 {
+		t.Error("Key '1' should not be a secondary sort key")
+	}
+}
+
+func TestSortFocusedKeys(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if !kb.IsSortFocused("s") {
+		t.Error("'s' should sort by the focused column")
+	}
+	if !kb.IsThenSortFocused("S") {
+		t.Error("'S' should add the focused column as a secondary sort key")
+	}
+}
+
+func TestFilterKeys(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if !kb.IsFilter("f") {
+		t.Error("'f' should open the filter row")
+	}
+	if !kb.IsClearFilters("F") {
+		t.Error("'F' should clear column filters")
+	}
+	if kb.IsFilter("F") {
+		t.Error("'F' should not open the filter row")
+	}
+}
+
+func TestCopyKeys(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if !kb.IsCopyCell("y") || !kb.IsCopyRow("Y") || !kb.IsCopyColumn("alt+y") || !kb.IsCopySelection("ctrl+y") {
+		t.Error("Default bindings should copy with y, Y, alt+y and ctrl+y")
+	}
+	if !kb.IsCopyFormat("ctrl+t") {
+		t.Error("'ctrl+t' should cycle the copy format")
+	}
+	if kb.IsCopyCell("Y") {
+		t.Error("'Y' should not copy a cell")
+	}
+
+	emacs := EmacsKeyBindings()
+	if !emacs.IsCopyCell("alt+w") || !emacs.IsCopySelection("ctrl+w") {
+		t.Error("Emacs bindings should copy with alt+w and ctrl+w")
+	}
+}
+
+func TestMarkKeys(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if !kb.IsToggleMark(" ") {
+		t.Error("Space should toggle a mark")
+	}
+	if !kb.IsMarkUp("shift+up") || !kb.IsMarkDown("shift+down") {
+		t.Error("Shift+up/down should extend marks")
+	}
+	if !kb.IsMarkAll("a") || !kb.IsInvertMarks("i") || !kb.IsClearMarks("u") {
+		t.Error("'a', 'i' and 'u' should mark all, invert and clear marks")
+	}
+	if kb.IsToggleMark("a") {
+		t.Error("'a' should not toggle a mark")
+	}
+}
+
+func TestGroupKeys(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if !kb.IsToggleGroup("enter") || !kb.IsToggleGroup(" ") {
+		t.Error("Enter and space should toggle a group")
+	}
+	if !kb.IsGroupByFocused("b") {
+		t.Error("'b' should group by the focused column")
+	}
+
+	emacs := EmacsKeyBindings()
+	if !emacs.IsGroupByFocused("alt+g") {
+		t.Error("Emacs bindings should group with alt+g")
+	}
+}
+
+func TestPivotKeys(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if !kb.IsPivot("p") || !kb.IsUnpivot("P") {
+		t.Error("'p' should pivot and 'P' should unpivot")
+	}
+
+	emacs := EmacsKeyBindings()
+	if !emacs.IsPivot("alt+p") || !emacs.IsUnpivot("alt+P") {
+		t.Error("Emacs bindings should pivot with alt+p and unpivot with alt+P")
+	}
+}
+
 func TestPageSizeKeys(t *testing.T) {
 	kb := DefaultKeyBindings()
 
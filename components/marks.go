@@ -0,0 +1,201 @@
+package components
+
+import (
+	"sort"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+// WithMarking enables or disables marking rows. While marking is enabled,
+// as it is by default, the table shows a marks column, so the columns do not
+// shift when the first row is marked.
+func (m *TableModel) WithMarking(enabled bool) *TableModel {
+	m.noMarking = !enabled
+	if !enabled {
+		m.marks = nil
+	}
+	return m
+}
+
+// WithOnAction sets a callback for named batch actions, such as "delete" or
+// "archive", that receives the marked rows, or the selected row if none are
+// marked. Bind keys to actions with WithAction.
+func (m *TableModel) WithOnAction(callback func(action string, rows []table.Row)) *TableModel {
+	m.onAction = callback
+	return m
+}
+
+// WithAction binds a key to a named action passed to the WithOnAction
+// callback. Action keys take precedence over the model's own key bindings.
+func (m *TableModel) WithAction(key, action string) *TableModel {
+	if m.actions == nil {
+		m.actions = make(map[string]string)
+	}
+	m.actions[key] = action
+	return m
+}
+
+// RunAction runs a named action on the marked rows, or on the selected row
+// if none are marked. Marks are kept; call ClearMarks once the action is
+// done with them.
+func (m *TableModel) RunAction(action string) {
+	if m.onAction == nil {
+		return
+	}
+
+	rows := m.GetMarkedRows()
+	if len(rows) == 0 {
+		if row, ok := m.GetSelectedRow(); ok {
+			rows = []table.Row{row}
+		}
+	}
+	if len(rows) > 0 {
+		m.onAction(action, rows)
+	}
+}
+
+// GetMarkedRows returns the marked rows in the table's current sort order,
+// including marked rows that the search or column filters hide. Rows of a
+// data source are returned in ID order.
+func (m *TableModel) GetMarkedRows() []table.Row {
+	if len(m.marks) == 0 || m.table == nil {
+		return nil
+	}
+
+	var rows []table.Row
+	if m.table.Source() != nil {
+		for _, row := range m.marks {
+			rows = append(rows, row)
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+		return rows
+	}
+
+	// Take rows from the table so they reflect edits since they were marked
+	for _, row := range m.table.Rows {
+		if _, ok := m.marks[row.ID]; ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// IsMarked reports whether a row is marked
+func (m *TableModel) IsMarked(row table.Row) bool {
+	_, ok := m.marks[row.ID]
+	return ok
+}
+
+// SetMarked marks or unmarks a row. Group header rows cannot be marked, and
+// no rows can be while marking is disabled.
+func (m *TableModel) SetMarked(row table.Row, marked bool) {
+	if row.Group != nil || m.noMarking {
+		return
+	}
+	if !marked {
+		delete(m.marks, row.ID)
+		return
+	}
+	if m.marks == nil {
+		m.marks = make(map[int]table.Row)
+	}
+	m.marks[row.ID] = row
+}
+
+// ClearMarks unmarks every row
+func (m *TableModel) ClearMarks() {
+	m.marks = nil
+}
+
+// handleMarkKeys handles marking key presses
+func (m *TableModel) handleMarkKeys(key string) bool {
+	if m.noMarking {
+		return false
+	}
+
+	switch {
+	case m.keyBindings.IsToggleMark(key):
+		if row, ok := m.GetSelectedRow(); ok {
+			m.SetMarked(row, !m.IsMarked(row))
+		}
+		return true
+
+	case m.keyBindings.IsMarkUp(key):
+		m.extendMarks(-1)
+		return true
+
+	case m.keyBindings.IsMarkDown(key):
+		m.extendMarks(1)
+		return true
+
+	case m.keyBindings.IsMarkAll(key):
+		for _, row := range m.viewRows() {
+			m.SetMarked(row, true)
+		}
+		return true
+
+	case m.keyBindings.IsInvertMarks(key):
+		for _, row := range m.viewRows() {
+			m.SetMarked(row, !m.IsMarked(row))
+		}
+		return true
+
+	case m.keyBindings.IsClearMarks(key):
+		m.ClearMarks()
+		return true
+	}
+
+	return false
+}
+
+// extendMarks marks the selected row, moves the selection by step within the
+// page and marks the row it lands on, extending a range of marked rows
+func (m *TableModel) extendMarks(step int) {
+	row, ok := m.GetSelectedRow()
+	if !ok {
+		return
+	}
+	m.SetMarked(row, true)
+
+	pageSize := len(m.getCurrentTable().GetPage(m.currentPage))
+	if next := m.selectedRow + step; next >= 0 && next < pageSize {
+		m.selectedRow = next
+	}
+	if row, ok := m.GetSelectedRow(); ok {
+		m.SetMarked(row, true)
+	}
+}
+
+// viewRows returns every data row in the current view, or the current page
+// for tables backed by a data source, whose other rows are not loaded. Rows
+// in collapsed groups are included; group headers are not.
+func (m *TableModel) viewRows() []table.Row {
+	currentTable := m.getCurrentTable()
+	if currentTable == nil {
+		return nil
+	}
+	if currentTable.Source() != nil {
+		return currentTable.GetPage(m.currentPage)
+	}
+	if m.groupedTable != nil {
+		// List the rows the groups were built from
+		currentTable = m.table
+		if m.filteredTable != nil {
+			currentTable = m.filteredTable
+		}
+	}
+	return currentTable.CurrentRows()
+}
+
+// markedIDs returns the IDs of the marked rows for the renderer, or nil to
+// hide the marks column while marking is disabled
+func (m *TableModel) markedIDs() map[int]bool {
+	if m.noMarking {
+		return nil
+	}
+	ids := make(map[int]bool, len(m.marks))
+	for id := range m.marks {
+		ids[id] = true
+	}
+	return ids
+}
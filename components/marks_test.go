@@ -0,0 +1,197 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anurag-roy/bubbletable/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// markedNames returns the names of the marked rows
+func markedNames(model *TableModel) string {
+	var names []string
+	for _, row := range model.GetMarkedRows() {
+		names = append(names, row.Cells[1].Value.(string))
+	}
+	return strings.Join(names, ",")
+}
+
+func TestToggleMarks(t *testing.T) {
+	model := newReadyModel([]TestEmployee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Carol"},
+		{ID: 4, Name: "Dave"},
+		{ID: 5, Name: "Eve"},
+	})
+
+	model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if got := markedNames(model); got != "Alice,Carol" {
+		t.Errorf("Expected Alice and Carol marked, got %q", got)
+	}
+
+	// Space again unmarks
+	model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if got := markedNames(model); got != "Alice" {
+		t.Errorf("Expected only Alice marked, got %q", got)
+	}
+
+	if !strings.Contains(model.View(), "[x]") || !strings.Contains(model.renderStatusBar(), "Marked: 1") {
+		t.Error("Expected the marks column and count to be shown")
+	}
+}
+
+func TestMarksColumnReserved(t *testing.T) {
+	model := newReadyModel([]TestEmployee{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}})
+
+	// The column is there before the first mark, so marking does not shift
+	// the other columns
+	before := model.View()
+	if !strings.Contains(before, "[ ]") {
+		t.Fatal("Expected the marks column while marking is enabled")
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if after := model.View(); strings.Index(after, "Alice") != strings.Index(before, "Alice") {
+		t.Error("Expected the first mark to leave the columns in place")
+	}
+
+	model.WithMarking(false)
+	model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if strings.Contains(model.View(), "[ ]") || len(model.GetMarkedRows()) != 0 {
+		t.Error("Expected no marks or marks column with marking disabled")
+	}
+}
+
+func TestRangeMarks(t *testing.T) {
+	model := newReadyModel([]TestEmployee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Carol"},
+		{ID: 4, Name: "Dave"},
+		{ID: 5, Name: "Eve"},
+	})
+
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	if got := markedNames(model); got != "Bob,Carol,Dave" {
+		t.Errorf("Expected Bob to Dave marked, got %q", got)
+	}
+
+	// Extending upwards past the first row stops there
+	model.Update(tea.KeyMsg{Type: tea.KeyHome})
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	if got := markedNames(model); got != "Alice,Bob,Carol,Dave" {
+		t.Errorf("Expected Alice added, got %q", got)
+	}
+}
+
+func TestMarkAllAndInvert(t *testing.T) {
+	model := newReadyModel([]TestEmployee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Carol"},
+		{ID: 4, Name: "Dave"},
+		{ID: 5, Name: "Eve"},
+	})
+
+	// Mark all and invert only touch rows in view
+	model.searchTerm = "a" // Alice, Carol, Dave
+	model.updateSearch()
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if got := markedNames(model); got != "Alice,Carol,Dave" {
+		t.Errorf("Expected the rows in view marked, got %q", got)
+	}
+
+	model.searchTerm = ""
+	model.updateSearch()
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if got := markedNames(model); got != "Bob,Eve" {
+		t.Errorf("Expected the marks inverted, got %q", got)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if got := model.GetMarkedRows(); len(got) != 0 {
+		t.Errorf("Expected marks cleared, got %d", len(got))
+	}
+}
+
+func TestMarksFollowRowsAcrossSortAndFilter(t *testing.T) {
+	model := newReadyModel([]TestEmployee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Carol"},
+		{ID: 4, Name: "Dave"},
+		{ID: 5, Name: "Eve"},
+	})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace}) // Alice
+
+	// Sort descending by name; Alice moves to the end but stays marked
+	model.GetTable().SortByColumn(1, true)
+	if row, _ := model.GetSelectedRow(); model.IsMarked(row) {
+		t.Errorf("Expected %v at the top to be unmarked", row.Cells[1].Value)
+	}
+	if got := markedNames(model); got != "Alice" {
+		t.Errorf("Expected Alice still marked, got %q", got)
+	}
+
+	// Marks hidden by a filter are kept and still returned
+	model.searchTerm = "bob"
+	model.updateSearch()
+	model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if got := markedNames(model); got != "Bob,Alice" {
+		t.Errorf("Expected Bob and the hidden Alice in sort order, got %q", got)
+	}
+}
+
+func TestBatchActions(t *testing.T) {
+	var gotAction string
+	var gotRows []table.Row
+	model := newReadyModel([]TestEmployee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Carol"},
+		{ID: 4, Name: "Dave"},
+		{ID: 5, Name: "Eve"},
+	}).
+		WithOnAction(func(action string, rows []table.Row) {
+			gotAction, gotRows = action, rows
+		}).
+		WithAction("d", "delete")
+
+	// Without marks the selected row is used
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if gotAction != "delete" || len(gotRows) != 1 || gotRows[0].ID != 0 {
+		t.Errorf("Expected delete of the selected row, got %q %v", gotAction, gotRows)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	model.RunAction("archive")
+	if gotAction != "archive" || len(gotRows) != 5 {
+		t.Errorf("Expected archive of the 5 marked rows, got %q with %d rows", gotAction, len(gotRows))
+	}
+
+	// Copying a selection copies the marked rows
+	var copied string
+	model.WithClipboardHandler(func(text string) error {
+		copied = text
+		return nil
+	})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}}) // Unmark all
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	pressKey(model, tea.KeyMsg{Type: tea.KeyCtrlY})
+	if copied != "ID\tName\n2\tBob" || model.statusMsg != "✓ Copied 1 marked row as TSV" {
+		t.Errorf("Expected the marked row copied, got %q (%q)", copied, model.statusMsg)
+	}
+
+	// New data clears marks
+	_ = model.SetData([]TestEmployee{{ID: 9, Name: "Zed"}})
+	if len(model.GetMarkedRows()) != 0 {
+		t.Error("Expected SetData to clear marks")
+	}
+}
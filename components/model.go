@@ -1,8 +1,12 @@
 package components
 
 import (
+	"database/sql"
 	"fmt"
+	"io"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/anurag-roy/bubbletable/renderer"
 	"github.com/anurag-roy/bubbletable/table"
@@ -19,8 +23,48 @@ type TableModel struct {
 	ready       bool
 	currentPage int
 	selectedRow int
+	selectedCol int
 	searchMode  bool
 	searchTerm  string
+	searchErr   error
+	searchText  []string // Terms searched as plain text (see table.QueryTextTerms)
+	fuzzySearch bool
+
+	// Column filters
+	filterMode    bool
+	filterColumn  int
+	filterInputs  map[int]string
+	columnFilters map[int]table.ColumnFilter
+	filterErr     error
+
+	// Lazy loading from a DataSource
+	loading      bool
+	pendingFetch *table.PageQuery
+	sourceErr    error
+
+	// Grouping: the group view of the filtered rows, and collapsed group paths
+	groupedTable *table.Table
+	collapsed    map[string]bool
+
+	// Pivoting: the table from before the pivot, and the column keys picked
+	// so far for the next one
+	unpivoted *table.Table
+	pivotKeys []string
+
+	// Mutations: the table subscribed to, and how to unsubscribe from it
+	watched     *table.Table
+	unsubscribe func()
+
+	// Marked rows, keyed by Row.ID so marks follow rows across sort and filter
+	marks     map[int]table.Row
+	actions   map[string]string // Key to action name
+	noMarking bool              // Rows cannot be marked (see WithMarking)
+
+	// Clipboard
+	copyFormat       CopyFormat
+	clipboardHandler ClipboardHandler
+	clipboardOut     io.Writer // OSC52 output; os.Stderr if nil
+	statusMsg        string    // Shown in the status bar until the next key press
 
 	// Configuration
 	keyBindings *KeyBindings
@@ -33,6 +77,7 @@ type TableModel struct {
 	onSort    func(columnIndex int, desc bool)
 	onSearch  func(term string)
 	onRefresh func()
+	onAction  func(action string, rows []table.Row)
 
 	// Dimensions
 	width  int
@@ -101,6 +146,60 @@ func NewTableWithColumns(data []map[string]interface{}, columns []table.Column)
 	}
 }
 
+// NewTableFromSource creates a table model that pages lazily through a data
+// source, fetching each page in the background as it comes into view. An
+// error reading the source's schema is shown in the status bar.
+func NewTableFromSource(src table.DataSource) *TableModel {
+	tbl := table.New()
+	err := tbl.SetSource(src)
+
+	m := newTableModel(tbl)
+	m.sourceErr = err
+	return m
+}
+
+// NewTableFromQuery runs a query and creates a table model from its result,
+// keeping the result's column order and types (see table.FromSQLRows)
+func NewTableFromQuery(db *sql.DB, query string, args ...interface{}) (*TableModel, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tbl, err := table.FromSQLRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	return newTableModel(tbl), nil
+}
+
+// NewTableFromJSON reads a JSON array of objects and creates a table model
+// from it, flattening nested objects into dotted columns (see table.ReadJSON)
+func NewTableFromJSON(r io.Reader) (*TableModel, error) {
+	tbl, err := table.ReadJSON(r, table.JSONOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return newTableModel(tbl), nil
+}
+
+// newTableModel creates a table model with default settings around a table
+func newTableModel(tbl *table.Table) *TableModel {
+	return &TableModel{
+		table:       tbl,
+		renderer:    renderer.NewTableRenderer(80, 24),
+		keyBindings: DefaultKeyBindings(),
+		theme:       renderer.DefaultTheme,
+		pageSize:    10,
+	}
+}
+
+// PageLoadedMsg delivers a page fetched from a table's DataSource
+type PageLoadedMsg struct {
+	Result table.PageResult
+}
+
 // Builder pattern methods for configuration
 
 // WithPageSize sets the page size
@@ -147,6 +246,62 @@ func (m *TableModel) WithSearch(enabled bool) *TableModel {
 	return m
 }
 
+// WithFuzzySearch enables or disables fuzzy search, which ranks rows by
+// match score and highlights the matched characters
+func (m *TableModel) WithFuzzySearch(enabled bool) *TableModel {
+	m.fuzzySearch = enabled
+	return m
+}
+
+// WithHorizontalScroll enables horizontal scrolling, which keeps each
+// column's declared width and scrolls to follow the focused column
+func (m *TableModel) WithHorizontalScroll(enabled bool) *TableModel {
+	if m.renderer != nil {
+		m.renderer.SetHorizontalScroll(enabled)
+	}
+	return m
+}
+
+// WithWidthStrategy sets how column widths are fitted to the terminal
+func (m *TableModel) WithWidthStrategy(strategy renderer.WidthStrategy) *TableModel {
+	if m.renderer != nil {
+		m.renderer.SetWidthStrategy(strategy)
+	}
+	return m
+}
+
+// WithFrozenColumns keeps the first n columns visible while scrolling
+// horizontally
+func (m *TableModel) WithFrozenColumns(n int) *TableModel {
+	if m.renderer != nil {
+		m.renderer.SetFrozenColumns(n)
+	}
+	return m
+}
+
+// WithBorder sets how the table frame is drawn, such as renderer.RoundedBorder
+func (m *TableModel) WithBorder(border renderer.TableBorder) *TableModel {
+	if m.renderer != nil {
+		m.renderer.SetBorder(border)
+	}
+	return m
+}
+
+// WithFooter shows a footer row of column aggregates under the rows, over
+// the current view or the whole table (see table.Column.Aggregate)
+func (m *TableModel) WithFooter(scope table.FooterScope) *TableModel {
+	if m.table != nil {
+		m.table.Footer = scope
+	}
+	if m.filteredTable != nil {
+		m.filteredTable.Footer = scope
+	}
+	if m.groupedTable != nil {
+		m.groupedTable.Footer = scope
+	}
+	return m
+}
+
 // WithOnSelect sets a callback for row selection
 func (m *TableModel) WithOnSelect(callback func(row table.Row)) *TableModel {
 	m.onSelect = callback
@@ -173,13 +328,25 @@ func (m *TableModel) WithOnRefresh(callback func()) *TableModel {
 
 // Bubble Tea interface implementation
 
-// Init initializes the model
+// Init initializes the model, fetching the first page of a data source
 func (m *TableModel) Init() tea.Cmd {
-	return nil
+	return m.fetchPage()
 }
 
 // Update handles messages and updates the model
 func (m *TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	m.paginate()
+	if fetch := m.fetchPage(); fetch != nil {
+		cmd = tea.Batch(cmd, fetch)
+	}
+	return model, cmd
+}
+
+// update handles a message before the current table is re-paginated
+func (m *TableModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.watchTable()
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -192,7 +359,7 @@ func (m *TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Optimize page size for terminal
 		if m.pageSize == 10 { // Only adjust if using default
-			optimalSize := m.renderer.GetOptimalPageSize()
+			optimalSize := m.optimalPageSize()
 			m.pageSize = optimalSize
 			if m.table != nil {
 				m.table.PageSize = optimalSize
@@ -203,25 +370,106 @@ func (m *TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
+
+	case PageLoadedMsg:
+		m.handlePageLoaded(msg.Result)
+		return m, nil
+
+	case CopiedMsg:
+		m.handleCopied(msg)
+		return m, nil
 	}
 
 	return m, nil
 }
 
+// fetchPage returns a command fetching the current page from the table's
+// data source, or nil if the page is loaded or already being fetched
+func (m *TableModel) fetchPage() tea.Cmd {
+	currentTable := m.getCurrentTable()
+	if currentTable == nil || currentTable.Source() == nil || currentTable.PageLoaded(m.currentPage) {
+		return nil
+	}
+
+	query := currentTable.PageQuery(m.currentPage)
+	if m.pendingFetch != nil && m.pendingFetch.Equal(query) {
+		return nil
+	}
+	m.pendingFetch = &query
+	m.loading = true
+
+	src := currentTable.Source()
+	return func() tea.Msg {
+		return PageLoadedMsg{Result: table.FetchPage(src, query)}
+	}
+}
+
+// handlePageLoaded stores a fetched page, ignoring results that the sort,
+// filter or page size have since made stale
+func (m *TableModel) handlePageLoaded(result table.PageResult) {
+	currentTable := m.getCurrentTable()
+	if currentTable == nil {
+		return
+	}
+
+	if m.pendingFetch == nil || !m.pendingFetch.Equal(result.Query) {
+		// An earlier fetch can still fill in a page the user returns to
+		currentTable.StorePage(result)
+		return
+	}
+
+	m.loading = false
+	if result.Err != nil {
+		// Leave the fetch pending so it is only retried on refresh
+		m.sourceErr = result.Err
+		return
+	}
+	m.pendingFetch = nil
+	m.sourceErr = nil
+	currentTable.StorePage(result)
+}
+
 // handleKeyPress is split into smaller functions to reduce complexity
 func (m *TableModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
+	m.statusMsg = ""
 
 	// Handle search mode input
 	if m.searchMode {
 		return m.handleSearchInput(key)
 	}
 
+	// Handle column filter input
+	if m.filterMode {
+		return m.handleFilterInput(key)
+	}
+
 	// Handle help mode - only allow help and quit keys
 	if m.showHelp {
 		return m.handleHelpInput(key)
 	}
 
+	if action, ok := m.actions[key]; ok {
+		m.RunAction(action)
+		return m, nil
+	}
+
+	if handled, cmd := m.handleCopyKeys(key); handled {
+		return m, cmd
+	}
+
+	if m.handleGroupKeys(key) {
+		return m, nil
+	}
+
+	if m.handlePivotKeys(key) {
+		return m, nil
+	}
+
+	if m.handleMarkKeys(key) {
+		return m, nil
+	}
+
 	// Handle different key categories
 	if handled, model := m.handleNavigationKeys(key); handled {
 		return model, nil
@@ -287,7 +535,21 @@ func (m *TableModel) handleVerticalNavigation(key string) (bool, tea.Model) {
 
 // handleHorizontalNavigation handles left, right, page up, and page down key presses
 func (m *TableModel) handleHorizontalNavigation(key string) (bool, tea.Model) {
-	if m.keyBindings.IsLeft(key) || m.keyBindings.IsPageUp(key) {
+	if m.keyBindings.IsLeft(key) {
+		if m.selectedCol > 0 {
+			m.selectedCol--
+		}
+		return true, m
+	}
+
+	if m.keyBindings.IsRight(key) {
+		if m.table != nil && m.selectedCol < len(m.table.Columns)-1 {
+			m.selectedCol++
+		}
+		return true, m
+	}
+
+	if m.keyBindings.IsPageUp(key) {
 		if m.currentPage > 0 {
 			m.currentPage--
 			m.selectedRow = 0
@@ -295,7 +557,7 @@ func (m *TableModel) handleHorizontalNavigation(key string) (bool, tea.Model) {
 		return true, m
 	}
 
-	if m.keyBindings.IsRight(key) || m.keyBindings.IsPageDown(key) {
+	if m.keyBindings.IsPageDown(key) {
 		currentTable := m.getCurrentTable()
 		if currentTable != nil && m.currentPage < currentTable.GetTotalPages()-1 {
 			m.currentPage++
@@ -341,7 +603,32 @@ func (m *TableModel) handleActionKeys(key string) (bool, tea.Model) {
 		m.showHelp = !m.showHelp
 		return true, m
 
+	case m.keyBindings.IsFilter(key):
+		if m.table != nil && m.selectedCol < len(m.table.Columns) {
+			m.filterMode = true
+			m.filterColumn = m.selectedCol
+		}
+		return true, m
+
+	case m.keyBindings.IsClearFilters(key):
+		m.filterInputs = nil
+		m.columnFilters = nil
+		m.filterErr = nil
+		m.applyFilters()
+		m.currentPage = 0
+		m.selectedRow = 0
+		return true, m
+
 	case m.keyBindings.IsRefresh(key):
+		if m.table != nil && m.table.Source() != nil {
+			// Fetch the page again, along with a fresh row count
+			m.table.Invalidate()
+			if m.filteredTable != nil {
+				m.filteredTable.Invalidate()
+			}
+			m.pendingFetch = nil
+			m.sourceErr = nil
+		}
 		if m.onRefresh != nil {
 			m.onRefresh()
 		}
@@ -359,13 +646,14 @@ func (m *TableModel) handleActionKeys(key string) (bool, tea.Model) {
 
 	case m.keyBindings.IsResetPage(key):
 		if m.renderer != nil {
-			m.adjustPageSize(m.renderer.GetOptimalPageSize())
+			m.adjustPageSize(m.optimalPageSize())
 		}
 		return true, m
 
 	case m.keyBindings.IsClearSort(key):
 		if m.table != nil {
 			m.table.ClearSort()
+			m.applyFilters()
 			m.currentPage = 0
 			m.selectedRow = 0
 		}
@@ -377,32 +665,85 @@ func (m *TableModel) handleActionKeys(key string) (bool, tea.Model) {
 
 // handleSortKeys handles sorting key presses
 func (m *TableModel) handleSortKeys(key string) (bool, tea.Model) {
-	if colIndex := m.keyBindings.GetSortColumn(key); colIndex >= 0 && m.table != nil {
-		if colIndex < len(m.table.Columns) {
-			// Three-state sorting: unsorted -> asc -> desc -> unsorted
-			if m.table.SortBy == colIndex {
-				if !m.table.SortDesc {
-					_ = m.table.SortByColumn(colIndex, true) // Ignore error
-				} else {
-					m.table.ClearSort()
-				}
-			} else {
-				_ = m.table.SortByColumn(colIndex, false) // Ignore error
-			}
+	if m.table == nil {
+		return false, m
+	}
 
-			m.currentPage = 0
-			m.selectedRow = 0
+	switch {
+	case m.keyBindings.IsSortFocused(key):
+		m.cycleSort(m.selectedCol)
+		return true, m
 
-			if m.onSort != nil {
-				m.onSort(colIndex, m.table.SortDesc)
-			}
-		}
+	case m.keyBindings.IsThenSortFocused(key):
+		m.cycleThenSort(m.selectedCol)
+		return true, m
+	}
+
+	if colIndex := m.keyBindings.GetSortColumn(key); colIndex >= 0 {
+		m.cycleSort(colIndex)
+		return true, m
+	}
+
+	if colIndex := m.keyBindings.GetThenSortColumn(key); colIndex >= 0 {
+		m.cycleThenSort(colIndex)
 		return true, m
 	}
 
 	return false, m
 }
 
+// cycleSort makes a column the only sort key, cycling
+// unsorted -> asc -> desc -> unsorted
+func (m *TableModel) cycleSort(colIndex int) {
+	if colIndex < 0 || colIndex >= len(m.table.Columns) {
+		return
+	}
+
+	if m.table.SortBy == colIndex {
+		if !m.table.SortDesc {
+			_ = m.table.SortByColumn(colIndex, true) // Ignore error
+		} else {
+			m.table.ClearSort()
+		}
+	} else {
+		_ = m.table.SortByColumn(colIndex, false) // Ignore error
+	}
+
+	m.afterSort(colIndex)
+}
+
+// cycleThenSort applies the same three-state cycle to a secondary key in
+// the sort chain
+func (m *TableModel) cycleThenSort(colIndex int) {
+	if colIndex < 0 || colIndex >= len(m.table.Columns) {
+		return
+	}
+
+	if sortKey, ok := m.table.GetSortKey(colIndex); !ok {
+		_ = m.table.AddSortKey(colIndex, false) // Ignore error
+	} else if !sortKey.Desc {
+		_ = m.table.AddSortKey(colIndex, true) // Ignore error
+	} else {
+		_ = m.table.RemoveSortKey(colIndex) // Ignore error
+	}
+
+	m.afterSort(colIndex)
+}
+
+// afterSort resets the view after the sort spec changes
+func (m *TableModel) afterSort(colIndex int) {
+	m.currentPage = 0
+	m.selectedRow = 0
+
+	// Re-apply the active search so the filtered view reflects the new order
+	m.applyFilters()
+
+	if m.onSort != nil {
+		sortKey, _ := m.table.GetSortKey(colIndex)
+		m.onSort(colIndex, sortKey.Desc)
+	}
+}
+
 // triggerSelectionCallback triggers the selection callback if configured
 func (m *TableModel) triggerSelectionCallback() {
 	if m.onSelect != nil {
@@ -422,33 +763,123 @@ func (m *TableModel) handleSearchInput(key string) (tea.Model, tea.Cmd) {
 	case "esc":
 		m.searchMode = false
 		m.searchTerm = ""
-		m.filteredTable = nil
+		m.searchErr = nil
+		m.applyFilters()
 		m.currentPage = 0
 		m.selectedRow = 0
 
 	case "backspace":
-		if m.searchTerm != "" {
-			m.searchTerm = m.searchTerm[:len(m.searchTerm)-1]
+		if input := []rune(m.searchTerm); len(input) > 0 {
+			m.searchTerm = string(input[:len(input)-1])
 			m.updateSearch()
 		}
 
 	case "enter":
 		m.searchMode = false
 
+	case "tab":
+		m.fuzzySearch = !m.fuzzySearch
+		m.updateSearch()
+
 	default:
 		// Handle character input
-		if len(key) == 1 {
-			char := key[0]
-			if char >= 32 && char <= 126 { // Printable ASCII
-				m.searchTerm += key
-				m.updateSearch()
+		if isPrintableKey(key) {
+			m.searchTerm += key
+			m.updateSearch()
+		}
+	}
+
+	return m, nil
+}
+
+// handleFilterInput handles input while editing the column filter row
+func (m *TableModel) handleFilterInput(key string) (tea.Model, tea.Cmd) {
+	numColumns := len(m.table.Columns)
+
+	switch key {
+	case "esc", "enter":
+		m.filterMode = false
+		m.selectedCol = m.filterColumn
+
+	case "tab", "right":
+		m.filterColumn = (m.filterColumn + 1) % numColumns
+		m.filterErr = nil
+
+	case "shift+tab", "left":
+		m.filterColumn = (m.filterColumn - 1 + numColumns) % numColumns
+		m.filterErr = nil
+
+	case "backspace":
+		if input := []rune(m.filterInputs[m.filterColumn]); len(input) > 0 {
+			m.setFilterInput(string(input[:len(input)-1]))
+		}
+
+	case " ":
+		if m.table.Columns[m.filterColumn].Type == table.Boolean {
+			// Tri-state toggle: any -> true -> false -> any
+			switch m.filterInputs[m.filterColumn] {
+			case "":
+				m.setFilterInput("true")
+			case "true":
+				m.setFilterInput("false")
+			default:
+				m.setFilterInput("")
 			}
+		} else {
+			m.setFilterInput(m.filterInputs[m.filterColumn] + key)
+		}
+
+	default:
+		// Handle character input
+		if isPrintableKey(key) {
+			m.setFilterInput(m.filterInputs[m.filterColumn] + key)
 		}
 	}
 
 	return m, nil
 }
 
+// isPrintableKey reports whether a key is a single printable character,
+// including non-ASCII input such as CJK text
+func isPrintableKey(key string) bool {
+	r, size := utf8.DecodeRuneInString(key)
+	return size > 0 && size == len(key) && unicode.IsPrint(r)
+}
+
+// setFilterInput updates the focused column's filter text and re-filters.
+// Input that does not parse for the column's type keeps the previous filter
+// and records the error for the filter bar.
+func (m *TableModel) setFilterInput(input string) {
+	if m.filterInputs == nil {
+		m.filterInputs = make(map[int]string)
+	}
+	m.filterInputs[m.filterColumn] = input
+
+	if m.table.Source() != nil {
+		m.filterErr = fmt.Errorf("column filters are not supported for data sources")
+		return
+	}
+
+	filter, err := table.ParseColumnFilter(m.table.Columns[m.filterColumn], input)
+	m.filterErr = err
+	if err != nil {
+		return
+	}
+
+	if m.columnFilters == nil {
+		m.columnFilters = make(map[int]table.ColumnFilter)
+	}
+	if filter == nil {
+		delete(m.columnFilters, m.filterColumn)
+	} else {
+		m.columnFilters[m.filterColumn] = filter
+	}
+
+	m.applyFilters()
+	m.currentPage = 0
+	m.selectedRow = 0
+}
+
 // handleHelpInput handles input during help mode
 func (m *TableModel) handleHelpInput(key string) (tea.Model, tea.Cmd) {
 	switch {
@@ -471,11 +902,7 @@ func (m *TableModel) updateSearch() {
 		return
 	}
 
-	if m.searchTerm == "" {
-		m.filteredTable = nil
-	} else {
-		m.filteredTable = m.table.Filter(m.searchTerm)
-	}
+	m.applyFilters()
 
 	m.currentPage = 0
 	m.selectedRow = 0
@@ -485,6 +912,46 @@ func (m *TableModel) updateSearch() {
 	}
 }
 
+// applyFilters narrows the table by the column filters and then by the
+// current search query, then groups the result. An invalid query keeps the
+// last valid results on screen and records the parse error so the search
+// bar can report it.
+func (m *TableModel) applyFilters() {
+	if m.table == nil {
+		return
+	}
+	defer m.regroup()
+
+	base := m.table.FilterColumns(m.columnFilters)
+
+	m.searchText = nil
+	if m.searchTerm == "" {
+		m.searchErr = nil
+		m.filteredTable = nil
+		if base != m.table {
+			m.filteredTable = base
+		}
+		return
+	}
+
+	if m.fuzzySearch {
+		m.filteredTable = base.FuzzyFilter(m.searchTerm)
+		m.searchErr = nil
+		return
+	}
+
+	filtered, err := base.FilterQuery(m.searchTerm)
+	m.searchErr = err
+	if err != nil {
+		return
+	}
+	m.searchText = base.QueryTextTerms(m.searchTerm)
+	if filtered == m.table {
+		filtered = nil
+	}
+	m.filteredTable = filtered
+}
+
 // adjustPageSize adjusts the page size and recalculates pages
 func (m *TableModel) adjustPageSize(newSize int) {
 	m.pageSize = newSize
@@ -494,14 +961,73 @@ func (m *TableModel) adjustPageSize(newSize int) {
 	if m.filteredTable != nil {
 		m.filteredTable.PageSize = newSize
 	}
+	if m.groupedTable != nil {
+		m.groupedTable.PageSize = newSize
+	}
 
 	// Reset to first page to avoid being out of bounds
 	m.currentPage = 0
 	m.selectedRow = 0
 }
 
-// getCurrentTable returns the current table (filtered or main)
+// optimalPageSize returns the page size that fits the terminal, leaving room
+// for the footer row
+func (m *TableModel) optimalPageSize() int {
+	return max(m.renderer.GetOptimalPageSize()-m.renderer.FooterLines(m.table), 5)
+}
+
+// paginate passes the view state to the renderer, splits the current table
+// into pages of rendered lines when columns wrap, and keeps the page and
+// selection in range. It runs after every Update, so View only draws.
+func (m *TableModel) paginate() {
+	currentTable := m.getCurrentTable()
+	if currentTable == nil || m.renderer == nil {
+		return
+	}
+
+	m.configureRenderer(currentTable)
+	m.renderer.PaginateByLines(currentTable, m.pageSize)
+	if last := currentTable.GetTotalPages() - 1; m.currentPage > last {
+		m.currentPage = max(last, 0)
+	}
+	if rows := len(currentTable.GetPage(m.currentPage)); m.selectedRow >= rows {
+		m.selectedRow = max(rows-1, 0)
+	}
+}
+
+// configureRenderer passes the view state the renderer draws, and pages by,
+// to the renderer
+func (m *TableModel) configureRenderer(currentTable *table.Table) {
+	if m.fuzzySearch {
+		m.renderer.SetHighlight(m.searchTerm)
+	} else {
+		m.renderer.SetHighlight("")
+	}
+	m.renderer.SetSelectedColumn(m.selectedCol)
+	m.renderer.SetMarks(m.markedIDs())
+	m.renderer.ScrollToColumn(currentTable.Columns, m.selectedCol)
+	if m.filterMode || len(m.filterInputs) > 0 {
+		inputs := make([]string, len(currentTable.Columns))
+		for colIndex, input := range m.filterInputs {
+			if colIndex < len(inputs) {
+				inputs[colIndex] = input
+			}
+		}
+		focus := -1
+		if m.filterMode {
+			focus = m.filterColumn
+		}
+		m.renderer.SetFilterRow(inputs, focus)
+	} else {
+		m.renderer.SetFilterRow(nil, -1)
+	}
+}
+
+// getCurrentTable returns the current table (grouped, filtered or main)
 func (m *TableModel) getCurrentTable() *table.Table {
+	if m.groupedTable != nil {
+		return m.groupedTable
+	}
 	if m.filteredTable != nil {
 		return m.filteredTable
 	}
@@ -538,10 +1064,28 @@ func (m *TableModel) View() string {
 	// Status bar
 	content.WriteString(m.renderStatusBar())
 
+	// Filter bar
+	if m.filterMode && m.filterColumn < len(m.table.Columns) {
+		content.WriteString("\n")
+		filterText := fmt.Sprintf("Filter %s: %s", m.table.Columns[m.filterColumn].Header, m.filterInputs[m.filterColumn])
+		if m.filterErr != nil {
+			filterText += fmt.Sprintf("  ✗ %s", m.filterErr)
+		}
+		content.WriteString(m.theme.Search.Render(filterText))
+	}
+
 	// Search bar
 	if m.searchMode {
 		content.WriteString("\n")
 		searchText := fmt.Sprintf("Search: %s", m.searchTerm)
+		if m.fuzzySearch {
+			searchText = fmt.Sprintf("Fuzzy: %s", m.searchTerm)
+		}
+		if m.searchErr != nil {
+			searchText += fmt.Sprintf("  ✗ %s", m.searchErr)
+		} else if len(m.searchText) > 0 {
+			searchText += fmt.Sprintf("  (%q searched as text)", strings.Join(m.searchText, " "))
+		}
 		content.WriteString(m.theme.Search.Render(searchText))
 	}
 
@@ -556,7 +1100,7 @@ func (m *TableModel) renderStatusBar() string {
 	}
 
 	totalPages := currentTable.GetTotalPages()
-	startRow := m.currentPage*m.pageSize + 1
+	startRow := currentTable.GetPageStart(m.currentPage) + 1
 	pageData := currentTable.GetPage(m.currentPage)
 	endRow := startRow + len(pageData) - 1
 
@@ -568,23 +1112,79 @@ func (m *TableModel) renderStatusBar() string {
 	status := fmt.Sprintf("Page %d/%d | Rows %d-%d of %d | Page Size: %d",
 		m.currentPage+1, totalPages, startRow, endRow, currentTable.TotalRows, m.pageSize)
 
+	// Add focused column info
+	if m.selectedCol < len(currentTable.Columns) {
+		status += fmt.Sprintf(" | Column: %s", currentTable.Columns[m.selectedCol].Header)
+	}
+
 	// Add sort info
-	if currentTable.SortBy >= 0 && currentTable.SortBy < len(currentTable.Columns) {
-		sortDir := "↑"
-		if currentTable.SortDesc {
-			sortDir = "↓"
-		}
-		status += fmt.Sprintf(" | Sort: %s %s", currentTable.Columns[currentTable.SortBy].Header, sortDir)
+	if sortInfo := formatSortKeys(currentTable); sortInfo != "" {
+		status += " | Sort: " + sortInfo
+	}
+
+	// Add pivot info
+	if m.unpivoted != nil {
+		status += " | Pivoted"
+	}
+
+	// Add grouping info
+	if groupInfo := formatGroupColumns(currentTable); groupInfo != "" {
+		status += " | Grouped: " + groupInfo
+	}
+
+	// Add column filter info
+	if len(m.columnFilters) > 0 {
+		status += " | Filters: " + m.table.DescribeColumnFilters(m.columnFilters)
 	}
 
 	// Add search info
 	if m.searchTerm != "" {
 		status += fmt.Sprintf(" | Search: '%s'", m.searchTerm)
+		if m.fuzzySearch {
+			status += " (fuzzy)"
+		}
+	}
+
+	// Add data source info
+	if m.loading {
+		status += " | Loading..."
+	}
+	if m.sourceErr != nil {
+		status += fmt.Sprintf(" | ✗ %s", m.sourceErr)
+	}
+
+	if len(m.marks) > 0 {
+		status += fmt.Sprintf(" | Marked: %d", len(m.marks))
+	}
+
+	// Explain a failed computed cell under the cursor
+	if cell, _, ok := m.GetSelectedCell(); ok && cell.Err() != nil {
+		status += fmt.Sprintf(" | ✗ %s", cell.Err())
+	}
+
+	if m.statusMsg != "" {
+		status += " | " + m.statusMsg
 	}
 
 	return m.theme.Status.Render(status)
 }
 
+// formatSortKeys describes the sort chain, e.g. "Status ↑, Priority ↓"
+func formatSortKeys(tbl *table.Table) string {
+	var parts []string
+	for _, key := range tbl.SortKeys {
+		if key.Column < 0 || key.Column >= len(tbl.Columns) {
+			continue
+		}
+		sortDir := "↑"
+		if key.Desc {
+			sortDir = "↓"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", tbl.Columns[key.Column].Header, sortDir))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // renderHelp renders the help screen
 func (m *TableModel) renderHelp() string {
 	help := `
@@ -593,8 +1193,9 @@ Table Navigation Help
 Navigation:
   ↑/k         - Move up
   ↓/j         - Move down  
-  ←/h         - Previous page
-  →/l         - Next page
+  ←/h         - Previous column
+  →/l         - Next column
+  PgUp/PgDn   - Previous/next page
   Home/g      - First page
   End/G       - Last page
 
@@ -605,12 +1206,51 @@ Actions:
   +/=         - Increase page size
   -/_         - Decrease page size
   c           - Clear sort
+  f           - Filter focused column
+  F           - Clear column filters
   q/Esc       - Quit
 
+Marks:
+  Space       - Mark/unmark selected row
+  Shift+↑/↓   - Extend marks up/down
+  a           - Mark all rows in view
+  i           - Invert marks in view
+  u           - Clear marks
+
+Groups:
+  b           - Group by focused column (again to ungroup)
+  Enter/Space - Collapse/expand selected group
+
+Pivot:
+  p           - Pick focused column as rows, then columns, then values
+  P           - Cancel picking, or restore the table from before the pivot
+
+Clipboard:
+  y           - Copy focused cell
+  Y           - Copy selected row
+  Alt+y       - Copy focused column
+  Ctrl+y      - Copy marked rows (all rows in view if none)
+  Ctrl+t      - Cycle format (TSV/CSV/JSON/Markdown)
+
 Sorting:
+  s           - Sort by focused column
+  S           - Add focused column as a secondary sort key
   1-9         - Sort by column 1-9
+  Shift+1-9   - Add column 1-9 as a secondary sort key
+
+Filter Mode:
+  Tab/→       - Next column
+  Shift+Tab/← - Previous column
+  Space       - Cycle any/true/false (boolean columns)
+  Text        - Contains (=text for equals)
+  1..10       - Range (numbers and dates; either side optional)
+  Esc/Enter   - Exit filter mode
 
 Search Mode:
+  status:done - Match a column (also = != > >= < <=)
+  -term       - Exclude matches
+  "a phrase"  - Match an exact phrase
+  Tab         - Toggle fuzzy matching
   Esc         - Exit search
   Backspace   - Delete character
   Enter       - Apply search
@@ -626,11 +1266,55 @@ func (m *TableModel) GetTable() *table.Table {
 	return m.table
 }
 
-// GetCurrentTable returns the current effective table (filtered or main)
+// GetCurrentTable returns the current effective table (grouped, filtered or
+// main). A grouped table lists group header rows among the data rows; see
+// table.Row.Group.
 func (m *TableModel) GetCurrentTable() *table.Table {
 	return m.getCurrentTable()
 }
 
+// GetSelectedColumn returns the column index of the cell cursor
+func (m *TableModel) GetSelectedColumn() int {
+	return m.selectedCol
+}
+
+// GetSelectedCell returns the cell under the cursor and its column
+func (m *TableModel) GetSelectedCell() (table.Cell, table.Column, bool) {
+	row, ok := m.GetSelectedRow()
+	if !ok || m.selectedCol >= len(row.Cells) || m.selectedCol >= len(m.table.Columns) {
+		return table.Cell{}, table.Column{}, false
+	}
+	return row.Cells[m.selectedCol], m.table.Columns[m.selectedCol], true
+}
+
+// GetSearchError returns the error from the current search query, if any
+func (m *TableModel) GetSearchError() error {
+	return m.searchErr
+}
+
+// GetColumnFilters returns the active column filters, keyed by column index
+func (m *TableModel) GetColumnFilters() map[int]table.ColumnFilter {
+	return m.columnFilters
+}
+
+// SetColumnFilter sets or, with a nil filter, clears a column filter
+func (m *TableModel) SetColumnFilter(columnIndex int, filter table.ColumnFilter) {
+	if m.columnFilters == nil {
+		m.columnFilters = make(map[int]table.ColumnFilter)
+	}
+	if filter == nil {
+		delete(m.columnFilters, columnIndex)
+		delete(m.filterInputs, columnIndex)
+	} else {
+		m.columnFilters[columnIndex] = filter
+	}
+
+	m.applyFilters()
+	m.currentPage = 0
+	m.selectedRow = 0
+	m.paginate()
+}
+
 // GetSelectedRow returns the currently selected row
 func (m *TableModel) GetSelectedRow() (table.Row, bool) {
 	currentTable := m.getCurrentTable()
@@ -652,19 +1336,41 @@ func (m *TableModel) SetData(data interface{}) error {
 		return fmt.Errorf("table is not initialized")
 	}
 
+	// New data replaces a pivot's source, so the pivot is dropped
+	if m.unpivoted != nil {
+		m.table = m.unpivoted
+		m.unpivoted = nil
+	}
+	m.pivotKeys = nil
+
 	err := m.table.SetData(data)
 	if err != nil {
 		return err
 	}
 
-	// Reset state
+	m.resetView()
+	return nil
+}
+
+// resetView clears the view state (position, search, filters, marks and
+// collapsed groups) after the table's data or columns change
+func (m *TableModel) resetView() {
 	m.currentPage = 0
 	m.selectedRow = 0
+	m.selectedCol = 0
 	m.filteredTable = nil
 	m.searchTerm = ""
+	m.searchErr = nil
+	m.searchText = nil
 	m.searchMode = false
-
-	return nil
+	m.filterMode = false
+	m.filterInputs = nil
+	m.columnFilters = nil
+	m.filterErr = nil
+	m.marks = nil
+	m.collapsed = nil
+	m.regroup()
+	m.paginate()
 }
 
 // RefreshData refreshes the table with new data
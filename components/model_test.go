@@ -1,6 +1,8 @@
 package components
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/anurag-roy/bubbletable/renderer"
@@ -82,6 +84,21 @@ func TestNewTableWithColumns(t *testing.T) {
 	}
 }
 
+func TestNewTableFromJSON(t *testing.T) {
+	model, err := NewTableFromJSON(strings.NewReader(`[{"id": 1, "user": {"name": "Alice"}}]`))
+	if err != nil {
+		t.Fatalf("NewTableFromJSON failed: %v", err)
+	}
+
+	if got := model.table.GetColumnNames(); len(got) != 2 || got[1] != "user.name" {
+		t.Errorf("Expected flattened columns, got %v", got)
+	}
+
+	if _, err := NewTableFromJSON(strings.NewReader(`{"id": 1}`)); err == nil {
+		t.Error("Expected an error for JSON that is not an array")
+	}
+}
+
 func TestBuilderPattern(t *testing.T) {
 	employees := []TestEmployee{
 		{1, "Alice"},
@@ -365,6 +382,272 @@ func TestSearchMode(t *testing.T) {
 	}
 }
 
+func TestUnicodeSearchInput(t *testing.T) {
+	employees := []TestEmployee{
+		{1, "Zoë"},
+		{2, "東京"},
+	}
+
+	model := NewTable(employees)
+	model.ready = true
+	model.searchMode = true
+
+	for _, r := range "東京x" {
+		model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if model.searchTerm != "東京x" {
+		t.Fatalf("Expected non-ASCII characters to be typed, got %q", model.searchTerm)
+	}
+
+	// Backspace removes a whole character, not a byte
+	model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if model.searchTerm != "東京" {
+		t.Fatalf("Expected backspace to remove one character, got %q", model.searchTerm)
+	}
+	if len(model.GetCurrentTable().CurrentRows()) != 1 {
+		t.Errorf("Expected 1 matching row, got %d", len(model.GetCurrentTable().CurrentRows()))
+	}
+}
+
+func TestSearchQueryError(t *testing.T) {
+	employees := []TestEmployee{
+		{1, "Alice"},
+		{2, "Bob"},
+	}
+
+	model := NewTable(employees)
+	model.ready = true
+	model.searchMode = true
+
+	for _, r := range "name:bob" {
+		model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if model.GetSearchError() != nil {
+		t.Fatalf("Unexpected search error: %v", model.GetSearchError())
+	}
+	if len(model.GetCurrentTable().CurrentRows()) != 1 {
+		t.Fatalf("Expected 1 matching row, got %d", len(model.GetCurrentTable().CurrentRows()))
+	}
+
+	// An invalid query keeps the last results and reports the error
+	model.searchTerm += " id>x"
+	model.updateSearch()
+	if model.GetSearchError() == nil {
+		t.Fatal("Expected a search error for a non-numeric ID comparison")
+	}
+	if len(model.GetCurrentTable().CurrentRows()) != 1 {
+		t.Errorf("Invalid query should keep previous results, got %d rows", len(model.GetCurrentTable().CurrentRows()))
+	}
+	if !contains(model.View(), "not a valid number") {
+		t.Error("Search bar should show the query error")
+	}
+
+	// A misspelt column is an error, but a time is searched for as text
+	model.searchTerm = "nmae:bob"
+	model.updateSearch()
+	if !contains(model.View(), `unknown column "nmae"`) {
+		t.Error("Search bar should report an unknown column")
+	}
+	model.searchTerm = "12:30"
+	model.updateSearch()
+	if model.GetSearchError() != nil || !contains(model.View(), `("12:30" searched as text)`) {
+		t.Errorf("Search bar should say the term is searched as text, got error %v", model.GetSearchError())
+	}
+}
+
+func TestFuzzySearch(t *testing.T) {
+	employees := []TestEmployee{
+		{1, "Alice Johnson"},
+		{2, "Bob Smith"},
+		{3, "Alicia Jones"},
+	}
+
+	model := NewTable(employees).WithFuzzySearch(true)
+	model.ready = true
+	model.searchMode = true
+
+	for _, r := range "aljn" {
+		model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	rows := model.GetCurrentTable().CurrentRows()
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 fuzzy matches, got %d", len(rows))
+	}
+
+	// Tab switches back to the query language
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if model.fuzzySearch {
+		t.Error("Tab should toggle fuzzy search off")
+	}
+	if len(model.GetCurrentTable().CurrentRows()) != 0 {
+		t.Errorf("Substring search for 'aljn' should match nothing, got %d rows", len(model.GetCurrentTable().CurrentRows()))
+	}
+}
+
+func TestFooter(t *testing.T) {
+	employees := []TestEmployee{
+		{1, "Alice"},
+		{2, "Bob"},
+		{3, "Alicia"},
+	}
+
+	model := NewTable(employees).WithFooter(table.FooterView)
+	model.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	if model.pageSize != 30-10-2 {
+		t.Errorf("Expected the page size to leave room for the footer, got %d", model.pageSize)
+	}
+
+	model.searchMode = true
+	for _, r := range "ali" {
+		model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := model.GetCurrentTable().FooterText()[0]; got != "4" {
+		t.Errorf("Expected the footer to total the matching IDs, got %q", got)
+	}
+
+	model.WithFooter(table.FooterAll)
+	if got := model.GetCurrentTable().FooterText()[0]; got != "6" {
+		t.Errorf("Expected the footer to total every ID, got %q", got)
+	}
+}
+
+func TestComputedCellError(t *testing.T) {
+	type Stock struct {
+		Value int
+		Units int
+		Each  struct{} `table:"Each,expr:value / units"`
+	}
+
+	model := NewTable([]Stock{{Value: 10, Units: 4}, {Value: 5, Units: 0}})
+	model.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	model.selectedCol = 2
+	if strings.Contains(model.View(), "✗") {
+		t.Error("Expected no error for a computed value")
+	}
+
+	pressKey(model, tea.KeyMsg{Type: tea.KeyDown})
+	view := model.View()
+	if !strings.Contains(view, table.CellErrorText) || !strings.Contains(view, "✗ Each: division by zero") {
+		t.Errorf("Expected the failed cell and its error in the status bar, got:\n%s", view)
+	}
+}
+
+func TestColumnFilterMode(t *testing.T) {
+	type Task struct {
+		ID   int
+		Name string
+		Done bool
+	}
+	tasks := []Task{
+		{1, "Alice", true},
+		{2, "Bob", false},
+		{3, "Alicia", false},
+	}
+
+	model := NewTable(tasks)
+	model.ready = true
+
+	// Open the filter row and type into the first column
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if !model.filterMode {
+		t.Fatal("Should enter filter mode when 'f' is pressed")
+	}
+	for _, r := range "2.." {
+		model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(model.GetCurrentTable().CurrentRows()) != 2 {
+		t.Errorf("Expected 2 rows with ID >= 2, got %d", len(model.GetCurrentTable().CurrentRows()))
+	}
+
+	// Move to the boolean column and cycle to "false"
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if len(model.GetColumnFilters()) != 2 {
+		t.Fatalf("Expected 2 column filters, got %d", len(model.GetColumnFilters()))
+	}
+
+	// Invalid input reports an error and keeps the previous filters
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if model.filterErr == nil {
+		t.Error("Expected an error for a non-numeric range")
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Column filters combine with the global search
+	model.searchTerm = "ali"
+	model.updateSearch()
+	rows := model.GetCurrentTable().CurrentRows()
+	if len(rows) != 1 || rows[0].Cells[1].Value != "Alicia" {
+		t.Errorf("Expected only Alicia to match, got %d rows", len(rows))
+	}
+
+	status := model.renderStatusBar()
+	if !contains(status, "Filters: ID 2.., Done false") {
+		t.Errorf("Status bar should list active filters, got %q", status)
+	}
+
+	// Clearing filters leaves only the search applied
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if len(model.GetCurrentTable().CurrentRows()) != 2 {
+		t.Errorf("Expected 2 search matches after clearing filters, got %d", len(model.GetCurrentTable().CurrentRows()))
+	}
+}
+
+func TestWrappedRowPagination(t *testing.T) {
+	data := make([]map[string]interface{}, 6)
+	for i := range data {
+		data[i] = map[string]interface{}{
+			"id":   i + 1,
+			"note": strings.Repeat("word ", 3*(i%2)+1), // 1 or 4 wrapped lines
+		}
+	}
+	columns := []table.Column{
+		*table.NewColumn("id", "ID").WithType(table.Integer).WithWidth(4),
+		*table.NewColumn("note", "Note").WithWidth(7).WithWrap(0),
+	}
+
+	model := NewTableWithColumns(data, columns).
+		WithPageSize(6).
+		WithWidthStrategy(renderer.FixedWidths)
+	model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	// Rows take 1, 4, 1, 4, 1, 4 lines, so 6-line pages hold rows 1-3, 4-5 and 6
+	current := model.GetCurrentTable()
+	if current.GetTotalPages() != 3 {
+		t.Fatalf("Expected 3 pages of at most 6 lines, got %d", current.GetTotalPages())
+	}
+
+	// Selection stays within the rows of the page
+	for i := 0; i < 3; i++ {
+		model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if model.selectedRow != 2 {
+		t.Errorf("Expected selection to stop at the last row of the page, got %d", model.selectedRow)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if row, ok := model.GetSelectedRow(); !ok || row.Cells[0].Value != 4 {
+		t.Errorf("Expected row 4 to start the second page, got %v", row.Cells)
+	}
+	if status := model.renderStatusBar(); !contains(status, "Rows 4-5 of 6") {
+		t.Errorf("Status bar should count rows by page breaks, got %q", status)
+	}
+
+	// Drawing leaves the pages and selection to Update
+	breaks := current.PageBreaks
+	model.selectedRow = 9
+	model.View()
+	if model.selectedRow != 9 || &current.PageBreaks[0] != &breaks[0] {
+		t.Error("Expected View to leave the model's state alone")
+	}
+}
+
 func TestPagination(t *testing.T) {
 	// Create enough data for multiple pages
 	employees := make([]TestEmployee, 25)
@@ -376,7 +659,7 @@ func TestPagination(t *testing.T) {
 	model.ready = true
 
 	// Test navigation to next page
-	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}} // right/next page
+	keyMsg := tea.KeyMsg{Type: tea.KeyPgDown}
 	updatedModel, _ := model.Update(keyMsg)
 
 	tableModel := updatedModel.(*TableModel)
@@ -385,7 +668,7 @@ func TestPagination(t *testing.T) {
 	}
 
 	// Test navigation to previous page
-	keyMsg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}} // left/prev page
+	keyMsg = tea.KeyMsg{Type: tea.KeyPgUp}
 	updatedModel, _ = tableModel.Update(keyMsg)
 
 	tableModel = updatedModel.(*TableModel)
@@ -394,6 +677,55 @@ func TestPagination(t *testing.T) {
 	}
 }
 
+func TestCellCursor(t *testing.T) {
+	employees := []TestEmployee{
+		{2, "Bob"},
+		{1, "Carol"},
+		{3, "Alice"},
+	}
+
+	model := NewTable(employees)
+	model.ready = true
+
+	// Right moves the cursor to the next column and stops at the last one
+	model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if model.GetSelectedColumn() != 1 {
+		t.Fatalf("Expected focused column 1, got %d", model.GetSelectedColumn())
+	}
+
+	cell, col, ok := model.GetSelectedCell()
+	if !ok || cell.Value != "Bob" || col.Key != "Name" {
+		t.Errorf("Expected focused cell Name=Bob, got %v in %q", cell.Value, col.Key)
+	}
+
+	// 's' sorts by the focused column
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if model.table.SortBy != 1 {
+		t.Errorf("Expected sort by focused column 1, got %d", model.table.SortBy)
+	}
+	if model.table.Rows[0].Cells[1].Value != "Alice" {
+		t.Errorf("Expected Alice first after sorting by name, got %v", model.table.Rows[0].Cells[1].Value)
+	}
+
+	// 'f' opens the filter row on the focused column
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if model.filterColumn != 1 {
+		t.Errorf("Expected filter row to open on column 1, got %d", model.filterColumn)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// Left moves back and stops at the first column
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	if model.GetSelectedColumn() != 0 {
+		t.Errorf("Expected focused column 0, got %d", model.GetSelectedColumn())
+	}
+	if model.currentPage != 0 {
+		t.Error("Left/right should not change pages")
+	}
+}
+
 func TestSortingIntegration(t *testing.T) {
 	employees := []TestEmployee{
 		{3, "Charlie"},
@@ -422,6 +754,44 @@ func TestSortingIntegration(t *testing.T) {
 	}
 }
 
+func TestSecondarySortKey(t *testing.T) {
+	employees := []TestEmployee{
+		{2, "Bob"},
+		{1, "Bob"},
+		{3, "Alice"},
+	}
+
+	model := NewTable(employees)
+	model.ready = true
+
+	// Sort by name, then add ID descending as a secondary key (shift+1 twice)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+
+	if len(model.table.SortKeys) != 2 {
+		t.Fatalf("Expected 2 sort keys, got %d", len(model.table.SortKeys))
+	}
+
+	expectedIDs := []int{3, 2, 1}
+	for i, expectedID := range expectedIDs {
+		if model.table.Rows[i].Cells[0].Value != expectedID {
+			t.Errorf("Row %d: expected ID %d, got %v", i, expectedID, model.table.Rows[i].Cells[0].Value)
+		}
+	}
+
+	status := model.renderStatusBar()
+	if !contains(status, "Sort: Name ↑, ID ↓") {
+		t.Errorf("Status bar should show the sort chain, got %q", status)
+	}
+
+	// A third press removes the secondary key
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+	if len(model.table.SortKeys) != 1 {
+		t.Errorf("Expected secondary key to be removed, got %+v", model.table.SortKeys)
+	}
+}
+
 func TestEmptyData(t *testing.T) {
 	model := NewTable([]TestEmployee{})
 
@@ -596,3 +966,119 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+// newReadyModel returns a model of rows sized to a 120×40 terminal
+func newReadyModel[T any](rows []T) *TableModel {
+	model := NewTable(rows)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return model
+}
+
+// runCmds runs a command, and the commands in any batch it returns, feeding
+// the resulting messages back into the model
+func runCmds(model *TableModel, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runCmds(model, c)
+		}
+	case nil:
+	default:
+		_, next := model.Update(msg)
+		runCmds(model, next)
+	}
+}
+
+func TestDataSourceLoading(t *testing.T) {
+	employees := make([]TestEmployee, 25)
+	for i := range employees {
+		employees[i] = TestEmployee{ID: i + 1, Name: "Employee"}
+	}
+	employees[7].Name = "Zed"
+
+	src, err := table.NewSliceSource(employees)
+	if err != nil {
+		t.Fatalf("NewSliceSource failed: %v", err)
+	}
+	model := NewTableFromSource(src).WithPageSize(10)
+
+	// The first page is fetched in the background
+	cmd := model.Init()
+	if cmd == nil {
+		t.Fatal("Expected Init to fetch the first page")
+	}
+	if !contains(model.renderStatusBar(), "Loading...") {
+		t.Errorf("Expected a loading indicator while fetching, got %q", model.renderStatusBar())
+	}
+	if model.Init() != nil {
+		t.Error("Expected no second fetch for a page already being fetched")
+	}
+
+	runCmds(model, cmd)
+	if contains(model.renderStatusBar(), "Loading...") {
+		t.Error("Expected the loading indicator to clear")
+	}
+	if status := model.renderStatusBar(); !contains(status, "Rows 1-10 of 25") {
+		t.Errorf("Expected the source's row count, got %q", status)
+	}
+
+	// Paging fetches the next page
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if cmd == nil {
+		t.Fatal("Expected paging to fetch the next page")
+	}
+	runCmds(model, cmd)
+	if row, ok := model.GetSelectedRow(); !ok || row.Cells[0].Value != 11 {
+		t.Errorf("Expected row 11 to start the second page, got %v", row.Cells)
+	}
+
+	// Sorting and searching are handed to the source
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	runCmds(model, cmd)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	runCmds(model, cmd)
+
+	current := model.GetCurrentTable()
+	if current.SourceFilter() != "z" || current.TotalRows != 1 {
+		t.Errorf("Expected the search to filter the source to 1 row, got %d", current.TotalRows)
+	}
+	if row, ok := model.GetSelectedRow(); !ok || row.Cells[1].Value != "Zed" {
+		t.Errorf("Expected Zed to match the search, got %v", row.Cells)
+	}
+}
+
+func TestDataSourceError(t *testing.T) {
+	model := NewTableFromSource(failingSource{})
+	runCmds(model, model.Init())
+
+	if status := model.renderStatusBar(); !contains(status, "✗ connection refused") {
+		t.Errorf("Expected the fetch error in the status bar, got %q", status)
+	}
+
+	// A failed fetch is not retried on every message, only on refresh
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyDown}); cmd != nil {
+		t.Error("Expected no retry until refresh")
+	}
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}); cmd == nil {
+		t.Error("Expected refresh to fetch again")
+	}
+}
+
+// failingSource has a schema but fails every fetch
+type failingSource struct{}
+
+func (failingSource) Schema() ([]table.Column, error) {
+	return []table.Column{*table.NewColumn("name", "Name")}, nil
+}
+
+func (failingSource) Count(filter string) (int, error) {
+	return 0, errors.New("connection refused")
+}
+
+func (failingSource) Fetch(offset, limit int, sort []table.SortKey, filter string) ([]table.Row, error) {
+	return nil, errors.New("connection refused")
+}
@@ -0,0 +1,41 @@
+package components
+
+import (
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+// watchTable subscribes to the mutations of the model's table, moving the
+// subscription when the table is replaced (by a pivot, say)
+func (m *TableModel) watchTable() {
+	if m.watched == m.table {
+		return
+	}
+	if m.unsubscribe != nil {
+		m.unsubscribe()
+		m.unsubscribe = nil
+	}
+	m.watched = m.table
+	if m.table != nil {
+		m.unsubscribe = m.table.Subscribe(m.handleMutation)
+	}
+}
+
+// handleMutation keeps the view in step with a row inserted, updated or
+// deleted in the table: the filtered rows are patched rather than filtered
+// again, and marks follow the row
+func (m *TableModel) handleMutation(event table.MutationEvent) {
+	if m.filteredTable != nil {
+		m.filteredTable.ApplyMutation(event)
+	}
+
+	if _, ok := m.marks[event.Row.ID]; ok {
+		if event.Kind == table.RowDeleted {
+			delete(m.marks, event.Row.ID)
+		} else {
+			m.marks[event.Row.ID] = event.Row
+		}
+	}
+
+	m.regroup()
+	m.paginate()
+}
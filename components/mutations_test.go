@@ -0,0 +1,54 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModelFollowsMutations(t *testing.T) {
+	model := newReadyModel([]TestEmployee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Carol"},
+		{ID: 4, Name: "Dave"},
+		{ID: 5, Name: "Eve"},
+	})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace}) // Mark Alice
+	model.searchTerm = "a"
+	model.applyFilters()
+
+	tbl := model.GetTable()
+	if err := tbl.AddRow(6, "Frank"); err != nil {
+		t.Fatalf("AddRow failed: %v", err)
+	}
+	if err := tbl.UpdateRow(1, 2, "Rob"); err != nil {
+		t.Fatalf("UpdateRow failed: %v", err)
+	}
+	if got := model.GetCurrentTable().TotalRows; got != 4 {
+		t.Errorf("Expected Alice, Carol, Dave and Frank to match, got %d rows", got)
+	}
+
+	if err := tbl.UpdateRow(0, 1, "Ada"); err != nil {
+		t.Fatalf("UpdateRow failed: %v", err)
+	}
+	if got := markedNames(model); got != "Ada" {
+		t.Errorf("Expected the mark to follow the update, got %q", got)
+	}
+
+	if err := tbl.DeleteRow(0); err != nil {
+		t.Fatalf("DeleteRow failed: %v", err)
+	}
+	if len(model.marks) != 0 || model.GetCurrentTable().TotalRows != 3 {
+		t.Error("Expected the deleted row to leave the marks and the search results")
+	}
+
+	// A pivot moves the subscription to the pivot table
+	if err := model.Pivot("Name", "ID", "ID", nil); err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+	model.Unpivot()
+	if model.watched != tbl {
+		t.Error("Expected the model to watch its table again after Unpivot")
+	}
+}
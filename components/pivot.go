@@ -0,0 +1,107 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+// Pivot replaces the table with a pivot of the current view (see
+// table.Pivot): the rows narrowed by search and column filters, ignoring
+// grouping. Search, filters and marks are reset, and Unpivot restores the
+// table from before the first pivot.
+func (m *TableModel) Pivot(rowKey, colKey, valueKey string, agg table.AggFunc) error {
+	if m.table == nil {
+		return fmt.Errorf("table is not initialized")
+	}
+
+	base := m.filteredTable
+	if base == nil {
+		base = m.table
+	}
+	pivot, err := table.Pivot(base, rowKey, colKey, valueKey, agg)
+	if err != nil {
+		return err
+	}
+	pivot.PageSize = m.pageSize
+
+	if m.unpivoted == nil {
+		m.unpivoted = m.table
+	}
+	m.table = pivot
+	m.pivotKeys = nil
+	m.resetView()
+	return nil
+}
+
+// Unpivot restores the table from before Pivot, reporting whether the table
+// was pivoted
+func (m *TableModel) Unpivot() bool {
+	if m.unpivoted == nil {
+		return false
+	}
+
+	m.table = m.unpivoted
+	m.table.PageSize = m.pageSize
+	m.unpivoted = nil
+	m.pivotKeys = nil
+	m.resetView()
+	return true
+}
+
+// IsPivoted reports whether the table is a pivot made by Pivot
+func (m *TableModel) IsPivoted() bool {
+	return m.unpivoted != nil
+}
+
+// handlePivotKeys handles pivot key presses. The pivot key picks the focused
+// column as the rows, then the columns, then the values, and pivots the
+// current view once all three are picked.
+func (m *TableModel) handlePivotKeys(key string) bool {
+	if m.keyBindings.IsUnpivot(key) {
+		switch {
+		case len(m.pivotKeys) > 0:
+			m.pivotKeys = nil
+			m.statusMsg = "Pivot cancelled"
+		case m.Unpivot():
+			m.statusMsg = "Pivot removed"
+		}
+		return true
+	}
+
+	if !m.keyBindings.IsPivot(key) || m.table == nil || m.selectedCol >= len(m.table.Columns) {
+		return false
+	}
+
+	m.pivotKeys = append(m.pivotKeys, m.table.Columns[m.selectedCol].Key)
+	headers := make([]string, len(m.pivotKeys))
+	for i, key := range m.pivotKeys {
+		headers[i] = m.pivotHeader(key)
+	}
+
+	switch len(m.pivotKeys) {
+	case 1:
+		m.statusMsg = fmt.Sprintf("Pivot rows: %s, pick columns", headers[0])
+	case 2:
+		m.statusMsg = fmt.Sprintf("Pivot %s × %s, pick values", headers[0], headers[1])
+	default:
+		keys := m.pivotKeys
+		if err := m.Pivot(keys[0], keys[1], keys[2], nil); err != nil {
+			m.pivotKeys = nil
+			m.statusMsg = fmt.Sprintf("✗ Pivot failed: %s", err)
+			return true
+		}
+		m.statusMsg = fmt.Sprintf("Pivoted %s by %s × %s", headers[2], headers[0], headers[1])
+	}
+	return true
+}
+
+// pivotHeader returns the header of the column with a key
+func (m *TableModel) pivotHeader(key string) string {
+	for _, col := range m.table.Columns {
+		if col.Key == key {
+			return col.Header
+		}
+	}
+	return key
+}
@@ -0,0 +1,98 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Sale is a test row in long format to pivot
+type Sale struct {
+	Region  string  `table:"Region"`
+	Quarter string  `table:"Quarter"`
+	Amount  float64 `table:"Amount"`
+}
+
+func TestPivotModel(t *testing.T) {
+	model := newReadyModel([]Sale{
+		{"North", "Q1", 6},
+		{"South", "Q1", 4},
+		{"North", "Q2", 10},
+		{"North", "Q1", 2},
+	})
+
+	if err := model.Pivot("Region", "Missing", "Amount", nil); err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+	if model.IsPivoted() {
+		t.Fatal("Expected a failed pivot to leave the table alone")
+	}
+
+	// Pivot the current view only
+	model.searchTerm = "q1"
+	model.applyFilters()
+	if err := model.Pivot("Region", "Quarter", "Amount", nil); err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+	if got := strings.Join(model.GetTable().GetColumnNames(), ","); got != "Region,Q1,Total" {
+		t.Errorf("Expected the searched rows to be pivoted, got columns %s", got)
+	}
+	if model.searchTerm != "" || model.GetCurrentTable() != model.GetTable() {
+		t.Error("Expected the search to be reset on the pivot")
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "Pivoted") || !strings.Contains(view, "Total") {
+		t.Errorf("Expected the pivot and its totals in the view, got:\n%s", view)
+	}
+
+	if !model.Unpivot() || model.IsPivoted() || len(model.GetTable().Rows) != 4 {
+		t.Error("Expected Unpivot to restore the sales")
+	}
+	if model.Unpivot() {
+		t.Error("Expected nothing to unpivot")
+	}
+}
+
+func TestPivotKeysPickFocusedColumns(t *testing.T) {
+	model := newReadyModel([]Sale{
+		{"North", "Q1", 6},
+		{"South", "Q1", 4},
+		{"North", "Q2", 10},
+		{"North", "Q1", 2},
+	})
+	pivotKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}}
+
+	model.selectedCol = 1
+	pressKey(model, pivotKey)
+	if !strings.Contains(model.statusMsg, "Pivot rows: Quarter") {
+		t.Errorf("Expected a prompt for the columns, got %q", model.statusMsg)
+	}
+
+	// P cancels the picks so far
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if model.pivotKeys != nil || model.statusMsg != "Pivot cancelled" {
+		t.Errorf("Expected the pivot to be cancelled, got %q", model.statusMsg)
+	}
+
+	for _, col := range []int{1, 0, 2} {
+		model.selectedCol = col
+		pressKey(model, pivotKey)
+	}
+	if !model.IsPivoted() || model.statusMsg != "Pivoted Amount by Quarter × Region" {
+		t.Fatalf("Expected a pivot of amount by quarter and region, got %q", model.statusMsg)
+	}
+	if got := strings.Join(model.GetTable().GetColumnNames(), ","); got != "Quarter,North,South,Total" {
+		t.Errorf("Unexpected pivot columns %s", got)
+	}
+	if got := model.GetTable().GetCellValue(0, 1); got != "8.00" {
+		t.Errorf("Expected North's Q1 sales of 8.00, got %s", got)
+	}
+
+	// P then restores the sales
+	pressKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if model.IsPivoted() || model.statusMsg != "Pivot removed" {
+		t.Errorf("Expected the pivot to be removed, got %q", model.statusMsg)
+	}
+}
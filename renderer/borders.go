@@ -0,0 +1,190 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TableBorder describes how the table frame is drawn. Characters come from
+// Chars and are coloured with the theme's Border style.
+type TableBorder struct {
+	Name       string
+	Chars      lipgloss.Border
+	Top        bool // Draw the top edge
+	Bottom     bool // Draw the bottom edge
+	Sides      bool // Draw the left and right edges
+	HeaderRule bool // Draw a rule between the header and the data rows
+	RowRules   bool // Draw rules between data rows
+}
+
+// Predefined borders
+var (
+	// DefaultBorder separates columns and the header with light lines
+	DefaultBorder = TableBorder{
+		Name:       "Default",
+		Chars:      lipgloss.NormalBorder(),
+		HeaderRule: true,
+	}
+
+	// RoundedBorder draws a full frame with rounded corners
+	RoundedBorder = TableBorder{
+		Name:       "Rounded",
+		Chars:      lipgloss.RoundedBorder(),
+		Top:        true,
+		Bottom:     true,
+		Sides:      true,
+		HeaderRule: true,
+	}
+
+	// DoubleBorder draws a full frame with double lines
+	DoubleBorder = TableBorder{
+		Name:       "Double",
+		Chars:      lipgloss.DoubleBorder(),
+		Top:        true,
+		Bottom:     true,
+		Sides:      true,
+		HeaderRule: true,
+	}
+
+	// ASCIIBorder draws a full frame using only ASCII characters
+	ASCIIBorder = TableBorder{
+		Name:       "ASCII",
+		Chars:      lipgloss.ASCIIBorder(),
+		Top:        true,
+		Bottom:     true,
+		Sides:      true,
+		HeaderRule: true,
+	}
+
+	// MarkdownBorder draws pipes and a dashed header rule like a Markdown table
+	MarkdownBorder = TableBorder{
+		Name:       "Markdown",
+		Chars:      lipgloss.MarkdownBorder(),
+		Sides:      true,
+		HeaderRule: true,
+	}
+
+	// NoBorder separates columns with spaces and draws no lines
+	NoBorder = TableBorder{
+		Name:  "None",
+		Chars: lipgloss.HiddenBorder(),
+	}
+)
+
+// GetAllBorders returns all predefined borders
+func GetAllBorders() []TableBorder {
+	return []TableBorder{
+		DefaultBorder,
+		RoundedBorder,
+		DoubleBorder,
+		ASCIIBorder,
+		MarkdownBorder,
+		NoBorder,
+	}
+}
+
+// GetBorderByName returns a predefined border by name
+func GetBorderByName(name string) (TableBorder, bool) {
+	for _, border := range GetAllBorders() {
+		if strings.EqualFold(border.Name, name) {
+			return border, true
+		}
+	}
+	return TableBorder{}, false
+}
+
+// SetBorder sets how the table frame is drawn
+func (r *TableRenderer) SetBorder(border TableBorder) {
+	r.border = border
+}
+
+// GetBorder returns how the table frame is drawn
+func (r *TableRenderer) GetBorder() TableBorder {
+	return r.border
+}
+
+// borderStyle returns the style for border characters: the theme's border
+// foreground, falling back to its text foreground
+func (r *TableRenderer) borderStyle() lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if color := r.theme.Border.GetBorderTopForeground(); !isNoColor(color) {
+		return style.Foreground(color)
+	}
+	if color := r.theme.Border.GetForeground(); !isNoColor(color) {
+		return style.Foreground(color)
+	}
+	return style
+}
+
+// isNoColor reports whether a color is unset
+func isNoColor(color lipgloss.TerminalColor) bool {
+	_, ok := color.(lipgloss.NoColor)
+	return ok
+}
+
+// frameWidth returns the width taken by the left and right edges
+func (r *TableRenderer) frameWidth() int {
+	if r.border.Sides {
+		return lipgloss.Width(r.border.Chars.Left) + lipgloss.Width(r.border.Chars.Right)
+	}
+	return 0
+}
+
+// frameLines returns how many lines the top and bottom edges take
+func (r *TableRenderer) frameLines() int {
+	lines := 0
+	if r.border.Top {
+		lines++
+	}
+	if r.border.Bottom {
+		lines++
+	}
+	return lines
+}
+
+// columnSeparator returns the character drawn between two columns
+func (r *TableRenderer) columnSeparator() string {
+	if r.border.Chars.Left == "" {
+		return " "
+	}
+	return r.border.Chars.Left
+}
+
+// buildRule draws a horizontal line across the columns, using fill for the
+// cells, junction between columns, and left/right at the edges if enabled
+func (r *TableRenderer) buildRule(columns []table.Column, left, fill, junction, right string, style lipgloss.Style) string {
+	if fill == "" {
+		fill = " "
+	}
+
+	segments := make([]string, len(columns))
+	for i, col := range columns {
+		segments[i] = strings.Repeat(fill, col.Width)
+	}
+
+	line := strings.Join(segments, junction)
+	if r.border.Sides {
+		line = left + line + right
+	}
+	return style.Render(line)
+}
+
+// buildTopRule draws the top edge of the frame
+func (r *TableRenderer) buildTopRule(columns []table.Column, style lipgloss.Style) string {
+	chars := r.border.Chars
+	return r.buildRule(columns, chars.TopLeft, chars.Top, chars.MiddleTop, chars.TopRight, style)
+}
+
+// buildMiddleRule draws a rule under the header or between data rows
+func (r *TableRenderer) buildMiddleRule(columns []table.Column, style lipgloss.Style) string {
+	chars := r.border.Chars
+	return r.buildRule(columns, chars.MiddleLeft, chars.Top, chars.Middle, chars.MiddleRight, style)
+}
+
+// buildBottomRule draws the bottom edge of the frame
+func (r *TableRenderer) buildBottomRule(columns []table.Column, style lipgloss.Style) string {
+	chars := r.border.Chars
+	return r.buildRule(columns, chars.BottomLeft, chars.Bottom, chars.MiddleBottom, chars.BottomRight, style)
+}
@@ -0,0 +1,131 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// borderTable returns a small table with two columns and three rows
+func borderTable() *table.Table {
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("name", "Name"),
+		*table.NewColumn("age", "Age").WithType(table.Integer),
+	})
+	tbl.SetData([]map[string]interface{}{
+		{"name": "Alice", "age": 30},
+		{"name": "Bob", "age": 25},
+		{"name": "Carol", "age": 41},
+	})
+	return tbl
+}
+
+func TestBorderPresets(t *testing.T) {
+	tests := []struct {
+		border TableBorder
+		lines  int    // Expected line count for the header and three rows
+		first  string // Expected start of the first line
+	}{
+		{DefaultBorder, 5, " Name"},
+		{RoundedBorder, 7, "╭─"},
+		{DoubleBorder, 7, "╔═"},
+		{ASCIIBorder, 7, "+-"},
+		{MarkdownBorder, 5, "| Name"},
+		{NoBorder, 4, " Name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.border.Name, func(t *testing.T) {
+			renderer := NewTableRenderer(40, 24)
+			renderer.SetBorder(tt.border)
+			lines := strings.Split(renderer.RenderTable(borderTable(), 0, -1), "\n")
+
+			if len(lines) != tt.lines {
+				t.Fatalf("Expected %d lines, got %d: %q", tt.lines, len(lines), lines)
+			}
+			if !strings.HasPrefix(lines[0], tt.first) {
+				t.Errorf("Expected first line to start with %q, got %q", tt.first, lines[0])
+			}
+
+			// Every line, rules included, spans the full terminal width
+			for _, line := range lines {
+				if width := lipgloss.Width(line); width != 40 {
+					t.Errorf("Line %q is %d cells wide, expected 40", line, width)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownBorder(t *testing.T) {
+	renderer := NewTableRenderer(24, 24)
+	renderer.SetBorder(MarkdownBorder)
+	renderer.SetWidthStrategy(FixedWidths)
+
+	tbl := borderTable()
+	tbl.Columns[0].Width = 8
+	tbl.Columns[1].Width = 5
+	lines := strings.Split(renderer.RenderTable(tbl, 0, -1), "\n")
+
+	expected := []string{
+		"| Name   | Age |",
+		"|--------|-----|",
+		"| Alice  |  30 |",
+	}
+	for i, want := range expected {
+		if lines[i] != want {
+			t.Errorf("Line %d: expected %q, got %q", i, want, lines[i])
+		}
+	}
+}
+
+func TestRowRules(t *testing.T) {
+	border := RoundedBorder
+	border.RowRules = true
+
+	renderer := NewTableRenderer(40, 24)
+	renderer.SetBorder(border)
+	lines := strings.Split(renderer.RenderTable(borderTable(), 0, -1), "\n")
+
+	// Top, header, header rule, three rows with two rules between, bottom
+	if len(lines) != 9 {
+		t.Fatalf("Expected 9 lines, got %d: %q", len(lines), lines)
+	}
+	if !strings.HasPrefix(lines[4], "├") || !strings.HasPrefix(lines[8], "╰") {
+		t.Errorf("Expected a rule between rows and a bottom edge, got %q", lines)
+	}
+
+	// Rules count towards the lines on a page
+	tbl := borderTable()
+	renderer.PaginateByLines(tbl, 3)
+	if tbl.GetTotalPages() != 2 || len(tbl.GetPage(0)) != 2 {
+		t.Errorf("Expected pages of 2 and 1 rows, got breaks %v", tbl.PageBreaks)
+	}
+}
+
+func TestBorderColor(t *testing.T) {
+	theme := DefaultTheme
+	theme.Border = lipgloss.NewStyle().BorderForeground(lipgloss.Color("#123456"))
+	renderer := NewTableRendererWithTheme(80, 24, &theme)
+	if got := renderer.borderStyle().GetForeground(); got != lipgloss.Color("#123456") {
+		t.Errorf("Expected border foreground from the theme, got %v", got)
+	}
+
+	theme.Border = lipgloss.NewStyle().Foreground(lipgloss.Color("#654321"))
+	renderer.SetTheme(&theme)
+	if got := renderer.borderStyle().GetForeground(); got != lipgloss.Color("#654321") {
+		t.Errorf("Expected text foreground as a fallback, got %v", got)
+	}
+}
+
+func TestGetBorderByName(t *testing.T) {
+	border, ok := GetBorderByName("rounded")
+	if !ok || border.Chars != lipgloss.RoundedBorder() {
+		t.Errorf("Expected to find the rounded border, got %v", border.Name)
+	}
+	if _, ok := GetBorderByName("Nonexistent"); ok {
+		t.Error("Expected no border for an unknown name")
+	}
+}
@@ -0,0 +1,33 @@
+package renderer
+
+import "github.com/anurag-roy/bubbletable/table"
+
+// FooterLines returns the terminal lines a table's footer takes: the footer
+// row and the rule above it, or 0 if the table shows no footer
+func (r *TableRenderer) FooterLines(tbl *table.Table) int {
+	if tbl == nil || tbl.Footer == table.NoFooter || tbl.Source() != nil {
+		return 0
+	}
+	if r.border.HeaderRule {
+		return 2
+	}
+	return 1
+}
+
+// buildFooterRow draws the footer row of aggregates in Theme.Footer, or
+// returns false if the table has no footer
+func (r *TableRenderer) buildFooterRow(tbl *table.Table, layout columnLayout) (string, bool) {
+	text := tbl.FooterText()
+	if text == nil {
+		return "", false
+	}
+
+	return r.buildTableRow(layout.columns, func(i int, col table.Column) string {
+		content := ""
+		if colIndex := layout.indexes[i]; colIndex >= 0 && colIndex < len(text) {
+			content = text[colIndex]
+		}
+		style := r.theme.Footer.Align(lipglossPosition(col.ResolvedAlign()))
+		return r.renderCell(style, content, col.Width)
+	}), true
+}
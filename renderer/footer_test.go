@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+func TestRenderFooter(t *testing.T) {
+	tbl := borderTable().WithFooter(table.FooterView)
+	r := NewTableRenderer(60, 20)
+	r.SetBorder(ASCIIBorder)
+
+	lines := strings.Split(r.RenderTable(tbl, 0, -1), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("Expected 9 lines, got %d:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+
+	// A rule separates the rows from the footer, above the bottom border
+	if lines[6] != lines[2] {
+		t.Errorf("Expected a rule above the footer, got %q", lines[6])
+	}
+	cells := strings.Split(lines[7], "|")
+	if len(cells) != 4 || strings.TrimSpace(cells[1]) != "" || strings.TrimSpace(cells[2]) != "96" {
+		t.Errorf("Expected an age total of 96, got %q", lines[7])
+	}
+	if !strings.HasSuffix(cells[2], "96 ") {
+		t.Errorf("Expected the total aligned like the column, got %q", cells[2])
+	}
+	if r.FooterLines(tbl) != 2 {
+		t.Errorf("Expected the footer to take 2 lines, got %d", r.FooterLines(tbl))
+	}
+
+	// The view's footer follows the search
+	filtered := tbl.Filter("Carol")
+	if output := r.RenderTable(filtered, 0, -1); !strings.Contains(output, "41 |\n") {
+		t.Errorf("Expected the footer to total the filtered rows, got:\n%s", output)
+	}
+}
+
+func TestRenderFooterWithMarks(t *testing.T) {
+	tbl := borderTable().WithFooter(table.FooterAll)
+	r := NewTableRenderer(60, 20)
+	r.SetBorder(DefaultBorder)
+	r.SetMarks(map[int]bool{})
+
+	lines := strings.Split(r.RenderTable(tbl, 0, -1), "\n")
+	footer := lines[len(lines)-1]
+	if strings.Contains(footer, UnmarkedSymbol) || !strings.Contains(footer, "96") {
+		t.Errorf("Expected a footer with a blank marks cell, got %q", footer)
+	}
+	if r.FooterLines(tbl) != 2 {
+		t.Errorf("Expected the footer to take 2 lines, got %d", r.FooterLines(tbl))
+	}
+	r.SetBorder(NoBorder)
+	if r.FooterLines(tbl) != 1 {
+		t.Errorf("Expected the footer to take 1 line without rules, got %d", r.FooterLines(tbl))
+	}
+	if r.FooterLines(borderTable()) != 0 {
+		t.Error("Expected no footer lines without a footer")
+	}
+}
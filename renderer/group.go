@@ -0,0 +1,32 @@
+package renderer
+
+import "github.com/anurag-roy/bubbletable/table"
+
+// buildGroupRow draws a group's header row in Theme.Footer, or
+// Theme.SelectedRow when selected: the group's heading in the first visible
+// column and its aggregates in the others
+func (r *TableRenderer) buildGroupRow(group *table.Group, layout columnLayout, selected bool) string {
+	style := r.theme.Footer
+	if selected {
+		style = r.theme.SelectedRow
+	}
+
+	headingAt := -1
+	for i, colIndex := range layout.indexes {
+		if colIndex >= 0 {
+			headingAt = i
+			break
+		}
+	}
+
+	return r.buildTableRow(layout.columns, func(i int, col table.Column) string {
+		if i == headingAt {
+			return r.renderCell(style, group.Heading(), col.Width)
+		}
+		content := ""
+		if colIndex := layout.indexes[i]; colIndex >= 0 && colIndex < len(group.Aggregates) {
+			content = col.FormatAggregate(group.Aggregates[colIndex])
+		}
+		return r.renderCell(style.Align(lipglossPosition(col.ResolvedAlign())), content, col.Width)
+	})
+}
@@ -0,0 +1,42 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+func TestRenderGroupRows(t *testing.T) {
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("name", "Name").WithWidth(20),
+		*table.NewColumn("team", "Team"),
+		*table.NewColumn("age", "Age").WithType(table.Integer),
+	})
+	tbl.AddRow("Alice", "red", 30)
+	tbl.AddRow("Bob", "blue", 25)
+	tbl.AddRow("Carol", "red", 41)
+	tbl.GroupBy("team")
+
+	r := NewTableRenderer(80, 20)
+	r.SetBorder(ASCIIBorder)
+	r.SetMarks(map[int]bool{})
+	view := tbl.GroupView(nil)
+
+	lines := strings.Split(r.RenderTable(view, 0, 0), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("Expected 9 lines, got %d:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+
+	// Headers show the heading in the first column, subtotals and no mark
+	red := lines[5]
+	if !strings.Contains(red, "▾ Team: red (2)") || !strings.Contains(red, "71 |") {
+		t.Errorf("Expected the red group's heading and total, got %q", red)
+	}
+	if strings.Contains(red, UnmarkedSymbol) {
+		t.Errorf("Expected no mark on a group header, got %q", red)
+	}
+	if !strings.Contains(lines[4], "Bob") || !strings.Contains(lines[4], UnmarkedSymbol) {
+		t.Errorf("Expected Bob's row under the blue header, got %q", lines[4])
+	}
+}
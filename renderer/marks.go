@@ -0,0 +1,52 @@
+package renderer
+
+import (
+	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Marks column symbols
+const (
+	MarkedSymbol   = "[x]"
+	UnmarkedSymbol = "[ ]"
+)
+
+// SetMarks shows a marks column before the first column, checking the rows
+// whose Row.ID is in marked. An empty map shows the column with no rows
+// checked; passing nil hides it.
+func (r *TableRenderer) SetMarks(marked map[int]bool) {
+	r.marks = marked
+}
+
+// marksWidth returns the width of the marks column and the separator after
+// it, or 0 if the column is hidden
+func (r *TableRenderer) marksWidth() int {
+	if r.marks == nil {
+		return 0
+	}
+	return r.marksColumn().Width + lipgloss.Width(r.columnSeparator())
+}
+
+// marksColumn returns the column the marks are drawn in
+func (r *TableRenderer) marksColumn() table.Column {
+	width := lipgloss.Width(MarkedSymbol) + r.theme.Cell.GetHorizontalPadding()
+	return table.Column{Width: width, Align: table.AlignCenter}
+}
+
+// withMarksColumn puts the marks column in front of a layout, with index -1
+func (r *TableRenderer) withMarksColumn(layout columnLayout) columnLayout {
+	if r.marks == nil {
+		return layout
+	}
+	layout.columns = append([]table.Column{r.marksColumn()}, layout.columns...)
+	layout.indexes = append([]int{-1}, layout.indexes...)
+	return layout
+}
+
+// markContent returns the marks column text for a row
+func (r *TableRenderer) markContent(row table.Row) string {
+	if r.marks[row.ID] {
+		return MarkedSymbol
+	}
+	return UnmarkedSymbol
+}
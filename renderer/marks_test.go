@@ -0,0 +1,61 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRenderMarks(t *testing.T) {
+	tbl := borderTable()
+	r := NewTableRenderer(60, 20)
+	r.SetBorder(ASCIIBorder)
+
+	unmarked := r.RenderTable(tbl, 0, 0)
+	if strings.Contains(unmarked, UnmarkedSymbol) {
+		t.Fatal("Expected no marks column without marks")
+	}
+
+	// Marks follow row IDs, not positions
+	r.SetMarks(map[int]bool{1: true})
+	tbl.SortByColumn(1, false) // Bob (ID 1) first
+	lines := strings.Split(r.RenderTable(tbl, 0, -1), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines, got %d:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+
+	wantMarks := []string{MarkedSymbol, UnmarkedSymbol, UnmarkedSymbol} // Bob, Alice, Carol
+	for i, want := range wantMarks {
+		line := lines[3+i]
+		cells := strings.Split(line, "|")
+		if len(cells) < 3 || strings.TrimSpace(cells[1]) != want {
+			t.Errorf("Row %d: expected mark %q, got %q", i, want, line)
+		}
+	}
+
+	// The marks column and its separator fit within the terminal width
+	for _, line := range lines {
+		if width := lipgloss.Width(line); width > 60 {
+			t.Errorf("Line wider than the terminal (%d): %q", width, line)
+		}
+	}
+	if lipgloss.Width(lines[0]) != lipgloss.Width(strings.Split(unmarked, "\n")[0]) {
+		t.Error("Expected the marks column to take space from the other columns")
+	}
+}
+
+func TestRenderMarksWithFilterRow(t *testing.T) {
+	tbl := borderTable()
+	r := NewTableRenderer(60, 20)
+	r.SetMarks(map[int]bool{})
+	r.SetFilterRow([]string{"al", ""}, 0)
+
+	output := r.RenderTable(tbl, 0, 0)
+	if !strings.Contains(output, "al_") {
+		t.Errorf("Expected the filter input next to the marks column, got:\n%s", output)
+	}
+	if strings.Count(output, UnmarkedSymbol) != 3 {
+		t.Errorf("Expected three unmarked rows, got:\n%s", output)
+	}
+}
@@ -2,8 +2,11 @@ package renderer
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
 )
 
 // TableRenderer handles rendering tables to terminal output
@@ -11,6 +14,24 @@ type TableRenderer struct {
 	terminalWidth  int
 	terminalHeight int
 	theme          Theme
+	highlight      []string     // Fuzzy search words to highlight in cells
+	filterRow      []string     // Per-column filter inputs shown under the header (nil hides the row)
+	filterFocus    int          // Column index of the filter being edited (-1 for none)
+	selectedCol    int          // Column index of the cell cursor in the selected row (-1 for none)
+	marks          map[int]bool // Row IDs checked in the marks column (nil hides the column)
+
+	widthStrategy WidthStrategy
+	ellipsis      string // Marks truncated cell text
+	border        TableBorder
+
+	// Horizontal scrolling
+	horizontalScroll bool
+	frozenColumns    int
+	columnOffset     int
+
+	// Row heights and page breaks of wrapped rows (see PaginateByLines)
+	heights rowHeights
+	paged   pagination
 }
 
 // NewTableRenderer creates a new table renderer with default settings
@@ -19,6 +40,10 @@ func NewTableRenderer(width, height int) *TableRenderer {
 		terminalWidth:  width,
 		terminalHeight: height,
 		theme:          DefaultTheme,
+		filterFocus:    -1,
+		selectedCol:    -1,
+		ellipsis:       table.DefaultEllipsis,
+		border:         DefaultBorder,
 	}
 }
 
@@ -28,6 +53,10 @@ func NewTableRendererWithTheme(width, height int, theme *Theme) *TableRenderer {
 		terminalWidth:  width,
 		terminalHeight: height,
 		theme:          *theme,
+		filterFocus:    -1,
+		selectedCol:    -1,
+		ellipsis:       table.DefaultEllipsis,
+		border:         DefaultBorder,
 	}
 }
 
@@ -42,6 +71,33 @@ func (r *TableRenderer) UpdateSize(width, height int) {
 	r.terminalHeight = height
 }
 
+// SetHighlight sets a fuzzy search pattern whose matched characters are
+// styled with Theme.Match in every cell. An empty pattern disables it.
+func (r *TableRenderer) SetHighlight(pattern string) {
+	r.highlight = strings.Fields(pattern)
+}
+
+// SetSelectedColumn sets the column of the cell cursor. The cell at the
+// selected row and this column is drawn with Theme.SelectedCell; -1 disables
+// the cell cursor.
+func (r *TableRenderer) SetSelectedColumn(col int) {
+	r.selectedCol = col
+}
+
+// SetEllipsis sets the text that marks truncated cells. An empty ellipsis
+// cuts text without a marker.
+func (r *TableRenderer) SetEllipsis(ellipsis string) {
+	r.ellipsis = ellipsis
+}
+
+// SetFilterRow shows a row of per-column filter inputs under the header.
+// focus is the index of the column being edited, or -1. Passing nil inputs
+// hides the row.
+func (r *TableRenderer) SetFilterRow(inputs []string, focus int) {
+	r.filterRow = inputs
+	r.filterFocus = focus
+}
+
 // RenderTable renders a table for the given page and selection
 func (r *TableRenderer) RenderTable(tbl *table.Table, currentPage, selectedRow int) string {
 	if tbl == nil || len(tbl.Columns) == 0 {
@@ -50,138 +106,292 @@ func (r *TableRenderer) RenderTable(tbl *table.Table, currentPage, selectedRow i
 
 	var tableRows []string
 
-	// Calculate available width for table content
-	availableWidth := r.terminalWidth
-	if availableWidth < 20 {
-		availableWidth = 80 // Fallback minimum width
+	// Pick the visible columns and fit them to the terminal
+	layout := r.withMarksColumn(r.layoutColumns(tbl.Columns, tbl.RowRange(0, contentSampleRows), r.availableWidth()))
+	adjustedColumns := layout.columns
+
+	// Hidden column indicator
+	if indicator := r.buildScrollIndicator(layout); indicator != "" {
+		tableRows = append(tableRows, indicator)
 	}
 
-	// Adjust column widths to fit terminal
-	adjustedColumns := r.distributeColumnWidths(tbl.Columns, availableWidth)
+	borderStyle := r.borderStyle()
+	if r.border.Top {
+		tableRows = append(tableRows, r.buildTopRule(adjustedColumns, borderStyle))
+	}
 
 	// Header row
 	headerRow := r.buildTableRow(adjustedColumns, func(colIndex int, col table.Column) string {
-		content := r.truncateText(col.Header, col.Width)
-		return r.theme.Header.Width(col.Width).Render(content)
+		return r.renderCell(r.theme.Header.Align(lipglossPosition(col.ResolvedAlign())), col.Header, col.Width)
 	})
 	tableRows = append(tableRows, headerRow)
 
+	// Column filter inputs
+	if r.filterRow != nil {
+		filterRow := r.buildTableRow(adjustedColumns, func(colIndex int, col table.Column) string {
+			colIndex = layout.indexes[colIndex]
+			input := ""
+			if colIndex >= 0 && colIndex < len(r.filterRow) {
+				input = r.filterRow[colIndex]
+			}
+			if colIndex == r.filterFocus {
+				return r.renderCell(r.theme.Search, input+"_", col.Width)
+			}
+			return r.renderCell(r.theme.Status.Padding(0, 1), input, col.Width)
+		})
+		tableRows = append(tableRows, filterRow)
+	}
+
 	// Header separator
-	separatorRow := r.buildSeparatorRow(adjustedColumns)
-	tableRows = append(tableRows, separatorRow)
+	if r.border.HeaderRule {
+		tableRows = append(tableRows, r.buildMiddleRule(adjustedColumns, borderStyle))
+	}
 
 	// Data rows
 	pageData := tbl.GetPage(currentPage)
+	decimals := r.alignDecimalColumns(adjustedColumns, layout.indexes, pageData)
 	for rowIndex, row := range pageData {
 		isSelected := rowIndex == selectedRow
+		if rowIndex > 0 && r.border.RowRules {
+			tableRows = append(tableRows, r.buildMiddleRule(adjustedColumns, borderStyle))
+		}
+		if row.Group != nil {
+			tableRows = append(tableRows, r.buildGroupRow(row.Group, layout, isSelected))
+			continue
+		}
 
-		dataRow := r.buildTableRow(adjustedColumns, func(colIndex int, col table.Column) string {
-			cellValue := ""
-			var cellVal interface{}
-			if colIndex < len(row.Cells) {
-				cell := row.Cells[colIndex]
-				cellVal = cell.Value
-				// Use the column's formatter
-				cellValue = col.Formatter(cell.Value)
-			}
+		// Lay out every cell first so the row is as tall as its tallest cell
+		styles := make([]lipgloss.Style, len(adjustedColumns))
+		contents := make([][]string, len(adjustedColumns))
+		height := 1
+		for i, col := range adjustedColumns {
+			colIndex := layout.indexes[i]
 
-			content := r.truncateText(cellValue, col.Width)
+			style := r.theme.Cell
+			if isSelected {
+				style = r.theme.SelectedRow
+				if colIndex == r.selectedCol && colIndex >= 0 {
+					style = r.theme.SelectedCell
+				}
+			}
+			style = style.Align(lipglossPosition(col.ResolvedAlign()))
 
-			// Use custom renderer if available
-			if col.Renderer != nil {
-				content = col.Renderer(cellVal, isSelected)
-				content = r.truncateText(content, col.Width)
+			var content string
+			if colIndex < 0 {
+				content = r.markContent(row)
+			} else {
+				content = cellContent(col, row, colIndex, isSelected)
+			}
+			if values, ok := decimals[colIndex]; ok {
+				content = values[rowIndex]
 			}
 
-			if isSelected {
-				return r.theme.SelectedRow.Width(col.Width).Render(content)
+			lines := r.cellLines(col, content, cellTextWidth(style, col.Width))
+			if col.Renderer == nil && col.Searchable {
+				for j := range lines {
+					lines[j] = r.highlightMatches(lines[j], style)
+				}
 			}
-			return r.theme.Cell.Width(col.Width).Render(content)
+
+			styles[i] = style
+			contents[i] = lines
+			height = max(height, len(lines))
+		}
+
+		dataRow := r.buildTableRow(adjustedColumns, func(colIndex int, col table.Column) string {
+			return styles[colIndex].Width(col.Width).Height(height).Render(strings.Join(contents[colIndex], "\n"))
 		})
 
 		tableRows = append(tableRows, dataRow)
 	}
 
+	// Footer aggregates
+	if footerRow, ok := r.buildFooterRow(tbl, layout); ok {
+		if r.border.HeaderRule {
+			tableRows = append(tableRows, r.buildMiddleRule(adjustedColumns, borderStyle))
+		}
+		tableRows = append(tableRows, footerRow)
+	}
+
+	if r.border.Bottom {
+		tableRows = append(tableRows, r.buildBottomRule(adjustedColumns, borderStyle))
+	}
+
 	// Join all table content
 	tableContent := strings.Join(tableRows, "\n")
 
 	return tableContent
 }
 
-// distributeColumnWidths distributes available width across columns intelligently
-func (r *TableRenderer) distributeColumnWidths(columns []table.Column, availableWidth int) []table.Column {
-	if len(columns) == 0 {
-		return columns
+// highlightMatches styles the characters of text matched by the highlight
+// pattern with Theme.Match, layered over the cell's own style
+func (r *TableRenderer) highlightMatches(text string, base lipgloss.Style) string {
+	if len(r.highlight) == 0 {
+		return text
+	}
+
+	matched := make(map[int]bool)
+	for _, word := range r.highlight {
+		if _, positions, ok := table.FuzzyMatch(word, text); ok {
+			for _, pos := range positions {
+				matched[pos] = true
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return text
+	}
+
+	plain := base.UnsetPadding().UnsetWidth()
+	match := r.theme.Match.Inherit(plain)
+
+	// Style whole grapheme clusters so that escape sequences never split a
+	// combining mark or emoji sequence
+	var b strings.Builder
+	var segment strings.Builder
+	segmentMatched := false
+	flush := func() {
+		if segmentMatched {
+			b.WriteString(match.Render(segment.String()))
+		} else {
+			b.WriteString(plain.Render(segment.String()))
+		}
+		segment.Reset()
 	}
 
-	adjusted := make([]table.Column, len(columns))
-	copy(adjusted, columns)
+	pos := 0 // Rune index, as reported by FuzzyMatch
+	state := -1
+	rest := text
+	for rest != "" {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+
+		clusterMatched := false
+		for n := utf8.RuneCountInString(cluster); n > 0; n-- {
+			clusterMatched = clusterMatched || matched[pos]
+			pos++
+		}
 
-	// Account for separators between columns
-	separatorWidth := len(columns) - 1
-	contentWidth := availableWidth - separatorWidth
+		if segment.Len() > 0 && clusterMatched != segmentMatched {
+			flush()
+		}
+		segmentMatched = clusterMatched
+		segment.WriteString(cluster)
+	}
+	flush()
+	return b.String()
+}
 
-	// Ensure minimum content width
-	if contentWidth < len(columns)*5 {
-		contentWidth = len(columns) * 5
+// lipglossPosition maps a column alignment to a lipgloss horizontal position
+func lipglossPosition(align table.Alignment) lipgloss.Position {
+	switch align {
+	case table.AlignRight, table.AlignDecimal:
+		return lipgloss.Right
+	case table.AlignCenter:
+		return lipgloss.Center
+	default:
+		return lipgloss.Left
 	}
+}
 
-	// Calculate ideal width per column
-	baseWidth := contentWidth / len(columns)
-	remainder := contentWidth % len(columns)
+// alignDecimalColumns formats the page's cells in decimal-aligned columns
+// so their decimal points line up, keyed by original column index. Cells
+// whose padded value would not fit the column are left unpadded.
+func (r *TableRenderer) alignDecimalColumns(columns []table.Column, indexes []int, rows []table.Row) map[int][]string {
+	var decimals map[int][]string
+	for i, col := range columns {
+		if col.ResolvedAlign() != table.AlignDecimal || col.Renderer != nil {
+			continue
+		}
 
-	// Distribute width, giving extra to first few columns
-	for i := range adjusted {
-		adjusted[i].Width = baseWidth
-		if i < remainder {
-			adjusted[i].Width++
+		colIndex := indexes[i]
+		values := make([]string, len(rows))
+		for rowIndex, row := range rows {
+			if colIndex < len(row.Cells) && row.Group == nil {
+				values[rowIndex] = col.Format(row.Cells[colIndex].Value)
+			}
 		}
 
-		// Ensure minimum width
-		if adjusted[i].Width < 5 {
-			adjusted[i].Width = 5
+		if decimals == nil {
+			decimals = make(map[int][]string)
+		}
+		textWidth := cellTextWidth(r.theme.Cell, col.Width)
+		for rowIndex, aligned := range table.AlignDecimals(values) {
+			if table.DisplayWidth(aligned) <= textWidth {
+				values[rowIndex] = aligned
+			}
 		}
+		decimals[colIndex] = values
 	}
-
-	return adjusted
+	return decimals
 }
 
-// buildTableRow builds a table row using the provided cell renderer function
+// buildTableRow builds a table row using the provided cell renderer function.
+// Cells may span several lines; shorter cells are padded with blank lines.
 func (r *TableRenderer) buildTableRow(columns []table.Column, cellRenderer func(int, table.Column) string) string {
 	var cells []string
+	height := 1
 	for i, col := range columns {
-		cells = append(cells, cellRenderer(i, col))
+		cell := cellRenderer(i, col)
+		cells = append(cells, cell)
+		height = max(height, lipgloss.Height(cell))
 	}
-	return strings.Join(cells, "│")
-}
 
-// buildSeparatorRow builds a separator row between header and data
-func (r *TableRenderer) buildSeparatorRow(columns []table.Column) string {
-	var separators []string
-	for _, col := range columns {
-		separator := strings.Repeat("─", col.Width+2) // +2 for padding
-		separators = append(separators, separator)
+	style := r.borderStyle()
+	separator := style.Render(r.columnSeparator())
+	joinLine := func(parts []string) string {
+		line := strings.Join(parts, separator)
+		if r.border.Sides {
+			line = style.Render(r.border.Chars.Left) + line + style.Render(r.border.Chars.Right)
+		}
+		return line
+	}
+	if height == 1 {
+		return joinLine(cells)
 	}
-	return strings.Join(separators, "┼")
-}
 
-// truncateText truncates text to fit within the specified width
-func (r *TableRenderer) truncateText(text string, width int) string {
-	if len(text) <= width {
-		return text
+	cellLines := make([][]string, len(cells))
+	for i, cell := range cells {
+		cellLines[i] = strings.Split(cell, "\n")
 	}
 
-	if width <= 3 {
-		return text[:width]
+	lines := make([]string, height)
+	parts := make([]string, len(cells))
+	for line := range lines {
+		for i, col := range columns {
+			if line < len(cellLines[i]) {
+				parts[i] = cellLines[i][line]
+			} else {
+				parts[i] = strings.Repeat(" ", col.Width)
+			}
+		}
+		lines[line] = joinLine(parts)
 	}
+	return strings.Join(lines, "\n")
+}
 
-	return text[:width-3] + "..."
+// truncateText truncates text to fit within the specified display width
+func (r *TableRenderer) truncateText(text string, width int) string {
+	return table.TruncateText(text, width, r.ellipsis)
 }
 
-// GetOptimalPageSize calculates the optimal page size based on terminal height
+// renderCell renders text in a cell of the given width, truncating it to
+// the room left by the style's padding so that it never wraps
+func (r *TableRenderer) renderCell(style lipgloss.Style, text string, width int) string {
+	return style.Width(width).Render(r.truncateText(text, cellTextWidth(style, width)))
+}
+
+// cellTextWidth returns the display width available for text in a cell
+func cellTextWidth(style lipgloss.Style, width int) int {
+	return max(width-style.GetHorizontalPadding(), 1)
+}
+
+// GetOptimalPageSize calculates the optimal page size based on terminal
+// height. It counts terminal lines available for data rows, which is also
+// the number of rows per page unless columns wrap (see PaginateByLines).
 func (r *TableRenderer) GetOptimalPageSize() int {
 	// Reserve space for header, separator, status, and some padding
-	reservedLines := 10
+	reservedLines := 10 + r.frameLines()
 	availableLines := r.terminalHeight - reservedLines
 
 	if availableLines < 5 {
@@ -201,7 +411,9 @@ func (r *TableRenderer) GetTableCapacity() int {
 	return r.GetOptimalPageSize()
 }
 
-// CalculateColumnWidths calculates optimal column widths based on content
+// CalculateColumnWidths calculates optimal column widths based on content,
+// measured in terminal display cells and bounded by each column's
+// MinWidth and MaxWidth (5 and 50 when unset)
 func (r *TableRenderer) CalculateColumnWidths(tbl *table.Table, maxSampleRows int) []table.Column {
 	if tbl == nil || len(tbl.Columns) == 0 {
 		return []table.Column{}
@@ -211,34 +423,16 @@ func (r *TableRenderer) CalculateColumnWidths(tbl *table.Table, maxSampleRows in
 	copy(columns, tbl.Columns)
 
 	// Sample up to maxSampleRows to determine optimal widths
-	sampleSize := len(tbl.Rows)
-	if sampleSize > maxSampleRows {
-		sampleSize = maxSampleRows
-	}
-
-	// Calculate max width needed for each column
-	for i, col := range columns {
-		maxWidth := len(col.Header) // Start with header width
-
-		// Check sample data
-		for j := 0; j < sampleSize; j++ {
-			if i < len(tbl.Rows[j].Cells) {
-				cellValue := col.Formatter(tbl.Rows[j].Cells[i].Value)
-				if len(cellValue) > maxWidth {
-					maxWidth = len(cellValue)
-				}
-			}
-		}
-
-		// Set reasonable bounds
-		if maxWidth < 5 {
-			maxWidth = 5
+	widths := measureContentWidths(columns, tbl.RowRange(0, maxSampleRows))
+	for i := range columns {
+		bounded := columns[i]
+		if bounded.MinWidth == 0 {
+			bounded.MinWidth = 5
 		}
-		if maxWidth > 50 {
-			maxWidth = 50
+		if bounded.MaxWidth == 0 {
+			bounded.MaxWidth = 50
 		}
-
-		columns[i].Width = maxWidth
+		columns[i].Width = clampColumnWidth(bounded, widths[i])
 	}
 
 	return columns
@@ -68,7 +68,7 @@ func BenchmarkRenderLargeTable(b *testing.B) {
 	}
 }
 
-func BenchmarkDistributeColumnWidths(b *testing.B) {
+func BenchmarkEvenWidths(b *testing.B) {
 	columns := []table.Column{
 		*table.NewColumn("col1", "Column 1"),
 		*table.NewColumn("col2", "Column 2"),
@@ -76,11 +76,10 @@ func BenchmarkDistributeColumnWidths(b *testing.B) {
 		*table.NewColumn("col4", "Column 4"),
 		*table.NewColumn("col5", "Column 5"),
 	}
-	renderer := NewTableRenderer(80, 24)
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		renderer.distributeColumnWidths(columns, 70)
+		EvenWidths(columns, nil, 70-(len(columns)-1), 1)
 	}
 }
 
@@ -163,3 +162,16 @@ func BenchmarkRenderNarrowTerminal(b *testing.B) {
 		renderer.RenderTable(tbl, 0, 0)
 	}
 }
+
+func BenchmarkRenderMillionRows(b *testing.B) {
+	data := generateRendererBenchData(1_000_000)
+	tbl := table.New().WithPageSize(40)
+	tbl.SetData(data)
+	tbl.SortByColumn(1, true)
+	renderer := NewTableRenderer(120, 50)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		renderer.RenderTable(tbl, 12_345, 0) // A page deep into the table
+	}
+}
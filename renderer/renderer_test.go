@@ -1,10 +1,14 @@
 package renderer
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // TestNewTableRenderer tests renderer creation
@@ -96,6 +100,214 @@ func TestRenderTable(t *testing.T) {
 	}
 }
 
+// TestHighlightMatches tests fuzzy match highlighting in cells
+func TestHighlightMatches(t *testing.T) {
+	theme := DefaultTheme
+	// Transforms apply regardless of the terminal's color profile
+	theme.Match = lipgloss.NewStyle().Transform(strings.ToUpper)
+	renderer := NewTableRendererWithTheme(80, 24, &theme)
+
+	if got := renderer.highlightMatches("foo bar", theme.Cell); got != "foo bar" {
+		t.Errorf("Expected no highlighting without a pattern, got %q", got)
+	}
+
+	renderer.SetHighlight("fb")
+	if got := renderer.highlightMatches("foo bar", theme.Cell); got != "Foo Bar" {
+		t.Errorf("Expected matched characters to be highlighted, got %q", got)
+	}
+
+	if got := renderer.highlightMatches("xyz", theme.Cell); got != "xyz" {
+		t.Errorf("Expected non-matching text to be unchanged, got %q", got)
+	}
+
+	tbl := table.NewWithColumns([]table.Column{*table.NewColumn("name", "Name")})
+	tbl.SetData([]map[string]interface{}{{"name": "foo bar"}})
+	if output := renderer.RenderTable(tbl, 0, -1); !strings.Contains(output, "Foo Bar") {
+		t.Errorf("Expected rendered table to highlight matches, got %q", output)
+	}
+}
+
+// TestRenderSelectedCell tests the cell cursor style
+func TestRenderSelectedCell(t *testing.T) {
+	theme := DefaultTheme
+	theme.SelectedCell = theme.SelectedRow.Transform(strings.ToUpper)
+	renderer := NewTableRendererWithTheme(80, 24, &theme)
+
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("first", "First"),
+		*table.NewColumn("last", "Last"),
+	})
+	tbl.SetData([]map[string]interface{}{
+		{"first": "ada", "last": "lovelace"},
+		{"first": "alan", "last": "turing"},
+	})
+
+	output := renderer.RenderTable(tbl, 0, 0)
+	if strings.Contains(output, "LOVELACE") {
+		t.Error("Expected no cell cursor by default")
+	}
+
+	renderer.SetSelectedColumn(1)
+	output = renderer.RenderTable(tbl, 0, 0)
+	if !strings.Contains(output, "LOVELACE") || strings.Contains(output, "ADA") {
+		t.Errorf("Expected only the focused cell to use SelectedCell, got %q", output)
+	}
+	if strings.Contains(output, "TURING") {
+		t.Error("Cells in unselected rows should not use SelectedCell")
+	}
+}
+
+// TestRenderWideCharacters tests that CJK, combining marks, emoji and styled
+// cells are truncated to their column without wrapping
+func TestRenderWideCharacters(t *testing.T) {
+	styled := lipgloss.NewStyle().Bold(true)
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("name", "名前"),
+		*table.NewColumn("status", "Status").WithRenderer(func(value interface{}, selected bool) string {
+			return styled.Render(fmt.Sprintf("● %v", value))
+		}),
+	})
+	tbl.SetData([]map[string]interface{}{
+		{"name": "東京都渋谷区神南一丁目", "status": "active and healthy"},
+		{"name": "crème brûlée à la carte", "status": "ok"},
+		{"name": "👩‍💻👨‍👩‍👧‍👦🏳️‍🌈 family and friends", "status": "away"},
+	})
+
+	renderer := NewTableRenderer(30, 24)
+	renderer.SetEllipsis("…")
+	lines := strings.Split(renderer.RenderTable(tbl, 0, -1), "\n")
+
+	// Header, separator and one line per row: any wrapping adds lines
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 lines, got %d: %q", len(lines), lines)
+	}
+	for _, line := range lines {
+		if width := lipgloss.Width(line); width != lipgloss.Width(lines[0]) {
+			t.Errorf("Line %q is %d cells wide, expected %d", line, width, lipgloss.Width(lines[0]))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("Line %q is not valid UTF-8", line)
+		}
+	}
+	if !strings.Contains(lines[2], "…") {
+		t.Errorf("Expected truncated cells to end with the ellipsis, got %q", lines[2])
+	}
+	if !strings.Contains(lines[4], "👩‍💻👨‍👩‍👧‍👦") {
+		t.Errorf("Expected emoji sequences to stay whole, got %q", lines[4])
+	}
+}
+
+// TestHighlightGraphemeClusters tests that highlighting never splits a
+// grapheme cluster with escape sequences
+func TestHighlightGraphemeClusters(t *testing.T) {
+	theme := DefaultTheme
+	theme.Match = lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	renderer := NewTableRendererWithTheme(80, 24, &theme)
+
+	renderer.SetHighlight("e")
+	got := renderer.highlightMatches("cafe\u0301", lipgloss.NewStyle())
+	if got != "caf[e\u0301]" {
+		t.Errorf("Expected the combining mark to be highlighted with its base, got %q", got)
+	}
+
+	renderer.SetHighlight("x")
+	got = renderer.highlightMatches("👩\u200d💻x", lipgloss.NewStyle())
+	if got != "👩\u200d💻[x]" {
+		t.Errorf("Expected the emoji sequence to stay whole, got %q", got)
+	}
+}
+
+// TestRenderAlignment tests column alignment, including numeric defaults
+// and decimal point alignment
+func TestRenderAlignment(t *testing.T) {
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("name", "Name").WithWidth(10),
+		*table.NewColumn("qty", "Qty").WithType(table.Integer).WithWidth(8),
+		*table.NewColumn("price", "Price").WithType(table.Float).WithWidth(12).
+			WithAlign(table.AlignDecimal).WithFormatter(func(v interface{}) string {
+			return strconv.FormatFloat(v.(float64), 'f', -1, 64)
+		}),
+		*table.NewColumn("tag", "Tag").WithWidth(8).WithAlign(table.AlignCenter),
+	})
+	tbl.SetData([]map[string]interface{}{
+		{"name": "bolts", "qty": 5, "price": 1234.5, "tag": "ab"},
+		{"name": "nuts", "qty": 120, "price": 7.25, "tag": "abcd"},
+		{"name": "gears", "qty": 42, "price": 10.0, "tag": "x"},
+	})
+
+	renderer := NewTableRenderer(80, 24)
+	renderer.SetWidthStrategy(FixedWidths)
+	lines := strings.Split(renderer.RenderTable(tbl, 0, -1), "\n")
+
+	cells := func(line string) []string {
+		return strings.Split(line, "│")
+	}
+
+	if got := cells(lines[0])[1]; got != "    Qty " {
+		t.Errorf("Expected numeric header to be right-aligned, got %q", got)
+	}
+	if got := cells(lines[3])[1]; got != "    120 " {
+		t.Errorf("Expected integers to be right-aligned, got %q", got)
+	}
+	if got := cells(lines[2])[3]; got != "   ab   " {
+		t.Errorf("Expected centered text, got %q", got)
+	}
+
+	expected := []string{"    1234.5  ", "       7.25 ", "      10    "}
+	for i, want := range expected {
+		if got := cells(lines[i+2])[2]; got != want {
+			t.Errorf("Row %d: expected decimal-aligned %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestRenderDecimalColumnWithoutFormatter(t *testing.T) {
+	tbl := table.NewWithColumns([]table.Column{
+		{Key: "price", Header: "Price", Type: table.Float, Width: 12, Align: table.AlignDecimal, NullText: "n/a"},
+	})
+	tbl.AddRow(1234.5)
+	tbl.AddRow(nil)
+	tbl.AddRow(7.25)
+
+	renderer := NewTableRenderer(80, 24)
+	renderer.SetWidthStrategy(FixedWidths)
+	lines := strings.Split(renderer.RenderTable(tbl, 0, -1), "\n")
+
+	if got := lines[3]; !strings.Contains(got, "n/a") {
+		t.Errorf("Expected the nil cell to show NullText, got %q", got)
+	}
+	if strings.Index(lines[2], ".") != strings.Index(lines[4], ".") {
+		t.Errorf("Expected decimal points to line up, got %q and %q", lines[2], lines[4])
+	}
+}
+
+// TestRenderFilterRow tests the per-column filter row under the header
+func TestRenderFilterRow(t *testing.T) {
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("name", "Name"),
+		*table.NewColumn("age", "Age").WithType(table.Integer),
+	})
+	tbl.SetData([]map[string]interface{}{{"name": "Alice", "age": 30}})
+
+	renderer := NewTableRenderer(80, 24)
+	without := strings.Split(renderer.RenderTable(tbl, 0, 0), "\n")
+
+	renderer.SetFilterRow([]string{"ali", "20..40"}, 1)
+	with := strings.Split(renderer.RenderTable(tbl, 0, 0), "\n")
+
+	if len(with) != len(without)+1 {
+		t.Fatalf("Expected filter row to add one line, got %d vs %d", len(with), len(without))
+	}
+	if !strings.Contains(with[1], "ali") || !strings.Contains(with[1], "20..40_") {
+		t.Errorf("Expected filter inputs under the header, got %q", with[1])
+	}
+
+	renderer.SetFilterRow(nil, -1)
+	if got := strings.Split(renderer.RenderTable(tbl, 0, 0), "\n"); len(got) != len(without) {
+		t.Error("Expected nil inputs to hide the filter row")
+	}
+}
+
 // TestRenderEmptyTable tests rendering empty table
 func TestRenderEmptyTable(t *testing.T) {
 	renderer := NewTableRenderer(80, 24)
@@ -125,24 +337,22 @@ func TestGetOptimalPageSize(t *testing.T) {
 	}
 }
 
-// TestDistributeColumnWidths tests column width distribution
-func TestDistributeColumnWidths(t *testing.T) {
-	renderer := NewTableRenderer(80, 24)
-
+// TestEvenWidthsFillSpace tests column width distribution
+func TestEvenWidthsFillSpace(t *testing.T) {
 	columns := []table.Column{
 		{Header: "Name", Width: 10},
 		{Header: "Age", Width: 5},
 		{Header: "City", Width: 15},
 	}
 
-	adjusted := renderer.distributeColumnWidths(columns, 60)
+	widths := EvenWidths(columns, nil, 60-(len(columns)-1), 1)
 
 	// Check total width allocation
 	totalWidth := 0
-	for _, col := range adjusted {
-		totalWidth += col.Width
-		if col.Width < 5 {
-			t.Errorf("Column width too small: %d", col.Width)
+	for _, width := range widths {
+		totalWidth += width
+		if width < 5 {
+			t.Errorf("Column width too small: %d", width)
 		}
 	}
 
@@ -167,6 +377,8 @@ func TestTruncateText(t *testing.T) {
 		{"Hi", 3, "Hi"},
 		{"Test", 2, "Te"},
 		{"A", 1, "A"},
+		{"日本語テキスト", 7, "日本..."},
+		{"👩‍💻 developer", 6, "👩‍💻 ..."},
 	}
 
 	for _, test := range tests {
@@ -213,8 +425,6 @@ func TestRenderTableWithSorting(t *testing.T) {
 }
 
 func TestMinimumColumnWidth(t *testing.T) {
-	renderer := NewTableRenderer(20, 24) // Very narrow terminal
-
 	columns := []table.Column{
 		*table.NewColumn("col1", "Column 1"),
 		*table.NewColumn("col2", "Column 2"),
@@ -222,12 +432,13 @@ func TestMinimumColumnWidth(t *testing.T) {
 		*table.NewColumn("col4", "Column 4"),
 	}
 
-	adjusted := renderer.distributeColumnWidths(columns, 20)
+	// A very narrow terminal
+	widths := EvenWidths(columns, nil, 20-(len(columns)-1), 1)
 
 	// All columns should have minimum width of 5
-	for i, col := range adjusted {
-		if col.Width < 5 {
-			t.Errorf("Column %d width should be at least 5, got %d", i, col.Width)
+	for i, width := range widths {
+		if width < 5 {
+			t.Errorf("Column %d width should be at least 5, got %d", i, width)
 		}
 	}
 }
@@ -0,0 +1,156 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+// columnLayout describes which columns are drawn and how wide they are
+type columnLayout struct {
+	columns     []table.Column // Visible columns with their render widths
+	indexes     []int          // Original column index of each visible column
+	hiddenLeft  int            // Scrollable columns hidden to the left
+	hiddenRight int            // Columns hidden to the right
+}
+
+// SetHorizontalScroll enables horizontal scrolling. Columns keep their
+// declared Width and only as many as fit the terminal are drawn; otherwise
+// every column is squeezed to fit.
+func (r *TableRenderer) SetHorizontalScroll(enabled bool) {
+	r.horizontalScroll = enabled
+}
+
+// SetFrozenColumns keeps the first n columns visible while the rest scroll
+func (r *TableRenderer) SetFrozenColumns(n int) {
+	if n < 0 {
+		n = 0
+	}
+	r.frozenColumns = n
+}
+
+// SetColumnOffset sets the first scrollable column shown after the frozen ones
+func (r *TableRenderer) SetColumnOffset(offset int) {
+	r.columnOffset = offset
+}
+
+// GetColumnOffset returns the first scrollable column shown after the frozen ones
+func (r *TableRenderer) GetColumnOffset() int {
+	return r.columnOffset
+}
+
+// ScrollToColumn adjusts the column offset so that column col is visible
+func (r *TableRenderer) ScrollToColumn(columns []table.Column, col int) {
+	if !r.horizontalScroll || col < r.frozenColumns || col >= len(columns) {
+		return
+	}
+
+	if col < r.columnOffset {
+		r.columnOffset = col
+		return
+	}
+
+	for r.columnOffset < col {
+		layout := r.layoutColumns(columns, nil, r.availableWidth())
+		if len(layout.indexes) > 0 && layout.indexes[len(layout.indexes)-1] >= col {
+			return
+		}
+		r.columnOffset++
+	}
+}
+
+// availableWidth returns the width available for table content
+func (r *TableRenderer) availableWidth() int {
+	availableWidth := r.terminalWidth
+	if availableWidth < 20 {
+		availableWidth = 80 // Fallback minimum width
+	}
+	return availableWidth - r.frameWidth() - r.marksWidth()
+}
+
+// layoutColumns picks the visible columns and their widths. When scrolling,
+// columns keep their declared Width; otherwise the width strategy fits them
+// to the terminal using rows as a content sample.
+func (r *TableRenderer) layoutColumns(columns []table.Column, rows []table.Row, availableWidth int) columnLayout {
+	if !r.horizontalScroll {
+		indexes := make([]int, len(columns))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return columnLayout{
+			columns: r.applyWidthStrategy(columns, rows, availableWidth),
+			indexes: indexes,
+		}
+	}
+
+	frozen := r.frozenColumns
+	if frozen > len(columns) {
+		frozen = len(columns)
+	}
+	offset := max(r.columnOffset, frozen)
+	if offset >= len(columns) && frozen < len(columns) {
+		offset = len(columns) - 1
+	}
+
+	var layout columnLayout
+	usedWidth := 0
+	add := func(i int) bool {
+		col := columns[i]
+		col.Width = clampColumnWidth(col, col.Width)
+		needed := col.Width
+		if len(layout.columns) > 0 {
+			needed++ // Separator
+		}
+		// Always draw at least one scrollable column, even if it overflows
+		if usedWidth+needed > availableWidth && i > offset {
+			return false
+		}
+		layout.columns = append(layout.columns, col)
+		layout.indexes = append(layout.indexes, i)
+		usedWidth += needed
+		return true
+	}
+
+	for i := 0; i < frozen; i++ {
+		add(i)
+	}
+	last := offset - 1
+	for i := offset; i < len(columns); i++ {
+		if !add(i) {
+			break
+		}
+		last = i
+	}
+
+	layout.hiddenLeft = offset - frozen
+	layout.hiddenRight = len(columns) - 1 - last
+	return layout
+}
+
+// buildScrollIndicator builds the line above the header that reports how
+// many columns are scrolled out of view on each side
+func (r *TableRenderer) buildScrollIndicator(layout columnLayout) string {
+	if layout.hiddenLeft == 0 && layout.hiddenRight == 0 {
+		return ""
+	}
+
+	left, right := "", ""
+	if layout.hiddenLeft > 0 {
+		left = fmt.Sprintf("◀ %d more", layout.hiddenLeft)
+	}
+	if layout.hiddenRight > 0 {
+		right = fmt.Sprintf("%d more ▶", layout.hiddenRight)
+	}
+
+	tableWidth := len(layout.columns) - 1 + r.frameWidth()
+	for _, col := range layout.columns {
+		tableWidth += col.Width
+	}
+	gap := tableWidth - table.DisplayWidth(left) - table.DisplayWidth(right)
+	if gap < 1 {
+		gap = 1
+	}
+
+	return r.theme.Status.Render(left + strings.Repeat(" ", gap) + right)
+}
@@ -0,0 +1,112 @@
+package renderer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+// wideTable builds a table with n columns of width 10
+func wideTable(n int) *table.Table {
+	columns := make([]table.Column, n)
+	row := make(map[string]interface{})
+	for i := range columns {
+		key := fmt.Sprintf("c%d", i)
+		columns[i] = *table.NewColumn(key, fmt.Sprintf("Col%d", i)).WithWidth(10)
+		row[key] = fmt.Sprintf("v%d", i)
+	}
+	tbl := table.NewWithColumns(columns)
+	tbl.SetData([]map[string]interface{}{row})
+	return tbl
+}
+
+// TestLayoutColumnsScroll tests which columns are visible when scrolling
+func TestLayoutColumnsScroll(t *testing.T) {
+	tbl := wideTable(20)
+	renderer := NewTableRenderer(50, 24)
+	renderer.SetHorizontalScroll(true)
+
+	// 4 columns of width 10 plus 3 separators fit in 50
+	layout := renderer.layoutColumns(tbl.Columns, nil, 50)
+	if !reflect.DeepEqual(layout.indexes, []int{0, 1, 2, 3}) {
+		t.Errorf("Expected columns 0-3 visible, got %v", layout.indexes)
+	}
+	if layout.hiddenLeft != 0 || layout.hiddenRight != 16 {
+		t.Errorf("Expected 0 hidden left and 16 hidden right, got %d and %d", layout.hiddenLeft, layout.hiddenRight)
+	}
+	for _, col := range layout.columns {
+		if col.Width != 10 {
+			t.Errorf("Expected declared width 10 to be kept, got %d", col.Width)
+		}
+	}
+
+	// Frozen columns stay in place while the rest scroll
+	renderer.SetFrozenColumns(1)
+	renderer.SetColumnOffset(5)
+	layout = renderer.layoutColumns(tbl.Columns, nil, 50)
+	if !reflect.DeepEqual(layout.indexes, []int{0, 5, 6, 7}) {
+		t.Errorf("Expected columns 0 and 5-7 visible, got %v", layout.indexes)
+	}
+	if layout.hiddenLeft != 4 || layout.hiddenRight != 12 {
+		t.Errorf("Expected 4 hidden left and 12 hidden right, got %d and %d", layout.hiddenLeft, layout.hiddenRight)
+	}
+
+	// Without scrolling every column is squeezed in
+	renderer.SetHorizontalScroll(false)
+	layout = renderer.layoutColumns(tbl.Columns, nil, 50)
+	if len(layout.indexes) != 20 {
+		t.Errorf("Expected all 20 columns without scrolling, got %d", len(layout.indexes))
+	}
+}
+
+// TestScrollToColumn tests following the focused column
+func TestScrollToColumn(t *testing.T) {
+	tbl := wideTable(20)
+	renderer := NewTableRenderer(50, 24)
+	renderer.SetHorizontalScroll(true)
+	renderer.SetFrozenColumns(1)
+
+	renderer.ScrollToColumn(tbl.Columns, 10)
+	layout := renderer.layoutColumns(tbl.Columns, nil, 50)
+	if last := layout.indexes[len(layout.indexes)-1]; last != 10 {
+		t.Errorf("Expected column 10 to be the last visible column, got %d", last)
+	}
+
+	renderer.ScrollToColumn(tbl.Columns, 3)
+	if renderer.GetColumnOffset() != 3 {
+		t.Errorf("Expected offset 3 after scrolling left, got %d", renderer.GetColumnOffset())
+	}
+
+	// Frozen columns never need scrolling
+	renderer.ScrollToColumn(tbl.Columns, 0)
+	if renderer.GetColumnOffset() != 3 {
+		t.Errorf("Expected offset to stay 3 for a frozen column, got %d", renderer.GetColumnOffset())
+	}
+}
+
+// TestRenderScrollIndicator tests the hidden column indicator
+func TestRenderScrollIndicator(t *testing.T) {
+	tbl := wideTable(20)
+	renderer := NewTableRenderer(50, 24)
+	renderer.SetHorizontalScroll(true)
+	renderer.SetFrozenColumns(1)
+	renderer.SetColumnOffset(5)
+
+	lines := strings.Split(renderer.RenderTable(tbl, 0, 0), "\n")
+	if !strings.Contains(lines[0], "◀ 4 more") || !strings.Contains(lines[0], "12 more ▶") {
+		t.Errorf("Expected hidden column counts above the header, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "Col0") || !strings.Contains(lines[1], "Col5") || strings.Contains(lines[1], "Col4") {
+		t.Errorf("Expected frozen Col0 followed by Col5, got %q", lines[1])
+	}
+
+	// No indicator when everything fits
+	renderer.SetHorizontalScroll(false)
+	lines = strings.Split(renderer.RenderTable(tbl, 0, 0), "\n")
+	if strings.Contains(lines[0], "more") {
+		t.Errorf("Expected no indicator without scrolling, got %q", lines[0])
+	}
+}
@@ -4,13 +4,16 @@ import "github.com/charmbracelet/lipgloss"
 
 // Theme represents a complete styling theme for tables
 type Theme struct {
-	Name        string
-	Header      lipgloss.Style
-	Cell        lipgloss.Style
-	SelectedRow lipgloss.Style
-	Border      lipgloss.Style
-	Status      lipgloss.Style
-	Search      lipgloss.Style
+	Name         string
+	Header       lipgloss.Style
+	Cell         lipgloss.Style
+	SelectedRow  lipgloss.Style
+	SelectedCell lipgloss.Style
+	Border       lipgloss.Style
+	Status       lipgloss.Style
+	Search       lipgloss.Style
+	Match        lipgloss.Style
+	Footer       lipgloss.Style // Footer row of column aggregates
 }
 
 // Predefined themes
@@ -29,6 +32,11 @@ var (
 			Background(lipgloss.Color("#44475A")).
 			Foreground(lipgloss.Color("#F8F8F2")).
 			Padding(0, 1),
+		SelectedCell: lipgloss.NewStyle().
+			Background(lipgloss.Color("#FFB86C")).
+			Foreground(lipgloss.Color("#282A36")).
+			Bold(true).
+			Padding(0, 1),
 		Border: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#874BFD")),
@@ -39,6 +47,14 @@ var (
 			Foreground(lipgloss.Color("#FFB86C")).
 			Background(lipgloss.Color("#282A36")).
 			Padding(0, 1),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFB86C")).
+			Bold(true).
+			Underline(true),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#C4A9F4")).
+			Bold(true).
+			Padding(0, 1),
 	}
 
 	// DraculaTheme is based on the popular Dracula color scheme
@@ -57,6 +73,11 @@ var (
 			Foreground(lipgloss.Color("#F8F8F2")).
 			Bold(true).
 			Padding(0, 1),
+		SelectedCell: lipgloss.NewStyle().
+			Background(lipgloss.Color("#FF79C6")).
+			Foreground(lipgloss.Color("#282A36")).
+			Bold(true).
+			Padding(0, 1),
 		Border: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#BD93F9")),
@@ -67,6 +88,14 @@ var (
 			Foreground(lipgloss.Color("#50FA7B")).
 			Background(lipgloss.Color("#44475A")).
 			Padding(0, 1),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#50FA7B")).
+			Bold(true).
+			Underline(true),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#BD93F9")).
+			Bold(true).
+			Padding(0, 1),
 	}
 
 	// MonokaiTheme is inspired by the Monokai color scheme
@@ -84,6 +113,11 @@ var (
 			Background(lipgloss.Color("#75715E")).
 			Foreground(lipgloss.Color("#F8F8F2")).
 			Padding(0, 1),
+		SelectedCell: lipgloss.NewStyle().
+			Background(lipgloss.Color("#A6E22E")).
+			Foreground(lipgloss.Color("#272822")).
+			Bold(true).
+			Padding(0, 1),
 		Border: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#A6E22E")),
@@ -94,6 +128,14 @@ var (
 			Foreground(lipgloss.Color("#FD971F")).
 			Background(lipgloss.Color("#49483E")).
 			Padding(0, 1),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F92672")).
+			Bold(true).
+			Underline(true),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#E6DB74")).
+			Bold(true).
+			Padding(0, 1),
 	}
 
 	// GithubTheme is inspired by GitHub's interface
@@ -111,6 +153,11 @@ var (
 			Background(lipgloss.Color("#0366d6")).
 			Foreground(lipgloss.Color("#ffffff")).
 			Padding(0, 1),
+		SelectedCell: lipgloss.NewStyle().
+			Background(lipgloss.Color("#005cc5")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Bold(true).
+			Padding(0, 1),
 		Border: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#e1e4e8")),
@@ -121,6 +168,14 @@ var (
 			Foreground(lipgloss.Color("#0366d6")).
 			Background(lipgloss.Color("#f1f8ff")).
 			Padding(0, 1),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#d73a49")).
+			Bold(true).
+			Underline(true),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#24292e")).
+			Bold(true).
+			Padding(0, 1),
 	}
 
 	// TerminalTheme is a minimalist black and white theme
@@ -138,6 +193,11 @@ var (
 			Background(lipgloss.Color("#ffffff")).
 			Foreground(lipgloss.Color("#000000")).
 			Padding(0, 1),
+		SelectedCell: lipgloss.NewStyle().
+			Background(lipgloss.Color("#000000")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Bold(true).
+			Padding(0, 1),
 		Border: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#ffffff")),
@@ -148,6 +208,14 @@ var (
 			Foreground(lipgloss.Color("#000000")).
 			Background(lipgloss.Color("#ffffff")).
 			Padding(0, 1),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ffff00")).
+			Bold(true).
+			Underline(true),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ffffff")).
+			Bold(true).
+			Padding(0, 1),
 	}
 
 	// SolarizedDarkTheme is based on the Solarized Dark color scheme
@@ -165,6 +233,11 @@ var (
 			Background(lipgloss.Color("#073642")).
 			Foreground(lipgloss.Color("#93a1a1")).
 			Padding(0, 1),
+		SelectedCell: lipgloss.NewStyle().
+			Background(lipgloss.Color("#2aa198")).
+			Foreground(lipgloss.Color("#002b36")).
+			Bold(true).
+			Padding(0, 1),
 		Border: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#586e75")),
@@ -175,6 +248,14 @@ var (
 			Foreground(lipgloss.Color("#b58900")).
 			Background(lipgloss.Color("#073642")).
 			Padding(0, 1),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#cb4b16")).
+			Bold(true).
+			Underline(true),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#268bd2")).
+			Bold(true).
+			Padding(0, 1),
 	}
 
 	// SolarizedLightTheme is based on the Solarized Light color scheme
@@ -192,6 +273,11 @@ var (
 			Background(lipgloss.Color("#eee8d5")).
 			Foreground(lipgloss.Color("#586e75")).
 			Padding(0, 1),
+		SelectedCell: lipgloss.NewStyle().
+			Background(lipgloss.Color("#2aa198")).
+			Foreground(lipgloss.Color("#fdf6e3")).
+			Bold(true).
+			Padding(0, 1),
 		Border: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#93a1a1")),
@@ -202,6 +288,14 @@ var (
 			Foreground(lipgloss.Color("#b58900")).
 			Background(lipgloss.Color("#eee8d5")).
 			Padding(0, 1),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#cb4b16")).
+			Bold(true).
+			Underline(true),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#268bd2")).
+			Bold(true).
+			Padding(0, 1),
 	}
 )
 
@@ -232,12 +326,16 @@ func GetThemeByName(name string) (Theme, bool) {
 // CustomizeTheme creates a new theme based on an existing theme with customizations
 func CustomizeTheme(base *Theme, name string, customizations map[string]lipgloss.Style) Theme {
 	theme := Theme{
-		Name:        name,
-		Header:      base.Header,
-		Cell:        base.Cell,
-		SelectedRow: base.SelectedRow,
-		Status:      base.Status,
-		Search:      base.Search,
+		Name:         name,
+		Header:       base.Header,
+		Cell:         base.Cell,
+		SelectedRow:  base.SelectedRow,
+		SelectedCell: base.SelectedCell,
+		Border:       base.Border,
+		Status:       base.Status,
+		Search:       base.Search,
+		Match:        base.Match,
+		Footer:       base.Footer,
 	}
 
 	// Apply customizations
@@ -250,10 +348,18 @@ func CustomizeTheme(base *Theme, name string, customizations map[string]lipgloss
 			theme.Cell = style
 		case "SelectedRow":
 			theme.SelectedRow = style
+		case "SelectedCell":
+			theme.SelectedCell = style
+		case "Border":
+			theme.Border = style
 		case "Status":
 			theme.Status = style
 		case "Search":
 			theme.Search = style
+		case "Match":
+			theme.Match = style
+		case "Footer":
+			theme.Footer = style
 		}
 	}
 
@@ -2,6 +2,8 @@ package renderer
 
 import (
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestAllThemesAvailable(t *testing.T) {
@@ -162,6 +164,32 @@ func TestCustomizeTheme(t *testing.T) {
 	}
 }
 
+func TestCustomizeThemeBorder(t *testing.T) {
+	theme := CustomizeTheme(&DraculaTheme, "Custom", nil)
+	if theme.Border.GetBorderTopForeground() != DraculaTheme.Border.GetBorderTopForeground() {
+		t.Error("Expected the base theme's border to be kept")
+	}
+
+	border := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	theme = CustomizeTheme(&DraculaTheme, "Custom", map[string]lipgloss.Style{"Border": border})
+	if theme.Border.GetForeground() != lipgloss.Color("#FF0000") {
+		t.Error("Expected the border customization to be applied")
+	}
+}
+
+func TestCustomizeThemeFooter(t *testing.T) {
+	theme := CustomizeTheme(&DraculaTheme, "Custom", nil)
+	if theme.Footer.GetForeground() != DraculaTheme.Footer.GetForeground() {
+		t.Error("Expected the base theme's footer to be kept")
+	}
+
+	footer := lipgloss.NewStyle().Italic(true)
+	theme = CustomizeTheme(&DraculaTheme, "Custom", map[string]lipgloss.Style{"Footer": footer})
+	if !theme.Footer.GetItalic() {
+		t.Error("Expected the footer customization to be applied")
+	}
+}
+
 func TestThemeStructure(t *testing.T) {
 	themes := []Theme{
 		DefaultTheme,
@@ -264,6 +292,10 @@ func TestThemeConsistency(t *testing.T) {
 			if len(searchRendered) == 0 {
 				t.Error("Search should render content")
 			}
+
+			if theme.Footer.GetHorizontalPadding() != theme.Cell.GetHorizontalPadding() {
+				t.Error("Footer should be padded like cells so aggregates line up")
+			}
 		})
 	}
 }
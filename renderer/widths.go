@@ -0,0 +1,258 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// contentSampleRows is how many rows content-aware strategies measure
+const contentSampleRows = 100
+
+// WidthStrategy computes a render width for each column. contentWidth is the
+// space available for cells, excluding column separators, and rows is a
+// sample of the table's rows for content-aware strategies. Widths include
+// the cell style's horizontal padding, given as cellPadding. The renderer
+// applies each column's MinWidth and MaxWidth to the result.
+type WidthStrategy func(columns []table.Column, rows []table.Row, contentWidth, cellPadding int) []int
+
+// EvenWidths splits the available space evenly, with a minimum of 5 per column.
+// It is the default strategy.
+func EvenWidths(columns []table.Column, _ []table.Row, contentWidth, _ int) []int {
+	widths := make([]int, len(columns))
+	if len(columns) == 0 {
+		return widths
+	}
+
+	// Ensure minimum content width
+	if contentWidth < len(columns)*5 {
+		contentWidth = len(columns) * 5
+	}
+
+	// Distribute width, giving extra to first few columns
+	baseWidth := contentWidth / len(columns)
+	remainder := contentWidth % len(columns)
+	for i := range widths {
+		widths[i] = baseWidth
+		if i < remainder {
+			widths[i]++
+		}
+	}
+	return widths
+}
+
+// FixedWidths uses each column's declared Width, even if the table overflows
+func FixedWidths(columns []table.Column, _ []table.Row, _, _ int) []int {
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = col.Width
+	}
+	return widths
+}
+
+// ProportionalWidths splits the available space by each column's Weight
+func ProportionalWidths(columns []table.Column, _ []table.Row, contentWidth, _ int) []int {
+	weights := make([]float64, len(columns))
+	for i, col := range columns {
+		weights[i] = columnWeight(col)
+	}
+	return distributeByWeight(make([]int, len(columns)), weights, contentWidth)
+}
+
+// ContentWidths sizes each column to its widest header or sampled cell. If
+// that does not fit, the widest columns are shrunk first.
+func ContentWidths(columns []table.Column, rows []table.Row, contentWidth, cellPadding int) []int {
+	widths := measureContentWidths(columns, rows)
+	for i := range widths {
+		widths[i] += cellPadding
+	}
+
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+	if total <= contentWidth {
+		return widths
+	}
+
+	// Lower a common ceiling until everything fits, so narrow columns keep
+	// their natural width and only the widest ones are truncated
+	ceiling := 0
+	for _, width := range widths {
+		ceiling = max(ceiling, width)
+	}
+	for ceiling > 1 && total > contentWidth {
+		ceiling--
+		total = 0
+		for _, width := range widths {
+			total += min(width, ceiling)
+		}
+	}
+	for i := range widths {
+		widths[i] = min(widths[i], ceiling)
+	}
+	return widths
+}
+
+// FillWidths starts from each column's declared Width and gives leftover
+// space to Flexible columns by Weight (or to every column if none are
+// flexible). When space is short, flexible columns shrink first.
+func FillWidths(columns []table.Column, _ []table.Row, contentWidth, _ int) []int {
+	widths := FixedWidths(columns, nil, contentWidth, 0)
+
+	weights := make([]float64, len(columns))
+	anyFlexible := false
+	for i, col := range columns {
+		if col.Flexible {
+			weights[i] = columnWeight(col)
+			anyFlexible = true
+		}
+	}
+	if !anyFlexible {
+		for i, col := range columns {
+			weights[i] = columnWeight(col)
+		}
+	}
+
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+
+	leftover := contentWidth - total
+	if leftover >= 0 {
+		extra := distributeByWeight(make([]int, len(columns)), weights, leftover)
+		for i := range widths {
+			widths[i] += extra[i]
+		}
+		return widths
+	}
+
+	// Shrink flexible columns toward their minimum before touching the rest
+	for i := range widths {
+		if leftover >= 0 {
+			break
+		}
+		if weights[i] == 0 {
+			continue
+		}
+		shrink := min(-leftover, widths[i]-max(columns[i].MinWidth, 1))
+		if shrink > 0 {
+			widths[i] -= shrink
+			leftover += shrink
+		}
+	}
+	return widths
+}
+
+// columnWeight returns a column's weight, treating unset weights as 1
+func columnWeight(col table.Column) float64 {
+	if col.Weight <= 0 {
+		return 1
+	}
+	return col.Weight
+}
+
+// distributeByWeight adds space to widths in proportion to weights, using
+// largest remainders so the result sums exactly to space
+func distributeByWeight(widths []int, weights []float64, space int) []int {
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	if totalWeight == 0 || space <= 0 {
+		return widths
+	}
+
+	remainders := make([]float64, len(widths))
+	assigned := 0
+	for i, weight := range weights {
+		share := float64(space) * weight / totalWeight
+		widths[i] += int(math.Floor(share))
+		remainders[i] = share - math.Floor(share)
+		assigned += int(math.Floor(share))
+	}
+
+	for assigned < space {
+		best := -1
+		for i, remainder := range remainders {
+			if weights[i] > 0 && (best < 0 || remainder > remainders[best]) {
+				best = i
+			}
+		}
+		widths[best]++
+		remainders[best] = -1
+		assigned++
+	}
+	return widths
+}
+
+// measureContentWidths returns the display width of each column's header and
+// widest formatted cell in rows
+func measureContentWidths(columns []table.Column, rows []table.Row) []int {
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = lipgloss.Width(col.Header)
+		for _, row := range rows {
+			if i >= len(row.Cells) {
+				continue
+			}
+			formatter := col.Formatter
+			if formatter == nil {
+				formatter = table.DefaultFormatter
+			}
+			widths[i] = max(widths[i], lipgloss.Width(formatter(row.Cells[i].Value)))
+		}
+	}
+	return widths
+}
+
+// SetWidthStrategy sets how column widths are computed when the table is
+// fitted to the terminal. A nil strategy restores EvenWidths.
+func (r *TableRenderer) SetWidthStrategy(strategy WidthStrategy) {
+	r.widthStrategy = strategy
+}
+
+// applyWidthStrategy runs the width strategy and applies column constraints
+func (r *TableRenderer) applyWidthStrategy(columns []table.Column, rows []table.Row, availableWidth int) []table.Column {
+	adjusted := make([]table.Column, len(columns))
+	copy(adjusted, columns)
+	if len(columns) == 0 {
+		return adjusted
+	}
+
+	strategy := r.widthStrategy
+	if strategy == nil {
+		strategy = EvenWidths
+	}
+
+	// Account for separators between columns
+	contentWidth := availableWidth - (len(columns) - 1)
+	if len(rows) > contentSampleRows {
+		rows = rows[:contentSampleRows]
+	}
+
+	widths := strategy(columns, rows, contentWidth, r.theme.Cell.GetHorizontalPadding())
+	for i := range adjusted {
+		if i < len(widths) {
+			adjusted[i].Width = widths[i]
+		}
+		adjusted[i].Width = clampColumnWidth(adjusted[i], adjusted[i].Width)
+	}
+	return adjusted
+}
+
+// clampColumnWidth applies a column's MinWidth and MaxWidth to width
+func clampColumnWidth(col table.Column, width int) int {
+	if col.MaxWidth > 0 && width > col.MaxWidth {
+		width = col.MaxWidth
+	}
+	if col.MinWidth > 0 && width < col.MinWidth {
+		width = col.MinWidth
+	}
+	if width < 1 {
+		width = 1
+	}
+	return width
+}
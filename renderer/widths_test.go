@@ -0,0 +1,151 @@
+package renderer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func sumWidths(widths []int) int {
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+	return total
+}
+
+func TestEvenWidths(t *testing.T) {
+	columns := []table.Column{{Width: 10}, {Width: 20}, {Width: 30}}
+
+	widths := EvenWidths(columns, nil, 32, 2)
+	if !reflect.DeepEqual(widths, []int{11, 11, 10}) {
+		t.Errorf("Expected [11 11 10], got %v", widths)
+	}
+
+	// Never below 5 per column
+	widths = EvenWidths(columns, nil, 6, 2)
+	if !reflect.DeepEqual(widths, []int{5, 5, 5}) {
+		t.Errorf("Expected [5 5 5], got %v", widths)
+	}
+}
+
+func TestFixedWidths(t *testing.T) {
+	columns := []table.Column{{Width: 10}, {Width: 20}, {Width: 30}}
+
+	widths := FixedWidths(columns, nil, 20, 2)
+	if !reflect.DeepEqual(widths, []int{10, 20, 30}) {
+		t.Errorf("Expected declared widths, got %v", widths)
+	}
+}
+
+func TestProportionalWidths(t *testing.T) {
+	columns := []table.Column{{Weight: 1}, {Weight: 2}, {}}
+
+	widths := ProportionalWidths(columns, nil, 40, 2)
+	if !reflect.DeepEqual(widths, []int{10, 20, 10}) {
+		t.Errorf("Expected [10 20 10], got %v", widths)
+	}
+
+	// Rounding never loses or gains space
+	widths = ProportionalWidths(columns, nil, 41, 2)
+	if sumWidths(widths) != 41 {
+		t.Errorf("Expected widths to sum to 41, got %v", widths)
+	}
+}
+
+func TestContentWidths(t *testing.T) {
+	columns := []table.Column{
+		*table.NewColumn("id", "ID"),
+		*table.NewColumn("name", "Name"),
+		*table.NewColumn("city", "City"),
+	}
+	rows := []table.Row{
+		{Cells: []table.Cell{{Value: 1}, {Value: "Alexandra Hamilton"}, {Value: "東京"}}},
+		{Cells: []table.Cell{{Value: 22}, {Value: "Bo"}, {Value: "Oslo"}}},
+	}
+
+	// Display width, not bytes: "東京" is 2 runes, 6 bytes and 4 cells wide
+	widths := ContentWidths(columns, rows, 80, 2)
+	if !reflect.DeepEqual(widths, []int{4, 20, 6}) {
+		t.Errorf("Expected [4 20 6], got %v", widths)
+	}
+
+	// When short of space, the widest column shrinks first
+	widths = ContentWidths(columns, rows, 20, 2)
+	if !reflect.DeepEqual(widths, []int{4, 10, 6}) {
+		t.Errorf("Expected [4 10 6], got %v", widths)
+	}
+}
+
+func TestFillWidths(t *testing.T) {
+	columns := []table.Column{
+		{Width: 5},
+		{Width: 10, Flexible: true},
+		{Width: 10, Flexible: true, Weight: 3},
+	}
+
+	widths := FillWidths(columns, nil, 33, 2)
+	if !reflect.DeepEqual(widths, []int{5, 12, 16}) {
+		t.Errorf("Expected leftover space to go to flexible columns, got %v", widths)
+	}
+
+	// Flexible columns shrink first when space is short
+	widths = FillWidths(columns, nil, 20, 2)
+	if widths[0] != 5 || sumWidths(widths) != 20 {
+		t.Errorf("Expected fixed column to keep its width and total 20, got %v", widths)
+	}
+
+	// Without flexible columns every column grows
+	widths = FillWidths([]table.Column{{Width: 5}, {Width: 5}}, nil, 20, 2)
+	if !reflect.DeepEqual(widths, []int{10, 10}) {
+		t.Errorf("Expected [10 10], got %v", widths)
+	}
+}
+
+func TestWidthStrategyConstraints(t *testing.T) {
+	columns := []table.Column{
+		*table.NewColumn("a", "A").WithMaxWidth(8),
+		*table.NewColumn("b", "B").WithMinWidth(30),
+		*table.NewColumn("c", "C"),
+	}
+
+	renderer := NewTableRenderer(62, 24)
+	renderer.SetWidthStrategy(ProportionalWidths)
+	adjusted := renderer.applyWidthStrategy(columns, nil, 62)
+
+	if adjusted[0].Width != 8 {
+		t.Errorf("Expected MaxWidth to cap column A at 8, got %d", adjusted[0].Width)
+	}
+	if adjusted[1].Width != 30 {
+		t.Errorf("Expected MinWidth to raise column B to 30, got %d", adjusted[1].Width)
+	}
+	if adjusted[2].Width != 20 {
+		t.Errorf("Expected column C to get its proportional share of 20, got %d", adjusted[2].Width)
+	}
+
+	// Columns passed in are not modified
+	if columns[0].Width != 15 {
+		t.Errorf("Expected source columns to be untouched, got width %d", columns[0].Width)
+	}
+}
+
+func TestRenderTableUsesWidthStrategy(t *testing.T) {
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("a", "A").WithWidth(6),
+		*table.NewColumn("b", "B").WithWidth(9),
+	})
+	tbl.SetData([]map[string]interface{}{{"a": "x", "b": "y"}})
+
+	renderer := NewTableRenderer(80, 24)
+	renderer.SetWidthStrategy(FixedWidths)
+	output := renderer.RenderTable(tbl, 0, -1)
+
+	// Header row: 6 + separator + 9
+	header := strings.Split(output, "\n")[0]
+	if lipgloss.Width(header) != 16 {
+		t.Errorf("Expected fixed widths to give a 16-cell header, got %d: %q", lipgloss.Width(header), header)
+	}
+}
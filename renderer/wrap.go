@@ -0,0 +1,201 @@
+package renderer
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/anurag-roy/bubbletable/table"
+)
+
+// cellContent returns the text a cell displays: the column's custom renderer
+// output if it has one, or else the formatted value (or NullText for nil)
+func cellContent(col table.Column, row table.Row, colIndex int, selected bool) string {
+	if colIndex >= len(row.Cells) {
+		return ""
+	}
+	value := row.Cells[colIndex].Value
+	if col.Renderer != nil {
+		return col.Renderer(value, selected)
+	}
+	return col.Format(value)
+}
+
+// cellLines fits cell text to width, word-wrapping it in Wrap columns and
+// truncating it to a single line otherwise
+func (r *TableRenderer) cellLines(col table.Column, text string, width int) []string {
+	if col.Wrap {
+		return table.WrapText(text, width, col.MaxLines, r.ellipsis)
+	}
+	return []string{r.truncateText(text, width)}
+}
+
+// hasWrappedColumns reports whether any column wraps its text
+func hasWrappedColumns(columns []table.Column) bool {
+	for _, col := range columns {
+		if col.Wrap {
+			return true
+		}
+	}
+	return false
+}
+
+// hasVariableRowLines reports whether rows can take more than one line,
+// either because columns wrap or because rules separate the rows
+func (r *TableRenderer) hasVariableRowLines(columns []table.Column) bool {
+	return r.border.RowRules || hasWrappedColumns(columns)
+}
+
+// rowHeights caches the heights RowHeights measured, by Row.ID, for one set
+// of wrapped column widths
+type rowHeights struct {
+	widths  string // Column index, text width and MaxLines of each wrapped column
+	heights map[int]rowHeight
+}
+
+// rowHeight is a measured row's height, with the values of its wrapped cells
+// to notice when they change
+type rowHeight struct {
+	values []interface{}
+	height int
+}
+
+// pagination is what PaginateByLines last split a table into pages for
+type pagination struct {
+	table    *table.Table
+	widths   string
+	maxLines int
+	rules    bool
+}
+
+// wrappedColumns returns the table's column layout, the layout positions of
+// the columns that wrap, and a key telling apart their widths
+func (r *TableRenderer) wrappedColumns(tbl *table.Table) (columnLayout, []int, string) {
+	if !hasWrappedColumns(tbl.Columns) {
+		return columnLayout{}, nil, ""
+	}
+
+	layout := r.layoutColumns(tbl.Columns, tbl.RowRange(0, contentSampleRows), r.availableWidth())
+	var wrapped []int
+	var widths strings.Builder
+	for i, col := range layout.columns {
+		if col.Wrap {
+			wrapped = append(wrapped, i)
+			fmt.Fprintf(&widths, "%d:%d:%d ", layout.indexes[i], cellTextWidth(r.theme.Cell, col.Width), col.MaxLines)
+		}
+	}
+	return layout, wrapped, widths.String()
+}
+
+// RowHeights returns how many terminal lines each row of the table takes up
+// when rendered. Rows are a single line unless a column wraps its text.
+//
+// Heights are cached by Row.ID until the column widths change, so only rows
+// that are new or whose wrapped cells changed are wrapped again.
+func (r *TableRenderer) RowHeights(tbl *table.Table) []int {
+	layout, wrapped, widths := r.wrappedColumns(tbl)
+	return r.rowHeights(tbl.CurrentRows(), layout, wrapped, widths)
+}
+
+// rowHeights measures rows, reusing cached heights
+func (r *TableRenderer) rowHeights(rows []table.Row, layout columnLayout, wrapped []int, widths string) []int {
+	heights := make([]int, len(rows))
+	for i := range heights {
+		heights[i] = 1
+	}
+	if len(wrapped) == 0 {
+		return heights
+	}
+
+	// Start over for new widths, or once rows of other tables pile up
+	if r.heights.widths != widths || len(r.heights.heights) > 2*len(rows)+contentSampleRows {
+		r.heights = rowHeights{widths: widths, heights: make(map[int]rowHeight, len(rows))}
+	}
+
+	values := make([]interface{}, len(wrapped))
+	for rowIndex, row := range rows {
+		if row.Group != nil {
+			continue // Group headers take one line
+		}
+		for i, pos := range wrapped {
+			values[i] = nil
+			if colIndex := layout.indexes[pos]; colIndex < len(row.Cells) {
+				values[i] = row.Cells[colIndex].Value
+			}
+		}
+		if cached, ok := r.heights.heights[row.ID]; ok && sameValues(cached.values, values) {
+			heights[rowIndex] = cached.height
+			continue
+		}
+
+		for _, pos := range wrapped {
+			col := layout.columns[pos]
+			width := cellTextWidth(r.theme.Cell, col.Width)
+			lines := r.cellLines(col, cellContent(col, row, layout.indexes[pos], false), width)
+			heights[rowIndex] = max(heights[rowIndex], len(lines))
+		}
+		r.heights.heights[row.ID] = rowHeight{values: slices.Clone(values), height: heights[rowIndex]}
+	}
+	return heights
+}
+
+// sameValues reports whether two rows' wrapped cells hold equal values.
+// Values that cannot be compared count as changed.
+func sameValues(a, b []interface{}) bool {
+	for i := range a {
+		if a[i] != nil && !reflect.TypeOf(a[i]).Comparable() {
+			return false
+		}
+		if b[i] != nil && !reflect.TypeOf(b[i]).Comparable() {
+			return false
+		}
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+// PaginateByLines splits the table into pages of at most maxLines rendered
+// lines by setting its PageBreaks, so that pages with tall, wrapped rows or
+// rules between rows never overflow the terminal. A row taller than maxLines
+// gets a page of its own. Other tables keep paging by PageSize.
+//
+// Tables backed by a DataSource always page by PageSize, since their rows are
+// not all loaded.
+//
+// Call it again whenever the table's rows, columns or the terminal size
+// change. Calls that would set the same page breaks as the last call return
+// at once: the table drops its PageBreaks when its rows change or are sorted.
+func (r *TableRenderer) PaginateByLines(tbl *table.Table, maxLines int) {
+	if tbl == nil {
+		return
+	}
+	if !r.hasVariableRowLines(tbl.Columns) || maxLines <= 0 || tbl.Source() != nil {
+		tbl.PageBreaks = nil
+		return
+	}
+
+	layout, wrapped, widths := r.wrappedColumns(tbl)
+	paged := pagination{table: tbl, widths: widths, maxLines: maxLines, rules: r.border.RowRules}
+	if tbl.PageBreaks != nil && r.paged == paged {
+		return
+	}
+
+	breaks := []int{0}
+	used := 0
+	for i, height := range r.rowHeights(tbl.CurrentRows(), layout, wrapped, widths) {
+		needed := height
+		if used > 0 && r.border.RowRules {
+			needed++ // Rule above the row
+		}
+		if used > 0 && used+needed > maxLines {
+			breaks = append(breaks, i)
+			used, needed = 0, height
+		}
+		used += needed
+	}
+	tbl.PageBreaks = breaks
+	r.paged = paged
+}
@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anurag-roy/bubbletable/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// notesTable returns a table whose Body column wraps over at most maxLines
+func notesTable(maxLines int) *table.Table {
+	tbl := table.NewWithColumns([]table.Column{
+		*table.NewColumn("id", "ID").WithType(table.Integer).WithWidth(4),
+		*table.NewColumn("body", "Body").WithWidth(12).WithWrap(maxLines),
+	})
+	tbl.SetData([]map[string]interface{}{
+		{"id": 1, "body": "short"},
+		{"id": 2, "body": "a somewhat longer note that wraps"},
+		{"id": 3, "body": "two line note"},
+	})
+	return tbl
+}
+
+func TestRenderWrappedCells(t *testing.T) {
+	renderer := NewTableRenderer(80, 24)
+	renderer.SetWidthStrategy(FixedWidths)
+
+	lines := strings.Split(renderer.RenderTable(notesTable(0), 0, -1), "\n")
+
+	// Header and separator, then rows of 1, 4 and 2 lines
+	if len(lines) != 2+1+4+2 {
+		t.Fatalf("Expected 9 lines, got %d: %q", len(lines), lines)
+	}
+	for i, line := range lines {
+		if width := lipgloss.Width(line); width != 17 {
+			t.Errorf("Line %d %q is %d cells wide, expected 17", i, line, width)
+		}
+		if i != 1 && strings.Count(line, "│") != 1 {
+			t.Errorf("Line %d %q should continue the column separator", i, line)
+		}
+	}
+	if got := lines[4]; got != "    │ longer     " {
+		t.Errorf("Expected continuation line with a blank ID cell, got %q", got)
+	}
+}
+
+func TestRenderWrapMaxLines(t *testing.T) {
+	renderer := NewTableRenderer(80, 24)
+	renderer.SetWidthStrategy(FixedWidths)
+
+	lines := strings.Split(renderer.RenderTable(notesTable(2), 0, -1), "\n")
+	if len(lines) != 2+1+2+2 {
+		t.Fatalf("Expected 7 lines, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[4], "...") {
+		t.Errorf("Expected the last allowed line to end with an ellipsis, got %q", lines[4])
+	}
+}
+
+func TestPaginateByLines(t *testing.T) {
+	renderer := NewTableRenderer(80, 24)
+	renderer.SetWidthStrategy(FixedWidths)
+	tbl := notesTable(0)
+
+	heights := renderer.RowHeights(tbl)
+	if len(heights) != 3 || heights[0] != 1 || heights[1] != 4 || heights[2] != 2 {
+		t.Fatalf("Expected row heights [1 4 2], got %v", heights)
+	}
+
+	renderer.PaginateByLines(tbl, 5)
+	if tbl.GetTotalPages() != 2 {
+		t.Fatalf("Expected 2 pages of at most 5 lines, got %d (breaks %v)", tbl.GetTotalPages(), tbl.PageBreaks)
+	}
+	for page := 0; page < tbl.GetTotalPages(); page++ {
+		lines := 0
+		for _, row := range tbl.GetPage(page) {
+			lines += heights[row.ID]
+		}
+		if lines > 5 {
+			t.Errorf("Page %d has %d lines, expected at most 5", page, lines)
+		}
+	}
+
+	// A row taller than the budget gets a page of its own
+	renderer.PaginateByLines(tbl, 3)
+	if got := len(tbl.GetPage(1)); got != 1 {
+		t.Errorf("Expected the tall row alone on page 2, got %d rows", got)
+	}
+
+	// Without wrapped columns, paging falls back to PageSize
+	tbl.Columns[1].Wrap = false
+	renderer.PaginateByLines(tbl, 3)
+	if tbl.PageBreaks != nil {
+		t.Errorf("Expected no page breaks without wrapped columns, got %v", tbl.PageBreaks)
+	}
+}
+
+func TestRowHeightsCached(t *testing.T) {
+	renderer := NewTableRenderer(80, 24)
+	renderer.SetWidthStrategy(FixedWidths)
+	tbl := notesTable(0)
+	wraps := 0
+	tbl.Columns[1].Renderer = func(value interface{}, selected bool) string {
+		wraps++
+		return value.(string)
+	}
+
+	renderer.RowHeights(tbl)
+	renderer.RowHeights(tbl)
+	if wraps != 3 {
+		t.Errorf("Expected each row to be wrapped once, got %d wraps", wraps)
+	}
+
+	// Only the changed row is wrapped again
+	if err := tbl.UpdateRow(2, 3, "now a rather longer note than before"); err != nil {
+		t.Fatalf("UpdateRow failed: %v", err)
+	}
+	if heights := renderer.RowHeights(tbl); wraps != 4 || heights[2] <= 2 {
+		t.Errorf("Expected the updated row wrapped again over more lines, got %d wraps and heights %v", wraps, heights)
+	}
+
+	// Paginating again with nothing changed keeps the page breaks
+	renderer.PaginateByLines(tbl, 5)
+	breaks := tbl.PageBreaks
+	renderer.PaginateByLines(tbl, 5)
+	if &tbl.PageBreaks[0] != &breaks[0] {
+		t.Error("Expected the page breaks to be kept")
+	}
+	tbl.SortByColumn(0, true)
+	renderer.PaginateByLines(tbl, 5)
+	if &tbl.PageBreaks[0] == &breaks[0] || wraps != 4 {
+		t.Errorf("Expected a sort to split pages again from cached heights, got %d wraps", wraps)
+	}
+
+	// New widths measure every row again
+	renderer.SetWidthStrategy(FillWidths)
+	renderer.RowHeights(tbl)
+	if wraps != 7 {
+		t.Errorf("Expected new widths to wrap every row, got %d wraps", wraps)
+	}
+}
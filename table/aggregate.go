@@ -0,0 +1,261 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// AggFunc summarizes a column's cells into a single value, such as a total
+type AggFunc func(cells []Cell) interface{}
+
+// Count is the result of a counting aggregate. Footers show counts as plain
+// numbers rather than with the column's Formatter, so a count of prices is
+// not shown as an amount.
+type Count int
+
+// FooterScope selects which rows a table's footer aggregates
+type FooterScope int
+
+const (
+	NoFooter   FooterScope = iota // No footer row
+	FooterView                    // Rows in the current view, narrowed by search and filters
+	FooterAll                     // Every row of the table, ignoring search and filters
+)
+
+// Built-in aggregates. Sum, average, minimum and maximum skip null cells and
+// values they cannot use; AggSum and AggAvg return nil if no value is numeric.
+var (
+	AggSum           AggFunc = aggSum
+	AggAvg           AggFunc = aggAvg
+	AggMin           AggFunc = func(cells []Cell) interface{} { return aggExtreme(cells, -1) }
+	AggMax           AggFunc = func(cells []Cell) interface{} { return aggExtreme(cells, 1) }
+	AggCount         AggFunc = aggCount
+	AggCountDistinct AggFunc = aggCountDistinct
+)
+
+// ParseAggFunc returns the built-in aggregate with a name: sum, avg, min, max,
+// count or distinct. "none" returns a nil aggregate.
+func ParseAggFunc(name string) (AggFunc, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "sum":
+		return AggSum, true
+	case "avg", "average", "mean":
+		return AggAvg, true
+	case "min":
+		return AggMin, true
+	case "max":
+		return AggMax, true
+	case "count":
+		return AggCount, true
+	case "distinct", "count-distinct", "countdistinct":
+		return AggCountDistinct, true
+	case "none":
+		return nil, true
+	default:
+		return nil, false
+	}
+}
+
+// DefaultAggFunc returns the aggregate a column of a DataType uses when it
+// sets none: a sum for Integer and Float columns, and no aggregate otherwise
+func DefaultAggFunc(dataType DataType) AggFunc {
+	switch dataType {
+	case Integer, Float:
+		return AggSum
+	default:
+		return nil
+	}
+}
+
+// aggSum adds up numeric values, returning an int if every value is an
+// integer and a float64 otherwise
+func aggSum(cells []Cell) interface{} {
+	var intSum int64
+	var floatSum float64
+	seen, integers := false, true
+
+	for _, cell := range cells {
+		if n, ok := integerValue(cell.Value); ok && integers {
+			intSum += n
+			floatSum += float64(n)
+			seen = true
+			continue
+		}
+		if f, ok := numericValue(cell.Value); ok {
+			floatSum += f
+			seen, integers = true, false
+		}
+	}
+
+	switch {
+	case !seen:
+		return nil
+	case integers:
+		return int(intSum)
+	default:
+		return floatSum
+	}
+}
+
+// integerValue converts a value of a Go integer kind to int64
+func integerValue(value interface{}) (int64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// aggAvg returns the mean of numeric values
+func aggAvg(cells []Cell) interface{} {
+	sum, n := 0.0, 0
+	for _, cell := range cells {
+		if f, ok := numericValue(cell.Value); ok {
+			sum += f
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return sum / float64(n)
+}
+
+// aggExtreme returns the smallest (sign -1) or largest (sign 1) value, in
+// the order sorting uses
+func aggExtreme(cells []Cell, sign int) interface{} {
+	var best *Cell
+	for i := range cells {
+		if cells[i].IsNull() {
+			continue
+		}
+		if best == nil || compareCells(cells[i], *best)*sign > 0 {
+			best = &cells[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.Value
+}
+
+// aggCount counts non-null cells
+func aggCount(cells []Cell) interface{} {
+	n := 0
+	for _, cell := range cells {
+		if !cell.IsNull() {
+			n++
+		}
+	}
+	return Count(n)
+}
+
+// aggCountDistinct counts distinct non-null values
+func aggCountDistinct(cells []Cell) interface{} {
+	seen := make(map[string]bool)
+	for _, cell := range cells {
+		if !cell.IsNull() {
+			seen[fmt.Sprintf("%v", cell.Value)] = true
+		}
+	}
+	return Count(len(seen))
+}
+
+// WithAggregate sets the aggregate shown in the column's footer cell in
+// place of DefaultAggFunc; nil leaves the cell empty
+func (c *Column) WithAggregate(agg AggFunc) *Column {
+	c.Aggregate = agg
+	c.NoAggregate = agg == nil
+	return c
+}
+
+// aggFunc returns the aggregate a column's footer uses, or nil for none
+func (c Column) aggFunc() AggFunc {
+	if c.NoAggregate {
+		return nil
+	}
+	if c.Aggregate != nil {
+		return c.Aggregate
+	}
+	return DefaultAggFunc(c.Type)
+}
+
+// FormatAggregate formats an aggregate result with the column's Formatter,
+// as a plain number for counts, or as empty text for nil
+func (c Column) FormatAggregate(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case Count:
+		return strconv.Itoa(int(v))
+	default:
+		return c.Format(value)
+	}
+}
+
+// WithFooter sets which rows the table's footer aggregates (builder pattern)
+func (t *Table) WithFooter(scope FooterScope) *Table {
+	t.Footer = scope
+	return t
+}
+
+// footerRows returns the rows the footer aggregates
+func (t *Table) footerRows() []Row {
+	switch {
+	case t.Footer == FooterAll && t.parent != nil:
+		return t.parent.UnsortedOrder
+	case t.ungrouped != nil:
+		// A group view's own rows include group headers and leave out
+		// collapsed rows
+		return t.ungrouped.CurrentRows()
+	default:
+		return t.CurrentRows()
+	}
+}
+
+// FooterValues returns each column's aggregate over the rows the footer
+// covers, with nil for columns without one. It returns nil if the table has
+// no footer or is backed by a DataSource, whose rows are not all loaded.
+//
+// The values are computed once and kept until the table's rows change, so
+// drawing a footer does not aggregate every row each time. The returned
+// slice is shared and must not be modified.
+func (t *Table) FooterValues() []interface{} {
+	if t.Footer == NoFooter || t.source != nil {
+		return nil
+	}
+	if t.isView() {
+		t.sync() // Drops the values if the view filters again
+	}
+	if t.footer == nil || t.footerScope != t.Footer {
+		t.footer = t.aggregate(t.footerRows())
+		t.footerScope = t.Footer
+	}
+	return t.footer
+}
+
+// FooterText returns each column's formatted footer text, empty for columns
+// without an aggregate, or nil if the table shows no footer
+func (t *Table) FooterText() []string {
+	values := t.FooterValues()
+	if values == nil {
+		return nil
+	}
+
+	text := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		if col.aggFunc() != nil {
+			text[i] = col.FormatAggregate(values[i])
+		}
+	}
+	return text
+}
@@ -0,0 +1,158 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuiltinAggregates(t *testing.T) {
+	ints := []Cell{{Value: 3, Type: Integer}, {Value: nil, Type: Integer}, {Value: 7, Type: Integer}, {Value: 3, Type: Integer}}
+	floats := []Cell{{Value: 1.5, Type: Float}, {Value: 2, Type: Float}}
+	dates := []Cell{{Value: "2024-03-01", Type: Date}, {Value: "2023-12-31", Type: Date}, {Value: nil, Type: Date}}
+
+	tests := []struct {
+		name  string
+		agg   AggFunc
+		cells []Cell
+		want  interface{}
+	}{
+		{"sum of ints stays int", AggSum, ints, 13},
+		{"sum of mixed is float", AggSum, floats, 3.5},
+		{"sum of nothing", AggSum, []Cell{{Value: nil}}, nil},
+		{"avg skips nulls", AggAvg, ints, 13.0 / 3},
+		{"min", AggMin, ints, 3},
+		{"max", AggMax, ints, 7},
+		{"min date", AggMin, dates, "2023-12-31"},
+		{"max date", AggMax, dates, "2024-03-01"},
+		{"min of nulls", AggMin, []Cell{{Value: nil}}, nil},
+		{"count skips nulls", AggCount, ints, Count(3)},
+		{"count distinct", AggCountDistinct, ints, Count(2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.agg(tt.cells); got != tt.want {
+				t.Errorf("Expected %v (%T), got %v (%T)", tt.want, tt.want, got, got)
+			}
+		})
+	}
+}
+
+func TestParseAggFunc(t *testing.T) {
+	for _, name := range []string{"sum", "avg", "min", "max", "count", "distinct", "Count-Distinct"} {
+		if agg, ok := ParseAggFunc(name); !ok || agg == nil {
+			t.Errorf("Expected an aggregate for %q", name)
+		}
+	}
+	if agg, ok := ParseAggFunc("none"); !ok || agg != nil {
+		t.Error("Expected none to give a nil aggregate")
+	}
+	if _, ok := ParseAggFunc("median"); ok {
+		t.Error("Expected median to be unknown")
+	}
+}
+
+// footerTestTable returns a table of orders with a footer
+func footerTestTable() *Table {
+	table := NewWithColumns([]Column{
+		*NewColumn("id", "ID").WithType(Integer).WithAggregate(nil),
+		*NewColumn("item", "Item").WithAggregate(AggCountDistinct),
+		*NewColumn("price", "Price").WithType(Float).WithFormatter(CurrencyFormatter),
+		*NewColumn("qty", "Qty").WithType(Integer).WithAggregate(AggAvg),
+		*NewColumn("note", "Note"),
+	}).WithFooter(FooterView)
+	table.AddRow(1, "apple", 1.5, 4, nil)
+	table.AddRow(2, "pear", 2.25, 2, nil)
+	table.AddRow(3, "apple", 1.5, 6, "bruised")
+	return table
+}
+
+func TestFooterText(t *testing.T) {
+	table := footerTestTable()
+
+	want := []string{"", "2", "$5.25", "4.00", ""}
+	if got := table.FooterText(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected footer %q, got %q", want, got)
+	}
+
+	table.Footer = NoFooter
+	if got := table.FooterText(); got != nil {
+		t.Errorf("Expected no footer, got %q", got)
+	}
+}
+
+func TestFooterScope(t *testing.T) {
+	table := footerTestTable()
+
+	view := table.Filter("apple")
+	if want := []string{"", "1", "$3.00", "5.00", ""}; !reflect.DeepEqual(view.FooterText(), want) {
+		t.Errorf("Expected view footer %q, got %q", want, view.FooterText())
+	}
+
+	table.Footer = FooterAll
+	all := table.Filter("apple").Filter("bruised")
+	if len(all.CurrentRows()) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(all.CurrentRows()))
+	}
+	if want := []string{"", "2", "$5.25", "4.00", ""}; !reflect.DeepEqual(all.FooterText(), want) {
+		t.Errorf("Expected whole-table footer %q, got %q", want, all.FooterText())
+	}
+}
+
+func TestFooterValuesCached(t *testing.T) {
+	table := footerTestTable()
+	view := table.Filter("apple")
+	table.Subscribe(view.ApplyMutation)
+	pears := table.Filter("pear")
+
+	first := view.FooterValues()
+	if &view.FooterValues()[0] != &first[0] {
+		t.Error("Expected the footer values to be computed once")
+	}
+
+	// Mutations drop the values of the views that follow them
+	table.AddRow(4, "apple", 3.0, 2, nil)
+	if want := []string{"", "1", "$6.00", "4.00", ""}; !reflect.DeepEqual(view.FooterText(), want) {
+		t.Errorf("Expected the footer to include the new row, got %q", view.FooterText())
+	}
+
+	// and of views that filter again
+	pears.FooterValues()
+	table.UpdateRow(1, 2, "pear", 2.25, 8, nil)
+	if got := pears.FooterText()[3]; got != "8.00" {
+		t.Errorf("Expected a stale view's footer to follow the update, got %q", got)
+	}
+
+	view.Footer = FooterAll
+	if got := view.FooterText()[2]; got != "$8.25" {
+		t.Errorf("Expected the footer to follow its scope, got %q", got)
+	}
+}
+
+func TestCustomAggregate(t *testing.T) {
+	table := footerTestTable()
+	table.Columns[4].Aggregate = func(cells []Cell) interface{} {
+		return "notes"
+	}
+	if got := table.FooterText()[4]; got != "notes" {
+		t.Errorf("Expected custom aggregate text, got %q", got)
+	}
+}
+
+func TestAggregateStructTag(t *testing.T) {
+	type order struct {
+		ID    int     `table:"ID,agg:none"`
+		Price float64 `table:"Price,agg:max"`
+		Item  string  `table:"Item,agg:count"`
+	}
+
+	table := New().WithFooter(FooterView)
+	if err := table.SetData([]order{{1, 2.5, "a"}, {2, 4, "b"}}); err != nil {
+		t.Fatalf("SetData failed: %v", err)
+	}
+
+	want := []string{"", "4.00", "2"}
+	if got := table.FooterText(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected footer %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,102 @@
+package table
+
+import (
+	"strings"
+)
+
+// Alignment controls how cell text is positioned within its column
+type Alignment int
+
+const (
+	AlignDefault Alignment = iota // Right for Integer and Float columns, left otherwise
+	AlignLeft
+	AlignCenter
+	AlignRight
+	AlignDecimal // Right-aligned with decimal points lined up down the column
+)
+
+// WithAlign sets the column alignment
+func (c *Column) WithAlign(align Alignment) *Column {
+	c.Align = align
+	return c
+}
+
+// ResolvedAlign returns the column's alignment, resolving AlignDefault from
+// the column's DataType
+func (c Column) ResolvedAlign() Alignment {
+	if c.Align != AlignDefault {
+		return c.Align
+	}
+	if c.Type == Integer || c.Type == Float {
+		return AlignRight
+	}
+	return AlignLeft
+}
+
+// parseAlignment parses an alignment name as used in the align: struct tag
+func parseAlignment(name string) (Alignment, bool) {
+	switch strings.ToLower(name) {
+	case "left":
+		return AlignLeft, true
+	case "center", "centre":
+		return AlignCenter, true
+	case "right":
+		return AlignRight, true
+	case "decimal":
+		return AlignDecimal, true
+	default:
+		return AlignDefault, false
+	}
+}
+
+// PadText pads s with spaces to width terminal cells according to align.
+// AlignDecimal pads like AlignRight; use AlignDecimals first to line up a
+// column. Text wider than width is returned unchanged.
+func PadText(s string, width int, align Alignment) string {
+	gap := width - DisplayWidth(s)
+	if gap <= 0 {
+		return s
+	}
+
+	switch align {
+	case AlignRight, AlignDecimal:
+		return strings.Repeat(" ", gap) + s
+	case AlignCenter:
+		left := gap / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+	default:
+		return s + strings.Repeat(" ", gap)
+	}
+}
+
+// AlignDecimals pads formatted numbers on the right so that, once
+// right-aligned, their decimal points line up. Values without a decimal
+// point line up where the point would follow their last digit, so suffixes
+// such as "%" stay in the fractional part.
+func AlignDecimals(values []string) []string {
+	fractionWidths := make([]int, len(values))
+	maxFraction := 0
+	for i, value := range values {
+		fractionWidths[i] = DisplayWidth(value[decimalPointIndex(value):])
+		maxFraction = max(maxFraction, fractionWidths[i])
+	}
+
+	aligned := make([]string, len(values))
+	for i, value := range values {
+		aligned[i] = value + strings.Repeat(" ", maxFraction-fractionWidths[i])
+	}
+	return aligned
+}
+
+// decimalPointIndex returns the byte index where the fractional part of a
+// formatted number starts: its last '.', or just after its last digit
+func decimalPointIndex(value string) int {
+	if i := strings.LastIndexByte(value, '.'); i >= 0 {
+		return i
+	}
+	isDigit := func(r rune) bool { return r >= '0' && r <= '9' }
+	if i := strings.LastIndexFunc(value, isDigit); i >= 0 {
+		return i + 1
+	}
+	return len(value)
+}
@@ -0,0 +1,98 @@
+package table
+
+import "testing"
+
+func TestResolvedAlign(t *testing.T) {
+	tests := []struct {
+		name     string
+		column   *Column
+		expected Alignment
+	}{
+		{"string default", NewColumn("name", "Name"), AlignLeft},
+		{"integer default", NewColumn("id", "ID").WithType(Integer), AlignRight},
+		{"float default", NewColumn("price", "Price").WithType(Float), AlignRight},
+		{"explicit", NewColumn("price", "Price").WithType(Float).WithAlign(AlignCenter), AlignCenter},
+		{"decimal", NewColumn("price", "Price").WithAlign(AlignDecimal), AlignDecimal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.column.ResolvedAlign(); got != tt.expected {
+				t.Errorf("ResolvedAlign() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStructTagAlign(t *testing.T) {
+	type Invoice struct {
+		Customer string  `table:"Customer,align:center"`
+		Amount   float64 `table:"Amount,align:decimal"`
+		Quantity int     `table:"Qty"`
+		Note     string  `table:"Note,align:sideways"`
+	}
+
+	tbl := New()
+	if err := tbl.SetData([]Invoice{{"Acme", 12.5, 3, ""}}); err != nil {
+		t.Fatalf("SetData failed: %v", err)
+	}
+
+	expected := []Alignment{AlignCenter, AlignDecimal, AlignRight, AlignLeft}
+	for i, align := range expected {
+		if got := tbl.Columns[i].ResolvedAlign(); got != align {
+			t.Errorf("Column %s: expected alignment %v, got %v", tbl.Columns[i].Key, align, got)
+		}
+	}
+}
+
+func TestPadText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		align    Alignment
+		expected string
+	}{
+		{"left", "ab", 5, AlignLeft, "ab   "},
+		{"right", "ab", 5, AlignRight, "   ab"},
+		{"center", "ab", 5, AlignCenter, " ab  "},
+		{"decimal", "1.5", 5, AlignDecimal, "  1.5"},
+		{"cjk", "日本", 6, AlignRight, "  日本"},
+		{"too wide", "abcdef", 3, AlignLeft, "abcdef"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PadText(tt.input, tt.width, tt.align); got != tt.expected {
+				t.Errorf("PadText(%q, %d, %v) = %q, expected %q", tt.input, tt.width, tt.align, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAlignDecimals(t *testing.T) {
+	values := []string{"$1,234.50", "$7.5", "$100", "12.25%", "N/A", ""}
+	expected := []string{"$1,234.50 ", "$7.5  ", "$100    ", "12.25%", "N/A    ", "    "}
+
+	got := AlignDecimals(values)
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("AlignDecimals()[%d] = %q, expected %q", i, got[i], expected[i])
+		}
+	}
+
+	// Once right-aligned, every decimal point sits in the same column
+	width := 0
+	for _, value := range got {
+		width = max(width, DisplayWidth(value))
+	}
+	point := -1
+	for _, value := range got[:4] {
+		padded := PadText(value, width, AlignRight)
+		index := decimalPointIndex(padded)
+		if point >= 0 && index != point {
+			t.Errorf("Decimal point of %q at %d, expected %d", padded, index, point)
+		}
+		point = index
+	}
+}
@@ -0,0 +1,323 @@
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// columnCache holds values derived from cells, stored column by column and
+// indexed by Row.ID, so that sorting and filtering large tables format or
+// parse each cell only once. It is built lazily from the rows of the table
+// that first needs it, shared with tables filtered from that table, and
+// dropped whenever the data changes.
+//
+// The cache is only enabled when row IDs are unique, non-negative and less
+// than twice the number of rows, as they are for tables built with SetData
+// and AddRow even after some rows are deleted. Row mutations update it in
+// place rather than dropping it (see updateRow).
+type columnCache struct {
+	enabled   bool
+	rows      []Row // Source rows, in any order
+	size      int   // Number of row IDs covered
+	sortKeys  map[int]*sortKeyColumn
+	formatted map[int][]string
+	lowered   map[int][]string
+}
+
+// sortKeyColumn holds one column's cell values parsed for comparison, using
+// the same rules as compareCells
+type sortKeyColumn struct {
+	kind  DataType
+	strs  []string
+	ints  []int
+	nums  []float64
+	bools []bool
+	times []time.Time
+	valid []bool // Whether each Date cell parsed
+	nulls []bool // Whether each cell is null, if any are
+}
+
+// newColumnCache indexes rows by ID, disabling the cache if IDs are too
+// sparse or repeat
+func newColumnCache(rows []Row) *columnCache {
+	cache := &columnCache{
+		rows:      rows,
+		sortKeys:  make(map[int]*sortKeyColumn),
+		formatted: make(map[int][]string),
+		lowered:   make(map[int][]string),
+	}
+
+	limit := 2 * len(rows)
+	seen := make([]bool, limit)
+	for _, row := range rows {
+		if row.ID < 0 || row.ID >= limit || seen[row.ID] {
+			return cache
+		}
+		seen[row.ID] = true
+		cache.size = max(cache.size, row.ID+1)
+	}
+	cache.enabled = true
+	return cache
+}
+
+// Invalidate drops values cached for sorting, filtering and the footer.
+// Call it after changing cell values, column formatters or aggregates in
+// place; SetData and AddRow
+// invalidate the cache themselves. Tables backed by a DataSource also drop
+// their fetched pages and row count, so they are fetched again.
+func (t *Table) Invalidate() {
+	t.cache = nil
+	t.footer = nil
+	t.PageBreaks = nil
+	t.positions = nil
+	t.version++ // Filtered views filter again
+	t.sourcePages = nil
+	t.sourceCounted = false
+}
+
+// columnCache returns the table's cache, building it on first use. It
+// returns nil if the cache cannot be used for this table's rows. Filtered
+// views use the cache of the table they were made from.
+func (t *Table) columnCache() *columnCache {
+	if t.isView() {
+		return t.parent.columnCache()
+	}
+	if t.cache == nil {
+		t.cache = newColumnCache(t.UnsortedOrder)
+	}
+	if !t.cache.enabled {
+		return nil
+	}
+	return t.cache
+}
+
+// covers reports whether the cache holds values for a row
+func (c *columnCache) covers(row Row) bool {
+	return row.ID >= 0 && row.ID < c.size
+}
+
+// cellValue returns the value of a column's cell in a source row
+func cellValueAt(row Row, colIndex int) (Cell, bool) {
+	if colIndex < 0 || colIndex >= len(row.Cells) {
+		return Cell{}, false
+	}
+	return row.Cells[colIndex], true
+}
+
+// formattedColumn returns a column's cells formatted by the column's
+// Formatter, indexed by row ID
+func (c *columnCache) formattedColumn(t *Table, colIndex int) []string {
+	if values, ok := c.formatted[colIndex]; ok {
+		return values
+	}
+
+	values := make([]string, c.size)
+	for _, row := range c.rows {
+		if cell, ok := cellValueAt(row, colIndex); ok {
+			values[row.ID] = t.formatCellValue(cell, colIndex)
+		}
+	}
+	c.formatted[colIndex] = values
+	return values
+}
+
+// loweredColumn returns a column's formatted cells in lower case, indexed by
+// row ID
+func (c *columnCache) loweredColumn(t *Table, colIndex int) []string {
+	if values, ok := c.lowered[colIndex]; ok {
+		return values
+	}
+
+	formatted := c.formattedColumn(t, colIndex)
+	values := make([]string, c.size)
+	for i, value := range formatted {
+		values[i] = strings.ToLower(value)
+	}
+	c.lowered[colIndex] = values
+	return values
+}
+
+// sortKeyColumn returns a column's cell values parsed for comparison,
+// indexed by row ID
+func (c *columnCache) sortKeyColumn(colIndex int) *sortKeyColumn {
+	if keys, ok := c.sortKeys[colIndex]; ok {
+		return keys
+	}
+
+	keys := &sortKeyColumn{kind: String}
+	for _, row := range c.rows {
+		if cell, ok := cellValueAt(row, colIndex); ok {
+			keys.kind = cell.Type
+			break
+		}
+	}
+
+	switch keys.kind {
+	case Integer:
+		keys.ints = make([]int, c.size)
+	case Float:
+		keys.nums = make([]float64, c.size)
+	case Boolean:
+		keys.bools = make([]bool, c.size)
+	case Date:
+		keys.times = make([]time.Time, c.size)
+		keys.valid = make([]bool, c.size)
+		keys.strs = make([]string, c.size)
+	default:
+		keys.strs = make([]string, c.size)
+	}
+
+	for _, row := range c.rows {
+		cell, ok := cellValueAt(row, colIndex)
+		if !ok {
+			continue
+		}
+		c.setSortKey(keys, row.ID, cell)
+	}
+
+	c.sortKeys[colIndex] = keys
+	return keys
+}
+
+// setSortKey parses a cell for comparison and stores it under a row ID
+func (c *columnCache) setSortKey(keys *sortKeyColumn, id int, cell Cell) {
+	if cell.IsNull() {
+		if keys.nulls == nil {
+			keys.nulls = make([]bool, c.size)
+		}
+		keys.nulls[id] = true
+		return
+	}
+	if keys.nulls != nil {
+		keys.nulls[id] = false
+	}
+	text := fmt.Sprintf("%v", cell.Value)
+
+	switch keys.kind {
+	case Integer:
+		keys.ints[id], _ = strconv.Atoi(text)
+	case Float:
+		keys.nums[id], _ = strconv.ParseFloat(text, 64)
+	case Boolean:
+		keys.bools[id] = text == "true"
+	case Date:
+		value, err := parseDate(cell.Value)
+		keys.times[id], keys.valid[id] = value, err == nil
+		keys.strs[id] = text
+	default:
+		keys.strs[id] = strings.ToLower(text)
+	}
+}
+
+// updateRow refreshes the cached values of a row that was added or changed,
+// growing the cache to cover a new row ID
+func (c *columnCache) updateRow(t *Table, row Row) {
+	if row.ID >= c.size {
+		c.grow(row.ID + 1)
+	}
+
+	for colIndex, keys := range c.sortKeys {
+		cell, _ := cellValueAt(row, colIndex)
+		c.setSortKey(keys, row.ID, cell)
+	}
+	for colIndex, values := range c.formatted {
+		if cell, ok := cellValueAt(row, colIndex); ok {
+			values[row.ID] = t.formatCellValue(cell, colIndex)
+		}
+	}
+	for colIndex, values := range c.lowered {
+		values[row.ID] = strings.ToLower(c.formatted[colIndex][row.ID])
+	}
+}
+
+// grow extends every cached column to cover size row IDs
+func (c *columnCache) grow(size int) {
+	c.size = size
+	for _, keys := range c.sortKeys {
+		keys.ints = growTo(keys.ints, size)
+		keys.nums = growTo(keys.nums, size)
+		keys.bools = growTo(keys.bools, size)
+		keys.times = growTo(keys.times, size)
+		keys.valid = growTo(keys.valid, size)
+		keys.strs = growTo(keys.strs, size)
+		keys.nulls = growTo(keys.nulls, size)
+	}
+	for colIndex, values := range c.formatted {
+		c.formatted[colIndex] = growTo(values, size)
+	}
+	for colIndex, values := range c.lowered {
+		c.lowered[colIndex] = growTo(values, size)
+	}
+}
+
+// growTo extends a non-nil slice with zero values to length n
+func growTo[T any](s []T, n int) []T {
+	if s == nil || len(s) >= n {
+		return s
+	}
+	return append(s, make([]T, n-len(s))...)
+}
+
+// compare orders two rows by their cached keys, like compareCells
+func (k *sortKeyColumn) compare(a, b int) int {
+	if k.nulls != nil && (k.nulls[a] || k.nulls[b]) {
+		return compareNulls(k.nulls[a], k.nulls[b])
+	}
+
+	switch k.kind {
+	case Integer:
+		return cmp.Compare(k.ints[a], k.ints[b])
+	case Float:
+		return cmp.Compare(k.nums[a], k.nums[b])
+	case Boolean:
+		switch {
+		case k.bools[a] == k.bools[b]:
+			return 0
+		case k.bools[a]:
+			return 1
+		default:
+			return -1
+		}
+	case Date:
+		if !k.valid[a] || !k.valid[b] {
+			return strings.Compare(k.strs[a], k.strs[b])
+		}
+		return k.times[a].Compare(k.times[b])
+	default:
+		return strings.Compare(k.strs[a], k.strs[b])
+	}
+}
+
+// cellText returns a cell formatted by its column's Formatter
+func (t *Table) cellText(row Row, colIndex int) string {
+	if cache := t.columnCache(); cache != nil && cache.covers(row) {
+		return cache.formattedColumn(t, colIndex)[row.ID]
+	}
+	return t.formatCellValue(row.Cells[colIndex], colIndex)
+}
+
+// cellTextLower returns a cell formatted by its column's Formatter, in lower case
+func (t *Table) cellTextLower(row Row, colIndex int) string {
+	if cache := t.columnCache(); cache != nil && cache.covers(row) {
+		return cache.loweredColumn(t, colIndex)[row.ID]
+	}
+	return strings.ToLower(t.formatCellValue(row.Cells[colIndex], colIndex))
+}
+
+// compareSortKeys orders two rows by ID using cached keys, like compareRows
+func compareSortKeys(a, b int, columns []*sortKeyColumn, keys []SortKey) int {
+	for i, key := range keys {
+		result := columns[i].compare(a, b)
+		if result == 0 {
+			continue
+		}
+		if key.Desc {
+			return -result
+		}
+		return result
+	}
+	return 0
+}
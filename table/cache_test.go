@@ -0,0 +1,122 @@
+package table
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+// cacheTestTable returns a table with a column of every data type
+func cacheTestTable() *Table {
+	table := NewWithColumns([]Column{
+		*NewColumn("name", "Name").WithType(String),
+		*NewColumn("age", "Age").WithType(Integer),
+		*NewColumn("score", "Score").WithType(Float),
+		*NewColumn("joined", "Joined").WithType(Date),
+		*NewColumn("active", "Active").WithType(Boolean),
+	})
+	table.SetData([]map[string]interface{}{
+		{"name": "carol", "age": 41, "score": 7.5, "joined": "2021-03-01", "active": true},
+		{"name": "Alice", "age": 30, "score": 9.25, "joined": "2020-11-15", "active": false},
+		{"name": "bob", "age": 30, "score": -1.0, "joined": "not a date", "active": true},
+		{"name": "Dave", "age": 7, "score": 9.25, "joined": "2019-06-30", "active": false},
+		{"name": "alice", "age": 55, "score": 0.0, "joined": "2021-03-01", "active": true},
+	})
+	return table
+}
+
+func rowIDs(rows []Row) []int {
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	return ids
+}
+
+func TestCachedSortMatchesCompareCells(t *testing.T) {
+	keySets := [][]SortKey{
+		{{Column: 0}},
+		{{Column: 0, Desc: true}},
+		{{Column: 1}},
+		{{Column: 2, Desc: true}},
+		{{Column: 3}},
+		{{Column: 4}},
+		{{Column: 1}, {Column: 0, Desc: true}},
+		{{Column: 4, Desc: true}, {Column: 2}},
+	}
+
+	for _, keys := range keySets {
+		table := cacheTestTable()
+		if err := table.SortByKeys(keys...); err != nil {
+			t.Fatalf("SortByKeys(%v) failed: %v", keys, err)
+		}
+
+		expected := slices.Clone(table.UnsortedOrder)
+		sort.SliceStable(expected, func(i, j int) bool {
+			return compareRows(expected[i], expected[j], keys) < 0
+		})
+
+		if got, want := rowIDs(table.Rows), rowIDs(expected); !slices.Equal(got, want) {
+			t.Errorf("Sort by %v: expected row order %v, got %v", keys, want, got)
+		}
+	}
+}
+
+func TestFilteredTableSharesCache(t *testing.T) {
+	table := cacheTestTable()
+	filtered := table.Filter("a")
+
+	if filtered.cache != nil || filtered.columnCache() != table.columnCache() {
+		t.Fatal("Expected the filtered table to share its source's cache")
+	}
+
+	// Sorting the filtered view uses keys cached for the source rows
+	filtered.SortByColumn(1, true)
+	if got := rowIDs(filtered.CurrentRows()); !slices.Equal(got, []int{4, 0, 1, 2, 3}) {
+		t.Errorf("Expected filtered rows sorted by age, got %v", got)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	table := cacheTestTable()
+	if got := table.Filter("carol").TotalRows; got != 1 {
+		t.Fatalf("Expected 1 match before editing, got %d", got)
+	}
+
+	// Cached text goes stale after an in-place edit until invalidated
+	table.UnsortedOrder[0].Cells[0].Value = "erin"
+	table.Invalidate()
+	if got := table.Filter("carol").TotalRows; got != 0 {
+		t.Errorf("Expected no matches after invalidating, got %d", got)
+	}
+	if got := table.Filter("erin").TotalRows; got != 1 {
+		t.Errorf("Expected the edited value to match, got %d", got)
+	}
+
+	// AddRow invalidates the cache itself
+	table.AddRow("frank", 20, 1.0, "2022-01-01", false)
+	if got := table.Filter("frank").TotalRows; got != 1 {
+		t.Errorf("Expected the added row to match, got %d", got)
+	}
+}
+
+func TestCacheDisabledForSparseIDs(t *testing.T) {
+	table := cacheTestTable()
+	for i := range table.UnsortedOrder {
+		table.UnsortedOrder[i].ID = i * 10
+	}
+	table.Invalidate()
+
+	if table.columnCache() != nil {
+		t.Fatal("Expected no cache for rows with sparse IDs")
+	}
+
+	// Sorting and filtering still work without it
+	table.SortByColumn(1, false)
+	if got := rowIDs(table.Rows); !slices.Equal(got, []int{30, 10, 20, 0, 40}) {
+		t.Errorf("Expected rows sorted by age, got %v", got)
+	}
+	if got := table.Filter("alice").TotalRows; got != 2 {
+		t.Errorf("Expected 2 matches, got %d", got)
+	}
+}
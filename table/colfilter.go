@@ -0,0 +1,239 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnFilter is a predicate applied to the cells of a single column
+type ColumnFilter interface {
+	// Match reports whether a cell passes the filter. formatted is the cell
+	// value as rendered by the column's Formatter.
+	Match(cell Cell, formatted string) bool
+	// String describes the filter for display
+	String() string
+}
+
+// TextFilter matches String cells that contain (or equal) Text, ignoring case
+type TextFilter struct {
+	Text  string
+	Exact bool
+}
+
+// Match implements ColumnFilter
+func (f TextFilter) Match(_ Cell, formatted string) bool {
+	if f.Exact {
+		return strings.EqualFold(formatted, f.Text)
+	}
+	return strings.Contains(strings.ToLower(formatted), strings.ToLower(f.Text))
+}
+
+// String implements ColumnFilter
+func (f TextFilter) String() string {
+	if f.Exact {
+		return "=" + f.Text
+	}
+	return "~" + f.Text
+}
+
+// NumberRange matches Integer and Float cells within [Min, Max]. A nil bound
+// leaves that side of the range open.
+type NumberRange struct {
+	Min *float64
+	Max *float64
+}
+
+// Match implements ColumnFilter
+func (f NumberRange) Match(cell Cell, _ string) bool {
+	value, ok := numericValue(cell.Value)
+	if !ok {
+		return false
+	}
+	return (f.Min == nil || value >= *f.Min) && (f.Max == nil || value <= *f.Max)
+}
+
+// String implements ColumnFilter
+func (f NumberRange) String() string {
+	format := func(bound *float64) string {
+		if bound == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*bound, 'f', -1, 64)
+	}
+	return formatRange(format(f.Min), format(f.Max))
+}
+
+// DateRange matches Date cells within [From, To]. A zero bound leaves that
+// side of the range open.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Match implements ColumnFilter
+func (f DateRange) Match(cell Cell, _ string) bool {
+	value, ok := dateValue(cell.Value)
+	if !ok {
+		return false
+	}
+	return (f.From.IsZero() || !value.Before(f.From)) && (f.To.IsZero() || !value.After(f.To))
+}
+
+// String implements ColumnFilter
+func (f DateRange) String() string {
+	format := func(bound time.Time) string {
+		if bound.IsZero() {
+			return ""
+		}
+		return bound.Format("2006-01-02")
+	}
+	return formatRange(format(f.From), format(f.To))
+}
+
+// BoolFilter matches Boolean cells equal to Value. Together with having no
+// filter at all it forms a tri-state: any, true, false.
+type BoolFilter struct {
+	Value bool
+}
+
+// Match implements ColumnFilter
+func (f BoolFilter) Match(cell Cell, _ string) bool {
+	value, ok := boolValue(cell.Value)
+	return ok && value == f.Value
+}
+
+// String implements ColumnFilter
+func (f BoolFilter) String() string {
+	return strconv.FormatBool(f.Value)
+}
+
+// formatRange renders range bounds, collapsing equal bounds to a single value
+func formatRange(lower, upper string) string {
+	if lower == upper {
+		return lower
+	}
+	return lower + ".." + upper
+}
+
+// ParseColumnFilter builds a filter for a column from user input, based on
+// the column's DataType. Empty input returns a nil filter.
+//
+//	String:         text contains, =text equals
+//	Integer, Float: 5, 1..10, 1.., ..10
+//	Date:           2024-01-01, 2024-01-01..2024-03-31, 2024-01-01.., ..2024-03-31
+//	Boolean:        true/false, yes/no, 1/0
+func ParseColumnFilter(col Column, input string) (ColumnFilter, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+
+	switch col.Type {
+	case Integer, Float:
+		lower, upper, err := parseRangeBounds(input, func(s string) (*float64, error) {
+			value, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %q is not a valid number", col.Header, s)
+			}
+			return &value, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return NumberRange{Min: lower, Max: upper}, nil
+
+	case Date:
+		from, to, err := parseRangeBounds(input, func(s string) (time.Time, error) {
+			value, err := parseDate(s)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("%s: %q is not a valid date", col.Header, s)
+			}
+			return value, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return DateRange{From: from, To: to}, nil
+
+	case Boolean:
+		value, ok := parseBool(input)
+		if !ok {
+			return nil, fmt.Errorf("%s: %q is not a valid boolean", col.Header, input)
+		}
+		return BoolFilter{Value: value}, nil
+
+	default:
+		if strings.HasPrefix(input, "=") {
+			return TextFilter{Text: input[1:], Exact: true}, nil
+		}
+		return TextFilter{Text: input}, nil
+	}
+}
+
+// parseRangeBounds splits "min..max" and parses each non-empty side. A value
+// without ".." is used for both bounds.
+func parseRangeBounds[T any](input string, parse func(string) (T, error)) (T, T, error) {
+	var lower, upper T
+	lowerText, upperText, isRange := strings.Cut(input, "..")
+	if !isRange {
+		value, err := parse(input)
+		return value, value, err
+	}
+
+	var err error
+	if lowerText = strings.TrimSpace(lowerText); lowerText != "" {
+		if lower, err = parse(lowerText); err != nil {
+			return lower, upper, err
+		}
+	}
+	if upperText = strings.TrimSpace(upperText); upperText != "" {
+		if upper, err = parse(upperText); err != nil {
+			return lower, upper, err
+		}
+	}
+	return lower, upper, nil
+}
+
+// FilterColumns returns a new table with the rows whose cells pass every
+// column filter, keyed by column index
+func (t *Table) FilterColumns(filters map[int]ColumnFilter) *Table {
+	if len(filters) == 0 || t.source != nil {
+		return t
+	}
+
+	return t.filterRows(func(row Row) bool {
+		for colIndex, filter := range filters {
+			if filter == nil {
+				continue
+			}
+			if colIndex < 0 || colIndex >= len(row.Cells) {
+				return false
+			}
+			if !filter.Match(row.Cells[colIndex], t.cellText(row, colIndex)) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// DescribeColumnFilters renders active filters as "Header filter" pairs,
+// in column order
+func (t *Table) DescribeColumnFilters(filters map[int]ColumnFilter) string {
+	indexes := make([]int, 0, len(filters))
+	for colIndex, filter := range filters {
+		if filter != nil && colIndex >= 0 && colIndex < len(t.Columns) {
+			indexes = append(indexes, colIndex)
+		}
+	}
+	sort.Ints(indexes)
+
+	parts := make([]string, len(indexes))
+	for i, colIndex := range indexes {
+		parts[i] = fmt.Sprintf("%s %s", t.Columns[colIndex].Header, filters[colIndex])
+	}
+	return strings.Join(parts, ", ")
+}
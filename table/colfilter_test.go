@@ -0,0 +1,98 @@
+package table
+
+import (
+	"testing"
+)
+
+func TestParseColumnFilter(t *testing.T) {
+	tests := []struct {
+		col      Column
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{*NewColumn("s", "Status"), "todo", "~todo", false},
+		{*NewColumn("s", "Status"), "=todo", "=todo", false},
+		{*NewColumn("p", "Priority").WithType(Integer), "2", "2", false},
+		{*NewColumn("p", "Priority").WithType(Integer), "1..5", "1..5", false},
+		{*NewColumn("p", "Priority").WithType(Integer), "3..", "3..", false},
+		{*NewColumn("e", "Estimate").WithType(Float), "..2.5", "..2.5", false},
+		{*NewColumn("p", "Priority").WithType(Integer), "high", "", true},
+		{*NewColumn("d", "Due").WithType(Date), "2024-01-01..2024-01-31", "2024-01-01..2024-01-31", false},
+		{*NewColumn("d", "Due").WithType(Date), "soon..", "", true},
+		{*NewColumn("b", "Done").WithType(Boolean), "yes", "true", false},
+		{*NewColumn("b", "Done").WithType(Boolean), "maybe", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.col.Header+"/"+tt.input, func(t *testing.T) {
+			filter, err := ParseColumnFilter(tt.col, tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseColumnFilter(%q) should fail", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseColumnFilter(%q) failed: %v", tt.input, err)
+			}
+			if filter.String() != tt.expected {
+				t.Errorf("ParseColumnFilter(%q) = %q, expected %q", tt.input, filter.String(), tt.expected)
+			}
+		})
+	}
+
+	filter, err := ParseColumnFilter(*NewColumn("s", "Status"), "  ")
+	if filter != nil || err != nil {
+		t.Errorf("Empty input should return no filter, got %v, %v", filter, err)
+	}
+}
+
+func TestFilterColumns(t *testing.T) {
+	tbl := newTicketTable()
+
+	parse := func(colIndex int, input string) ColumnFilter {
+		filter, err := ParseColumnFilter(tbl.Columns[colIndex], input)
+		if err != nil {
+			t.Fatalf("ParseColumnFilter(%q) failed: %v", input, err)
+		}
+		return filter
+	}
+
+	tests := []struct {
+		name     string
+		filters  map[int]ColumnFilter
+		expected []string
+	}{
+		{"text contains", map[int]ColumnFilter{1: parse(1, "do")}, []string{"Fix login bug", "Write docs", "Fix logout bug"}},
+		{"text equals", map[int]ColumnFilter{1: parse(1, "=todo")}, []string{"Write docs", "Fix logout bug"}},
+		{"integer range", map[int]ColumnFilter{2: parse(2, "2..5")}, []string{"Fix login bug", "Add dark mode"}},
+		{"float open range", map[int]ColumnFilter{4: parse(4, "8..")}, []string{"Write docs", "Add dark mode"}},
+		{"date range", map[int]ColumnFilter{5: parse(5, "2024-01-01..2024-01-31")}, []string{"Fix login bug", "Add dark mode"}},
+		{"boolean", map[int]ColumnFilter{6: parse(6, "false")}, []string{"Write docs", "Add dark mode", "Fix logout bug"}},
+		{"combined", map[int]ColumnFilter{1: parse(1, "todo"), 3: parse(3, "bob"), 2: parse(2, "..5")}, []string{"Write docs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := tbl.FilterColumns(tt.filters)
+			if len(filtered.CurrentRows()) != len(tt.expected) {
+				t.Fatalf("Expected %d rows, got %d", len(tt.expected), len(filtered.CurrentRows()))
+			}
+			for i, title := range tt.expected {
+				if filtered.CurrentRows()[i].Cells[0].Value != title {
+					t.Errorf("Row %d: expected %q, got %v", i, title, filtered.CurrentRows()[i].Cells[0].Value)
+				}
+			}
+		})
+	}
+
+	if tbl.FilterColumns(nil) != tbl {
+		t.Error("FilterColumns with no filters should return the table itself")
+	}
+
+	described := tbl.DescribeColumnFilters(map[int]ColumnFilter{2: parse(2, "1..5"), 1: parse(1, "todo")})
+	if described != "Status ~todo, Priority 1..5" {
+		t.Errorf("Unexpected filter description %q", described)
+	}
+}
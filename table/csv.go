@@ -0,0 +1,305 @@
+package table
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions configures ReadCSV and WriteCSV
+type CSVOptions struct {
+	Delimiter rune // Field separator; ',' if zero. Use '\t' for TSV.
+	Header    bool // The first record holds column names
+
+	// Reading
+	SniffTypes bool // Pick each column's DataType from its values
+	LazyQuotes bool // Accept quotes inside unquoted fields and stray quotes in quoted ones
+
+	// Writing
+	QuoteAll  bool // Quote every field, not only those that need it
+	Formatted bool // Write each column's Formatter output instead of raw values
+	AllRows   bool // Write every row in its original order instead of the current view
+}
+
+// DefaultCSVOptions returns options for comma-separated files with a header
+// row, sniffing column types when reading
+func DefaultCSVOptions() CSVOptions {
+	return CSVOptions{Delimiter: ',', Header: true, SniffTypes: true}
+}
+
+// TSVOptions returns options for tab-separated files with a header row,
+// sniffing column types when reading
+func TSVOptions() CSVOptions {
+	opts := DefaultCSVOptions()
+	opts.Delimiter = '\t'
+	return opts
+}
+
+// delimiter returns the field separator, defaulting to a comma
+func (o CSVOptions) delimiter() rune {
+	if o.Delimiter == 0 {
+		return ','
+	}
+	return o.Delimiter
+}
+
+// ReadCSV reads a table from delimited text. Without a header row columns
+// are named "Column 1", "Column 2" and so on. Without type sniffing every
+// column is a String column.
+//
+// Sniffing types a column Integer, Float, Boolean or Date when every
+// non-empty value parses the way sorting compares that type, and converts
+// the values; empty values in such columns become nil. Columns with numbers
+// such as "02134" that have a leading zero stay String.
+func ReadCSV(r io.Reader, opts CSVOptions) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.delimiter()
+	reader.LazyQuotes = opts.LazyQuotes
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return New(), nil
+	}
+
+	var headers []string
+	if opts.Header {
+		headers, records = records[0], records[1:]
+	} else {
+		headers = make([]string, len(records[0]))
+	}
+
+	columns := make([]Column, len(headers))
+	for i, header := range headers {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			header = fmt.Sprintf("Column %d", i+1)
+		}
+		columns[i] = *NewColumn(header, header)
+	}
+
+	if opts.SniffTypes {
+		for i := range columns {
+			columns[i].Type = sniffColumnType(records, i)
+			columns[i].Width = New().getDefaultWidth(columns[i].Type)
+		}
+	}
+
+	t := NewWithColumns(columns)
+	for id, record := range records {
+		cells := make([]Cell, len(columns))
+		for i, col := range columns {
+			cells[i] = Cell{Value: csvValue(record[i], col.Type), Type: col.Type}
+		}
+		row := Row{ID: id, Cells: cells, Data: record}
+		t.Rows = append(t.Rows, row)
+		t.UnsortedOrder = append(t.UnsortedOrder, row)
+		t.originalData = append(t.originalData, record)
+		t.TotalRows++
+	}
+	return t, nil
+}
+
+// sniffColumnType returns the narrowest DataType that every non-empty value
+// of a column parses as, checking the same parsing compareCells uses
+func sniffColumnType(records [][]string, colIndex int) DataType {
+	candidates := []DataType{Integer, Float, Boolean, Date}
+	seen := false
+
+	for _, record := range records {
+		value := record[colIndex]
+		if value == "" {
+			continue
+		}
+		seen = true
+
+		remaining := candidates[:0]
+		for _, dataType := range candidates {
+			if csvParses(value, dataType) {
+				remaining = append(remaining, dataType)
+			}
+		}
+		candidates = remaining
+		if len(candidates) == 0 {
+			return String
+		}
+	}
+
+	if !seen {
+		return String
+	}
+	return candidates[0]
+}
+
+// csvParses reports whether text parses as a DataType. Numbers with a
+// leading zero, such as ZIP codes and IDs like "007", are not numbers, as
+// converting them would lose the zeros.
+func csvParses(text string, dataType DataType) bool {
+	switch dataType {
+	case Integer:
+		_, err := strconv.Atoi(text)
+		return err == nil && !hasLeadingZero(text)
+	case Float:
+		_, err := strconv.ParseFloat(text, 64)
+		return err == nil && !hasLeadingZero(text)
+	case Boolean:
+		return text == "true" || text == "false"
+	case Date:
+		_, err := parseDate(text)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// hasLeadingZero reports whether a number starts with a zero before another
+// digit, as "02134" does but "0" and "0.5" do not
+func hasLeadingZero(text string) bool {
+	digits := strings.TrimLeft(text, "+-")
+	return len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9'
+}
+
+// csvValue converts text to a cell value of a DataType. Dates keep their
+// text so they are written back unchanged.
+func csvValue(text string, dataType DataType) interface{} {
+	if dataType == String {
+		return text
+	}
+	if text == "" {
+		return nil
+	}
+
+	switch dataType {
+	case Integer:
+		n, _ := strconv.Atoi(text)
+		return n
+	case Float:
+		f, _ := strconv.ParseFloat(text, 64)
+		return f
+	case Boolean:
+		return text == "true"
+	default:
+		return text
+	}
+}
+
+// WriteCSV writes the table as delimited text: the current view (sorted and
+// filtered rows, as shown) unless opts.AllRows asks for every row in its
+// original order. Tables backed by a DataSource fetch the rows to write.
+func (t *Table) WriteCSV(w io.Writer, opts CSVOptions) error {
+	rows, err := t.exportRows(opts.AllRows)
+	if err != nil {
+		return err
+	}
+
+	write := newCSVRecordWriter(w, opts)
+	if opts.Header {
+		if err := write(t.GetColumnNames()); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(t.Columns))
+	for _, row := range rows {
+		for i, col := range t.Columns {
+			record[i] = exportValue(col, row, i, opts.Formatted)
+		}
+		if err := write(record); err != nil {
+			return err
+		}
+	}
+	return write(nil)
+}
+
+// newCSVRecordWriter returns a function writing one record at a time; a nil
+// record flushes the output
+func newCSVRecordWriter(w io.Writer, opts CSVOptions) func(record []string) error {
+	if !opts.QuoteAll {
+		writer := csv.NewWriter(w)
+		writer.Comma = opts.delimiter()
+		return func(record []string) error {
+			if record == nil {
+				writer.Flush()
+				return writer.Error()
+			}
+			return writer.Write(record)
+		}
+	}
+
+	delimiter := string(opts.delimiter())
+	return func(record []string) error {
+		if record == nil {
+			return nil
+		}
+		fields := make([]string, len(record))
+		for i, field := range record {
+			fields[i] = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+		}
+		_, err := io.WriteString(w, strings.Join(fields, delimiter)+"\n")
+		return err
+	}
+}
+
+// exportRows returns the rows to export: the current view, or every row in
+// its original order. Source-backed tables fetch them from their source.
+func (t *Table) exportRows(all bool) ([]Row, error) {
+	if t.source == nil {
+		if all {
+			return t.originalRows(), nil
+		}
+		return t.CurrentRows(), nil
+	}
+
+	filter, sort := t.sourceFilter, t.SortKeys
+	if all {
+		filter, sort = "", nil
+	}
+	count, err := t.source.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+	return t.source.Fetch(0, count, sort, filter)
+}
+
+// exportValue returns a cell's text for export: the column's formatted
+// display text, or the raw value
+func exportValue(col Column, row Row, colIndex int, formatted bool) string {
+	if colIndex >= len(row.Cells) {
+		return ""
+	}
+	value := row.Cells[colIndex].Value
+	if formatted {
+		return col.Format(value)
+	}
+	return RawValue(value)
+}
+
+// RawValue returns a value as plain text without formatting: numbers in full
+// precision, dates as RFC 3339 (or just the date at midnight UTC) and nil as
+// empty text
+func RawValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		if v.Equal(v.Truncate(24*time.Hour)) && v.Location() == time.UTC {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,202 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+const invoicesCSV = `id,customer,amount,paid,due
+3,"Smith, Jane",12.50,true,2024-03-01
+1,Acme,7,false,
+2,"Say ""hi""",100.25,true,2024-01-15
+`
+
+func TestReadCSV(t *testing.T) {
+	table, err := ReadCSV(strings.NewReader(invoicesCSV), DefaultCSVOptions())
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+
+	if table.TotalRows != 3 || len(table.Rows) != 3 || table.Rows[2].ID != 2 {
+		t.Fatalf("Expected 3 rows with sequential IDs, got %d", table.TotalRows)
+	}
+	for i, want := range []DataType{Integer, String, Float, Boolean, Date} {
+		if table.Columns[i].Type != want {
+			t.Errorf("Column %s: expected type %v, got %v", table.Columns[i].Key, want, table.Columns[i].Type)
+		}
+	}
+
+	first := table.Rows[0].Cells
+	if first[0].Value != 3 || first[1].Value != "Smith, Jane" || first[2].Value != 12.5 || first[3].Value != true {
+		t.Errorf("Expected values converted to column types, got %#v", first)
+	}
+	if !table.Rows[1].Cells[4].IsNull() {
+		t.Errorf("Expected an empty date to be null, got %#v", table.Rows[1].Cells[4].Value)
+	}
+	if got := table.Rows[2].Cells[1].Value; got != `Say "hi"` {
+		t.Errorf("Expected quoted field to be unescaped, got %q", got)
+	}
+
+	// Sniffed types sort numerically
+	table.SortByColumn(2, false)
+	if got := table.Rows[2].Cells[2].Value; got != 100.25 {
+		t.Errorf("Expected 100.25 to sort last, got %v", got)
+	}
+}
+
+func TestReadCSVOptions(t *testing.T) {
+	// Tab-separated, no header, no sniffing
+	table, err := ReadCSV(strings.NewReader("1\ta b\n2\t\n"), CSVOptions{Delimiter: '\t'})
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+	if got := table.GetColumnNames(); len(got) != 2 || got[0] != "Column 1" || got[1] != "Column 2" {
+		t.Errorf("Expected generated column names, got %v", got)
+	}
+	if table.Columns[0].Type != String || table.Rows[0].Cells[0].Value != "1" {
+		t.Errorf("Expected String columns without sniffing, got %v", table.Columns[0].Type)
+	}
+	if table.TotalRows != 2 {
+		t.Errorf("Expected the first record to be data, got %d rows", table.TotalRows)
+	}
+
+	// Stray quotes need LazyQuotes
+	bad := "name\nsay \"hi\"\n"
+	if _, err := ReadCSV(strings.NewReader(bad), DefaultCSVOptions()); err == nil {
+		t.Error("Expected an error for a bare quote")
+	}
+	opts := DefaultCSVOptions()
+	opts.LazyQuotes = true
+	table, err = ReadCSV(strings.NewReader(bad), opts)
+	if err != nil {
+		t.Fatalf("ReadCSV with LazyQuotes failed: %v", err)
+	}
+	if got := table.Rows[0].Cells[0].Value; got != `say "hi"` {
+		t.Errorf("Expected the quote kept, got %q", got)
+	}
+
+	// Empty input gives an empty table
+	table, err = ReadCSV(strings.NewReader(""), DefaultCSVOptions())
+	if err != nil || table.TotalRows != 0 {
+		t.Errorf("Expected an empty table, got %v rows, err %v", table, err)
+	}
+}
+
+func TestSniffColumnType(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   DataType
+	}{
+		{[]string{"1", "-2", ""}, Integer},
+		{[]string{"1", "2.5"}, Float},
+		{[]string{"true", "false"}, Boolean},
+		{[]string{"2024-01-15", "03/01/2024"}, Date},
+		{[]string{"1", "abc"}, String},
+		{[]string{"0", "0.5", "-0.25"}, Float},
+		{[]string{"02134", "10001"}, String},
+		{[]string{"1", "007"}, String},
+		{[]string{"1.5", "-00.5"}, String},
+		{[]string{"", ""}, String},
+	}
+
+	for _, tt := range tests {
+		records := make([][]string, len(tt.values))
+		for i, value := range tt.values {
+			records[i] = []string{value}
+		}
+		if got := sniffColumnType(records, 0); got != tt.want {
+			t.Errorf("sniffColumnType(%q) = %v, expected %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestCSVKeepsLeadingZeros(t *testing.T) {
+	input := "zip,agent,rate\n02134,007,0.5\n10001,12,0\n"
+	table, err := ReadCSV(strings.NewReader(input), DefaultCSVOptions())
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+	if table.Columns[0].Type != String || table.Columns[1].Type != String || table.Columns[2].Type != Float {
+		t.Errorf("Expected String, String and Float columns, got %v, %v and %v",
+			table.Columns[0].Type, table.Columns[1].Type, table.Columns[2].Type)
+	}
+
+	var out strings.Builder
+	if err := table.WriteCSV(&out, DefaultCSVOptions()); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if out.String() != input {
+		t.Errorf("Expected the zeros to survive a round trip, got:\n%s", out.String())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	table, err := ReadCSV(strings.NewReader(invoicesCSV), DefaultCSVOptions())
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+
+	// Raw values round-trip, apart from float precision
+	var out strings.Builder
+	if err := table.WriteCSV(&out, DefaultCSVOptions()); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	want := strings.Replace(invoicesCSV, "12.50", "12.5", 1)
+	if out.String() != want {
+		t.Errorf("Expected round trip:\n%s\ngot:\n%s", want, out.String())
+	}
+
+	// The current view is written unless AllRows is set
+	table.SortByColumn(0, false)
+	view := table.Filter("a")
+	opts := TSVOptions()
+	opts.Header = false
+	out.Reset()
+	if err := view.WriteCSV(&out, opts); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if want := "1\tAcme\t7\tfalse\t\n2\t\"Say \"\"hi\"\"\"\t100.25\ttrue\t2024-01-15\n3\tSmith, Jane\t12.5\ttrue\t2024-03-01\n"; out.String() != want {
+		t.Errorf("Expected the sorted view, got:\n%s", out.String())
+	}
+
+	opts.AllRows = true
+	out.Reset()
+	if err := table.WriteCSV(&out, opts); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "3\t") {
+		t.Errorf("Expected rows in their original order, got:\n%s", out.String())
+	}
+}
+
+func TestWriteCSVFormatted(t *testing.T) {
+	table := NewWithColumns([]Column{
+		*NewColumn("name", "Name"),
+		*NewColumn("price", "Price").WithType(Float).WithFormatter(CurrencyFormatter),
+		*NewColumn("note", "Note").WithNullText("-"),
+	})
+	table.AddRow("Widget", 9.5, nil)
+
+	var out strings.Builder
+	opts := CSVOptions{Header: true, Formatted: true, QuoteAll: true}
+	if err := table.WriteCSV(&out, opts); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	want := "\"Name\",\"Price\",\"Note\"\n\"Widget\",\"$9.50\",\"-\"\n"
+	if out.String() != want {
+		t.Errorf("Expected formatted output:\n%s\ngot:\n%s", want, out.String())
+	}
+}
+
+func TestWriteCSVSource(t *testing.T) {
+	table := newSourceTable(t)
+	view := table.Filter("Engineering")
+
+	var out strings.Builder
+	if err := view.WriteCSV(&out, CSVOptions{}); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 9 {
+		t.Errorf("Expected the 9 filtered rows from the source, got %d", lines)
+	}
+}
@@ -3,6 +3,7 @@ package table
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,6 +21,24 @@ const (
 	Boolean
 )
 
+// String returns the name of the data type
+func (d DataType) String() string {
+	switch d {
+	case String:
+		return "String"
+	case Integer:
+		return "Integer"
+	case Float:
+		return "Float"
+	case Date:
+		return "Date"
+	case Boolean:
+		return "Boolean"
+	default:
+		return fmt.Sprintf("DataType(%d)", int(d))
+	}
+}
+
 // Formatter is a function that formats a value for display
 type Formatter func(value interface{}) string
 
@@ -35,11 +54,23 @@ type Column struct {
 	Header     string
 	Type       DataType
 	Width      int
+	MinWidth   int     // Minimum render width (0 for no minimum)
+	MaxWidth   int     // Maximum render width (0 for no maximum)
+	Weight     float64 // Relative share of space for proportional widths (0 counts as 1)
+	Flexible   bool    // Receives leftover space in fill mode
+	Align      Alignment
+	Wrap       bool   // Word-wrap long text over several lines instead of truncating it
+	MaxLines   int    // Maximum lines of a wrapped cell (0 for no limit)
+	NullText   string // Shown for nil (SQL NULL) values instead of formatting them
 	Sortable   bool
 	Searchable bool
 	Formatter  Formatter
 	Renderer   CellRenderer
 	Accessor   Accessor
+	Expr       string // Expression computing the cells from the row's other columns (see ParseExpr)
+
+	Aggregate   AggFunc // Footer aggregate (nil for DefaultAggFunc of the column's Type)
+	NoAggregate bool    // Leave the footer cell empty instead of using the default aggregate
 }
 
 // NewColumn creates a new column with the given key and header
@@ -69,6 +100,59 @@ func (c *Column) WithWidth(width int) *Column {
 	return c
 }
 
+// WithMinWidth sets the minimum render width
+func (c *Column) WithMinWidth(width int) *Column {
+	c.MinWidth = width
+	return c
+}
+
+// WithMaxWidth sets the maximum render width
+func (c *Column) WithMaxWidth(width int) *Column {
+	c.MaxWidth = width
+	return c
+}
+
+// WithWeight sets the column's relative share of space for proportional widths
+func (c *Column) WithWeight(weight float64) *Column {
+	c.Weight = weight
+	return c
+}
+
+// WithFlexible sets whether the column receives leftover space in fill mode
+func (c *Column) WithFlexible(flexible bool) *Column {
+	c.Flexible = flexible
+	return c
+}
+
+// WithWrap word-wraps the column's text over at most maxLines lines
+// (0 for no limit) instead of truncating it
+func (c *Column) WithWrap(maxLines int) *Column {
+	c.Wrap = true
+	c.MaxLines = maxLines
+	return c
+}
+
+// WithNullText sets the text shown for nil (SQL NULL) values
+func (c *Column) WithNullText(text string) *Column {
+	c.NullText = text
+	return c
+}
+
+// Format formats a value for display with the column's Formatter, showing
+// NullText for nil values and CellErrorText for failed computed cells
+func (c Column) Format(value interface{}) string {
+	if value == nil {
+		return c.NullText
+	}
+	if _, ok := value.(*CellError); ok {
+		return CellErrorText
+	}
+	if c.Formatter == nil {
+		return DefaultFormatter(value)
+	}
+	return c.Formatter(value)
+}
+
 // WithSortable sets whether the column is sortable
 func (c *Column) WithSortable(sortable bool) *Column {
 	c.Sortable = sortable
@@ -105,23 +189,64 @@ type Cell struct {
 	Type  DataType
 }
 
+// IsNull reports whether the cell has no value, as for SQL NULL
+func (c Cell) IsNull() bool {
+	return c.Value == nil
+}
+
 // Row represents a table row
 type Row struct {
 	ID    int
 	Cells []Cell
 	Data  interface{} // Original data for custom accessors
+	Group *Group      // Group this row heads in a GroupView (nil for data rows)
+}
+
+// SortKey is a single (column, direction) entry in a multi-column sort spec
+type SortKey struct {
+	Column int  // Column index to sort by
+	Desc   bool // Sort direction (true for descending)
 }
 
 // Table represents the complete table data structure
 type Table struct {
 	Columns       []Column
-	Rows          []Row
-	UnsortedOrder []Row // Store original row order for unsort functionality
-	SortBy        int   // Column index for sorting (-1 if not sorted)
-	SortDesc      bool  // Sort direction (true for descending)
+	Rows          []Row     // Rows in sorted order (nil for a filtered view; see CurrentRows)
+	UnsortedOrder []Row     // Store original row order for unsort functionality (nil for a filtered view)
+	SortBy        int       // Column index of the primary sort key (-1 if not sorted)
+	SortDesc      bool      // Direction of the primary sort key (true for descending)
+	SortKeys      []SortKey // Ordered sort keys; the first entry is the primary key
 	PageSize      int
+	PageBreaks    []int // Start row of each page when pages vary in size (nil pages by PageSize; dropped when rows change or are sorted)
 	TotalRows     int
-	originalData  []interface{} // Store original data for re-processing
+	Footer        FooterScope    // Rows aggregated in the footer row (NoFooter for none)
+	GroupColumns  []int          // Column indexes rows are grouped by, outermost first (see GroupBy)
+	ColumnOrder   ColumnOrder    // Order of columns inferred from maps
+	KeyOrder      []string       // Explicit order of columns inferred from maps
+	originalData  []interface{}  // Store original data for re-processing
+	cache         *columnCache   // Parsed and formatted cells, built on demand
+	footer        []interface{}  // Footer values, computed on demand (see FooterValues)
+	footerScope   FooterScope    // Footer the footer values were computed for
+	exprs         []*Expr        // Compiled Expr of each column (see compiledExprs)
+	parent        *Table         // Table a filtered view was narrowed from
+	ungrouped     *Table         // Table a group view lists the groups of
+	matches       func(Row) bool // Rows a filtered view keeps (see ApplyMutation)
+	order         []int32        // Rows of a filtered view, as positions in parent.UnsortedOrder
+	original      []int32        // Original order of a filtered view's rows, as positions
+	refilter      func() *Table  // Filters a view again from the table it was made from (see sync)
+	version       int            // Count of row changes; for a view, the count it reflects
+	positions     *rowPositions  // Position of each row ID in UnsortedOrder, built on demand
+
+	// Row mutations (see UpdateRow and Subscribe)
+	nextID       int // ID of the next new row (0 until first needed)
+	listeners    []mutationListener
+	nextListener int
+
+	// Lazy paging through a DataSource (see SetSource)
+	source        DataSource
+	sourceFilter  string
+	sourcePages   map[int]PageResult
+	sourceCounted bool
 }
 
 // New creates a new empty table
@@ -132,6 +257,7 @@ func New() *Table {
 		UnsortedOrder: make([]Row, 0),
 		SortBy:        -1,
 		SortDesc:      false,
+		SortKeys:      nil,
 		PageSize:      10,
 		TotalRows:     0,
 		originalData:  make([]interface{}, 0),
@@ -171,56 +297,70 @@ func (t *Table) SetData(data interface{}) error {
 	}
 
 	// Clear existing data
-	t.Rows = make([]Row, 0)
-	t.UnsortedOrder = make([]Row, 0)
+	t.Rows = make([]Row, 0, v.Len())
+	t.UnsortedOrder = make([]Row, 0, v.Len())
+	t.PageBreaks = nil
 	t.TotalRows = 0
-	t.originalData = make([]interface{}, 0)
-
-	// If no columns are defined, try to infer them from the data
+	t.nextID = 0
+	t.originalData = make([]interface{}, 0, v.Len())
+	t.cache = nil
+	t.footer = nil
+	t.positions = nil
+	t.version++
+	t.source = nil
+	t.sourcePages = nil
+
+	// If no columns are defined, try to infer them from the data: from the
+	// keys of every map, or from the first struct's fields
 	if len(t.Columns) == 0 && v.Len() > 0 {
-		firstItem := v.Index(0).Interface()
-		columns, err := t.inferColumnsFromStruct(firstItem)
+		var columns []Column
+		var err error
+		if indirect(v.Index(0)).Kind() == reflect.Map {
+			maps := make([]reflect.Value, v.Len())
+			for i := range maps {
+				maps[i] = indirect(v.Index(i))
+			}
+			columns, err = t.inferColumnsFromMaps(maps)
+		} else {
+			columns, err = t.inferColumnsFromStruct(v.Index(0).Interface())
+		}
 		if err != nil {
 			return err
 		}
 		t.Columns = columns
 	}
 
+	exprs, err := t.compiledExprs()
+	if err != nil {
+		return err
+	}
+
 	// Process each item in the slice
 	for i := 0; i < v.Len(); i++ {
 		item := v.Index(i).Interface()
 		t.originalData = append(t.originalData, item)
 
-		t.addRowFromData(item, i)
+		t.addRowFromData(item, i, exprs)
 	}
 
 	return nil
 }
 
-// addRowFromData adds a row from arbitrary data
-func (t *Table) addRowFromData(data interface{}, id int) {
+// addRowFromData adds a row from arbitrary data, computing the cells of
+// columns with an expression
+func (t *Table) addRowFromData(data interface{}, id int, exprs []*Expr) {
 	cells := make([]Cell, len(t.Columns))
 
 	for i, col := range t.Columns {
-		var value interface{}
-		var err error
-
-		if col.Accessor != nil {
-			// Use custom accessor
-			value = col.Accessor(data)
-		} else {
-			// Try to extract value based on column key
-			value, err = t.extractValueFromData(data, col.Key)
-			if err != nil {
-				value = ""
-			}
+		if col.Expr != "" {
+			continue
 		}
-
 		cells[i] = Cell{
-			Value: value,
+			Value: t.valueFromData(data, col),
 			Type:  col.Type,
 		}
 	}
+	t.evalExprs(cells, exprs)
 
 	row := Row{
 		ID:    id,
@@ -233,27 +373,67 @@ func (t *Table) addRowFromData(data interface{}, id int) {
 	t.TotalRows++
 }
 
-// extractValueFromData extracts a value from data using reflection
+// valueFromData returns a column's value for a data row, from its Accessor
+// or its key
+func (t *Table) valueFromData(data interface{}, col Column) interface{} {
+	if col.Accessor != nil {
+		// Use custom accessor
+		return col.Accessor(data)
+	}
+
+	// Try to extract value based on column key
+	value, err := t.extractValueFromData(data, col.Key)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// extractValueFromData extracts a value from data using reflection. Keys
+// that name no field or map entry directly are tried as dotted paths
+// through nested structs and maps, so "user.address.city" reads
+// data.User.Address.City or data["user"]["address"]["city"].
 func (t *Table) extractValueFromData(data interface{}, key string) (interface{}, error) {
 	v := reflect.ValueOf(data)
 
+	field, err := extractField(v, key)
+	if err != nil && strings.Contains(key, ".") {
+		field = v
+		for _, part := range strings.Split(key, ".") {
+			if field, err = extractField(field, part); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !field.CanInterface() {
+		return nil, fmt.Errorf("field %s is unexported", key)
+	}
+	return field.Interface(), nil
+}
+
+// extractField looks up a map key or struct field by name
+func extractField(v reflect.Value, key string) (reflect.Value, error) {
+	v = indirect(v)
+
 	// Handle maps
 	if v.Kind() == reflect.Map {
-		mapValue := v.MapIndex(reflect.ValueOf(key))
-		if mapValue.IsValid() {
-			return mapValue.Interface(), nil
+		if v.Type().Key().Kind() == reflect.String {
+			mapValue := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+			if mapValue.IsValid() {
+				return mapValue, nil
+			}
 		}
-		return nil, fmt.Errorf("key %s not found in map", key)
+		return reflect.Value{}, fmt.Errorf("key %s not found in map", key)
 	}
 
 	// Handle structs
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
 	if v.Kind() == reflect.Struct {
 		field := v.FieldByName(key)
 		if field.IsValid() {
-			return field.Interface(), nil
+			return field, nil
 		}
 
 		// Try case-insensitive search
@@ -261,14 +441,17 @@ func (t *Table) extractValueFromData(data interface{}, key string) (interface{},
 		for i := 0; i < t.NumField(); i++ {
 			fieldType := t.Field(i)
 			if strings.EqualFold(fieldType.Name, key) {
-				return v.Field(i).Interface(), nil
+				return v.Field(i), nil
 			}
 		}
 
-		return nil, fmt.Errorf("field %s not found in struct", key)
+		return reflect.Value{}, fmt.Errorf("field %s not found in struct", key)
 	}
 
-	return nil, fmt.Errorf("cannot extract value from type %T", data)
+	if !v.IsValid() {
+		return reflect.Value{}, fmt.Errorf("cannot extract %s from nil", key)
+	}
+	return reflect.Value{}, fmt.Errorf("cannot extract value from type %s", v.Type())
 }
 
 // inferColumnsFromStruct infers columns from a struct using reflection and struct tags
@@ -279,7 +462,7 @@ func (t *Table) inferColumnsFromStruct(data interface{}) ([]Column, error) {
 	}
 
 	if v.Kind() == reflect.Map {
-		return t.inferColumnsFromMap(data)
+		return t.inferColumnsFromMaps([]reflect.Value{v})
 	}
 
 	if v.Kind() != reflect.Struct {
@@ -313,37 +496,11 @@ func (t *Table) inferColumnsFromStruct(data interface{}) ([]Column, error) {
 		// Infer type from Go type
 		col.Type = t.inferDataType(field.Type)
 
-		// Set default width based on type
-		col.Width = t.getDefaultWidth(col.Type)
-
-		columns = append(columns, col)
-	}
-
-	return columns, nil
-}
-
-// inferColumnsFromMap infers columns from a map
-func (t *Table) inferColumnsFromMap(data interface{}) ([]Column, error) {
-	v := reflect.ValueOf(data)
-	if v.Kind() != reflect.Map {
-		return nil, fmt.Errorf("expected map, got %T", data)
-	}
-
-	var columns []Column
-	for _, key := range v.MapKeys() {
-		keyStr := fmt.Sprintf("%v", key.Interface())
-		value := v.MapIndex(key)
-
-		col := Column{
-			Key:        keyStr,
-			Header:     keyStr,
-			Sortable:   true,
-			Searchable: true,
-			Formatter:  DefaultFormatter,
-			Type:       t.inferDataType(value.Type()),
+		// Set default width based on type unless the tag set one
+		if col.Width == 0 {
+			col.Width = t.getDefaultWidth(col.Type)
 		}
 
-		col.Width = t.getDefaultWidth(col.Type)
 		columns = append(columns, col)
 	}
 
@@ -353,6 +510,12 @@ func (t *Table) inferColumnsFromMap(data interface{}) ([]Column, error) {
 // parseStructTag parses struct tag for column configuration
 func (t *Table) parseStructTag(col *Column, tag string) Column {
 	result := *col
+
+	// An expression may hold commas, so it takes the rest of the tag
+	if before, expr, ok := strings.Cut(tag, ",expr:"); ok {
+		tag = before
+		result.Expr = strings.TrimSpace(expr)
+	}
 	parts := strings.Split(tag, ",")
 
 	for i, part := range parts {
@@ -373,6 +536,32 @@ func (t *Table) parseStructTag(col *Column, tag string) Column {
 				if width, err := strconv.Atoi(widthStr); err == nil && width > 0 {
 					result.Width = width
 				}
+			case strings.HasPrefix(part, "minwidth:"):
+				if width, err := strconv.Atoi(strings.TrimPrefix(part, "minwidth:")); err == nil && width > 0 {
+					result.MinWidth = width
+				}
+			case strings.HasPrefix(part, "maxwidth:"):
+				if width, err := strconv.Atoi(strings.TrimPrefix(part, "maxwidth:")); err == nil && width > 0 {
+					result.MaxWidth = width
+				}
+			case strings.HasPrefix(part, "weight:"):
+				if weight, err := strconv.ParseFloat(strings.TrimPrefix(part, "weight:"), 64); err == nil && weight > 0 {
+					result.Weight = weight
+				}
+			case strings.HasPrefix(part, "align:"):
+				if align, ok := parseAlignment(strings.TrimPrefix(part, "align:")); ok {
+					result.Align = align
+				}
+			case strings.HasPrefix(part, "maxlines:"):
+				if lines, err := strconv.Atoi(strings.TrimPrefix(part, "maxlines:")); err == nil && lines > 0 {
+					result.Wrap = true
+					result.MaxLines = lines
+				}
+			case strings.HasPrefix(part, "agg:"):
+				if agg, ok := ParseAggFunc(strings.TrimPrefix(part, "agg:")); ok {
+					result.Aggregate = agg
+					result.NoAggregate = agg == nil
+				}
 			case strings.HasPrefix(part, "format:"):
 				// Parse formatter
 				formatStr := strings.TrimPrefix(part, "format:")
@@ -390,6 +579,10 @@ func (t *Table) parseStructTag(col *Column, tag string) Column {
 					result.Searchable = true
 				case "!searchable":
 					result.Searchable = false
+				case "flex":
+					result.Flexible = true
+				case "wrap":
+					result.Wrap = true
 				}
 			}
 		}
@@ -449,126 +642,331 @@ func (t *Table) getFormatterByName(name string) Formatter {
 	}
 }
 
-// AddRow adds a new row to the table with explicit values
+// AddRow adds a new row to the table with explicit values, at the end of
+// the original order and in sorted position if the table is sorted.
+// Computed columns (see Column.Expr) are evaluated from the other values;
+// give values for every column, ignoring those of computed columns, or only
+// for the columns that are not computed.
 func (t *Table) AddRow(values ...interface{}) error {
-	if len(values) != len(t.Columns) {
-		return fmt.Errorf("expected %d values, got %d", len(t.Columns), len(values))
+	return t.InsertRowAt(len(t.UnsortedOrder), values...)
+}
+
+// rowCells returns the cells for a row of explicit values, as AddRow takes them
+func (t *Table) rowCells(values []interface{}) ([]Cell, error) {
+	exprs, err := t.compiledExprs()
+	if err != nil {
+		return nil, err
+	}
+
+	computed := 0
+	for _, expr := range exprs {
+		if expr != nil {
+			computed++
+		}
+	}
+	if len(values) != len(t.Columns) && len(values) != len(t.Columns)-computed {
+		return nil, fmt.Errorf("expected %d values, got %d", len(t.Columns), len(values))
 	}
 
-	cells := make([]Cell, len(values))
-	for i, value := range values {
+	cells := make([]Cell, len(t.Columns))
+	next := 0
+	for i, col := range t.Columns {
+		if exprs[i] != nil && len(values) < len(t.Columns) {
+			continue
+		}
 		cells[i] = Cell{
-			Value: value,
-			Type:  t.Columns[i].Type,
+			Value: values[next],
+			Type:  col.Type,
 		}
+		next++
 	}
+	t.evalExprs(cells, exprs)
+	return cells, nil
+}
 
-	row := Row{
-		ID:    t.TotalRows,
-		Cells: cells,
-		Data:  values, // Store the raw values as data
+// AddColumn appends a column to the table and fills in its cells for the
+// rows already added: from each row's data like SetData, or by evaluating
+// the column's Expr
+func (t *Table) AddColumn(col Column) error {
+	if t.source != nil {
+		return fmt.Errorf("cannot add columns to a table backed by a data source")
+	}
+	if t.isView() {
+		return fmt.Errorf("cannot add columns to a view; add them to the table it was made from")
+	}
+	t.Columns = append(t.Columns, col)
+	exprs, err := t.compiledExprs()
+	if err != nil {
+		t.Columns = t.Columns[:len(t.Columns)-1]
+		return err
 	}
 
-	t.Rows = append(t.Rows, row)
-	t.UnsortedOrder = append(t.UnsortedOrder, row)
-	t.TotalRows++
+	colIndex := len(t.Columns) - 1
+	col = t.Columns[colIndex]
+	cellsByID := make(map[int][]Cell, len(t.UnsortedOrder))
+	for i, row := range t.UnsortedOrder {
+		cells := make([]Cell, len(t.Columns))
+		copy(cells, row.Cells)
+		if expr := exprs[colIndex]; expr != nil {
+			t.evalExpr(cells, colIndex, expr)
+		} else {
+			cells[colIndex] = Cell{Value: t.valueFromData(row.Data, col), Type: col.Type}
+		}
+		t.UnsortedOrder[i].Cells = cells
+		cellsByID[row.ID] = cells
+	}
+	for i, row := range t.Rows {
+		if cells, ok := cellsByID[row.ID]; ok {
+			t.Rows[i].Cells = cells
+		}
+	}
+	t.cache = nil
+	t.footer = nil
 	return nil
 }
 
 // GetPage returns a slice of rows for the given page number (0-indexed)
 func (t *Table) GetPage(pageNum int) []Row {
-	start := pageNum * t.PageSize
+	if t.source != nil {
+		return t.sourcePage(pageNum)
+	}
+
+	start := t.GetPageStart(pageNum)
 	end := start + t.PageSize
+	if t.PageBreaks != nil {
+		end = t.rowCount()
+		if pageNum+1 < len(t.PageBreaks) {
+			end = t.PageBreaks[pageNum+1]
+		}
+	}
 
-	if start >= len(t.Rows) {
+	if start >= t.rowCount() {
 		return []Row{}
 	}
 
-	if end > len(t.Rows) {
-		end = len(t.Rows)
-	}
+	return t.RowRange(start, end)
+}
 
-	return t.Rows[start:end]
+// GetPageStart returns the index of the first row on a page
+func (t *Table) GetPageStart(pageNum int) int {
+	if t.PageBreaks != nil {
+		if pageNum < 0 || pageNum >= len(t.PageBreaks) {
+			return t.rowCount()
+		}
+		return t.PageBreaks[pageNum]
+	}
+	return pageNum * t.PageSize
 }
 
 // GetTotalPages returns the total number of pages
 func (t *Table) GetTotalPages() int {
+	if len(t.PageBreaks) > 0 {
+		return len(t.PageBreaks)
+	}
 	if t.PageSize <= 0 {
 		return 1
 	}
-	if len(t.Rows) == 0 {
+	rows := t.rowCount()
+	if t.source != nil {
+		rows = t.TotalRows
+	}
+	if rows == 0 {
 		return 1
 	}
-	return (len(t.Rows) + t.PageSize - 1) / t.PageSize
+	return (rows + t.PageSize - 1) / t.PageSize
 }
 
 // SortByColumn sorts the table by the specified column
 func (t *Table) SortByColumn(columnIndex int, descending bool) error {
-	if columnIndex < 0 || columnIndex >= len(t.Columns) {
-		return fmt.Errorf("invalid column index: %d", columnIndex)
+	return t.SortByKeys(SortKey{Column: columnIndex, Desc: descending})
+}
+
+// SortByKeys sorts the table by an ordered list of sort keys. Later keys only
+// break ties left by earlier ones, and rows that tie on every key keep their
+// current relative order.
+func (t *Table) SortByKeys(keys ...SortKey) error {
+	if len(keys) == 0 {
+		t.ClearSort()
+		return nil
 	}
 
-	if !t.Columns[columnIndex].Sortable {
-		return fmt.Errorf("column %s is not sortable", t.Columns[columnIndex].Header)
+	for _, key := range keys {
+		if key.Column < 0 || key.Column >= len(t.Columns) {
+			return fmt.Errorf("invalid column index: %d", key.Column)
+		}
+		if !t.Columns[key.Column].Sortable {
+			return fmt.Errorf("column %s is not sortable", t.Columns[key.Column].Header)
+		}
+	}
+	if t.source != nil && !t.sourceCanSort() {
+		return fmt.Errorf("data source cannot sort")
 	}
 
-	t.SortBy = columnIndex
-	t.SortDesc = descending
+	t.SortKeys = append([]SortKey(nil), keys...)
+	t.SortBy = keys[0].Column
+	t.SortDesc = keys[0].Desc
 
-	sort.Slice(t.Rows, func(i, j int) bool {
-		cellI := t.Rows[i].Cells[columnIndex]
-		cellJ := t.Rows[j].Cells[columnIndex]
+	// Sources sort rows as they fetch them
+	if t.source != nil {
+		t.sourcePages = nil
+		return nil
+	}
+	if t.isView() {
+		t.sync()
+		t.sortView()
+		return nil
+	}
 
-		result := compareCells(cellI, cellJ)
-		if descending {
-			return result > 0
+	// Start from the original order so repeated sorts are deterministic
+	t.PageBreaks = nil
+	t.Rows = make([]Row, len(t.UnsortedOrder))
+	copy(t.Rows, t.UnsortedOrder)
+
+	// Compare pre-parsed keys when the table can cache them
+	if cache := t.columnCache(); cache != nil {
+		columns := make([]*sortKeyColumn, len(t.SortKeys))
+		for i, key := range t.SortKeys {
+			columns[i] = cache.sortKeyColumn(key.Column)
 		}
-		return result < 0
+		slices.SortStableFunc(t.Rows, func(a, b Row) int {
+			return compareSortKeys(a.ID, b.ID, columns, t.SortKeys)
+		})
+		return nil
+	}
+
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		return compareRows(t.Rows[i], t.Rows[j], t.SortKeys) < 0
 	})
 
 	return nil
 }
 
+// AddSortKey appends a secondary sort key to the current sort spec. If the
+// column is already part of the spec its direction is updated in place.
+func (t *Table) AddSortKey(columnIndex int, descending bool) error {
+	keys := append([]SortKey(nil), t.SortKeys...)
+	for i := range keys {
+		if keys[i].Column == columnIndex {
+			keys[i].Desc = descending
+			return t.SortByKeys(keys...)
+		}
+	}
+	return t.SortByKeys(append(keys, SortKey{Column: columnIndex, Desc: descending})...)
+}
+
+// RemoveSortKey removes a column from the current sort spec
+func (t *Table) RemoveSortKey(columnIndex int) error {
+	keys := make([]SortKey, 0, len(t.SortKeys))
+	for _, key := range t.SortKeys {
+		if key.Column != columnIndex {
+			keys = append(keys, key)
+		}
+	}
+	return t.SortByKeys(keys...)
+}
+
+// GetSortKey returns the sort key for a column and whether the column is sorted
+func (t *Table) GetSortKey(columnIndex int) (SortKey, bool) {
+	for _, key := range t.SortKeys {
+		if key.Column == columnIndex {
+			return key, true
+		}
+	}
+	return SortKey{}, false
+}
+
 // ClearSort clears any active sorting and restores original order
 func (t *Table) ClearSort() {
 	t.SortBy = -1
 	t.SortDesc = false
+	t.SortKeys = nil
+	t.sourcePages = nil
+	if t.isView() {
+		t.sync()
+		t.sortView()
+		return
+	}
 	// Restore original order
+	t.PageBreaks = nil
 	t.Rows = make([]Row, len(t.UnsortedOrder))
 	copy(t.Rows, t.UnsortedOrder)
 }
 
-// Filter returns a new table with rows matching the search term
+// Filter returns a new table with rows matching the search term. The term is
+// interpreted as a filter query (see ParseQuery); if it does not compile, it
+// falls back to a plain case-insensitive substring match.
 func (t *Table) Filter(searchTerm string) *Table {
-	if searchTerm == "" {
-		return t
+	filtered, err := t.FilterQuery(searchTerm)
+	if err != nil && t.source != nil {
+		return NewWithColumns(t.Columns)
+	}
+	if err != nil {
+		lowerTerm := strings.ToLower(searchTerm)
+		return t.filterRows(func(row Row) bool {
+			return t.rowMatchesSearch(row, lowerTerm)
+		})
 	}
+	return filtered
+}
 
+// FilterQuery returns a new table with rows matching a filter query, or an
+// error describing why the query could not be parsed or bound to the columns
+func (t *Table) FilterQuery(query string) (*Table, error) {
+	if strings.TrimSpace(query) == "" {
+		return t, nil
+	}
+	if t.source != nil {
+		return t.filterSource(query)
+	}
+
+	matches, err := t.CompileQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return t.filterRows(matches), nil
+}
+
+// filterRows returns a new table with the rows accepted by matches. The
+// new table is a view holding the positions of those rows rather than
+// copies of them (see CurrentRows).
+func (t *Table) filterRows(matches func(row Row) bool) *Table {
 	filtered := NewWithColumns(t.Columns)
+	filtered.Rows = nil
+	filtered.UnsortedOrder = nil
 	filtered.PageSize = t.PageSize
-	searchTerm = strings.ToLower(searchTerm)
-
-	for _, row := range t.Rows {
-		if t.rowMatchesSearch(row, searchTerm) {
-			filtered.Rows = append(filtered.Rows, row)
-			filtered.UnsortedOrder = append(filtered.UnsortedOrder, row)
-			filtered.TotalRows++
+	filtered.Footer = t.Footer
+	filtered.GroupColumns = slices.Clone(t.GroupColumns)
+	filtered.parent = t
+	filtered.matches = matches
+	if t.isView() {
+		// A view of a view keeps the rows both would keep
+		filtered.parent = t.parent
+		narrower := t.matches
+		filtered.matches = func(row Row) bool {
+			return narrower(row) && matches(row)
 		}
 	}
 
+	filtered.order, filtered.original = t.viewPositions(matches)
+	filtered.TotalRows = len(filtered.order)
+	filtered.version = filtered.parent.version
+	filtered.refilter = func() *Table {
+		return t.filterRows(matches)
+	}
+
 	// Preserve sort state from original table
 	filtered.SortBy = t.SortBy
 	filtered.SortDesc = t.SortDesc
+	filtered.SortKeys = append([]SortKey(nil), t.SortKeys...)
 
 	return filtered
 }
 
 // rowMatchesSearch checks if a row contains the search term in any searchable cell
 func (t *Table) rowMatchesSearch(row Row, searchTerm string) bool {
-	for i, cell := range row.Cells {
+	for i := range row.Cells {
 		if i < len(t.Columns) && t.Columns[i].Searchable {
-			cellStr := strings.ToLower(t.formatCellValue(cell, i))
-			if strings.Contains(cellStr, searchTerm) {
+			if strings.Contains(t.cellTextLower(row, i), searchTerm) {
 				return true
 			}
 		}
@@ -578,23 +976,22 @@ func (t *Table) rowMatchesSearch(row Row, searchTerm string) bool {
 
 // formatCellValue formats a cell value using the column's formatter
 func (t *Table) formatCellValue(cell Cell, columnIndex int) string {
-	if columnIndex < len(t.Columns) && t.Columns[columnIndex].Formatter != nil {
-		return t.Columns[columnIndex].Formatter(cell.Value)
+	if columnIndex < len(t.Columns) {
+		return t.Columns[columnIndex].Format(cell.Value)
 	}
 	return DefaultFormatter(cell.Value)
 }
 
 // GetCellValue returns the formatted value of a cell
 func (t *Table) GetCellValue(rowIndex, columnIndex int) string {
-	if rowIndex < 0 || rowIndex >= len(t.Rows) {
+	if rowIndex < 0 || rowIndex >= t.rowCount() {
 		return ""
 	}
 	if columnIndex < 0 || columnIndex >= len(t.Columns) {
 		return ""
 	}
 
-	cell := t.Rows[rowIndex].Cells[columnIndex]
-	return t.formatCellValue(cell, columnIndex)
+	return t.cellText(t.rowAt(rowIndex), columnIndex)
 }
 
 // GetColumnNames returns the headers of all columns
@@ -606,8 +1003,31 @@ func (t *Table) GetColumnNames() []string {
 	return names
 }
 
-// compareCells compares two cells for sorting purposes
+// compareRows compares two rows key by key, returning the first non-zero result
+func compareRows(a, b Row, keys []SortKey) int {
+	for _, key := range keys {
+		if key.Column >= len(a.Cells) || key.Column >= len(b.Cells) {
+			continue
+		}
+		result := compareCells(a.Cells[key.Column], b.Cells[key.Column])
+		if result == 0 {
+			continue
+		}
+		if key.Desc {
+			return -result
+		}
+		return result
+	}
+	return 0
+}
+
+// compareCells compares two cells for sorting purposes. Null cells sort
+// before all values.
 func compareCells(a, b Cell) int {
+	if a.IsNull() || b.IsNull() {
+		return compareNulls(a.IsNull(), b.IsNull())
+	}
+
 	switch a.Type {
 	case String:
 		aStr := fmt.Sprintf("%v", a.Value)
@@ -662,6 +1082,18 @@ func compareCells(a, b Cell) int {
 	}
 }
 
+// compareNulls orders cells by whether they are null, nulls first
+func compareNulls(aNull, bNull bool) int {
+	switch {
+	case aNull == bNull:
+		return 0
+	case aNull:
+		return -1
+	default:
+		return 1
+	}
+}
+
 // parseDate parses various date formats
 func parseDate(value interface{}) (time.Time, error) {
 	str := fmt.Sprintf("%v", value)
@@ -204,3 +204,82 @@ func BenchmarkFloatComparison(b *testing.B) {
 		compareCells(cell1, cell2)
 	}
 }
+
+// millionRows is built once and shared by the 1M-row benchmarks
+var millionRows *Table
+
+func millionRowTable(b *testing.B) *Table {
+	b.Helper()
+	if millionRows == nil {
+		millionRows = New()
+		millionRows.SetData(generateBenchData(1_000_000))
+	}
+	return millionRows
+}
+
+func BenchmarkSetDataMillion(b *testing.B) {
+	data := generateBenchData(1_000_000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		table := New()
+		table.SetData(data)
+	}
+}
+
+func BenchmarkSortMillion(b *testing.B) {
+	table := millionRowTable(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		table.SortByColumn(1, i%2 == 0) // Sort by name
+	}
+}
+
+func BenchmarkSortMillionMultiKey(b *testing.B) {
+	table := millionRowTable(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		table.SortByKeys(SortKey{Column: 2}, SortKey{Column: 3, Desc: true})
+	}
+}
+
+func BenchmarkFilterMillion(b *testing.B) {
+	table := millionRowTable(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		table.Filter("Employee_5")
+	}
+}
+
+func BenchmarkFilterQueryMillion(b *testing.B) {
+	table := millionRowTable(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		table.FilterQuery("department:sales salary>=75000")
+	}
+}
+
+func BenchmarkFilterThenSortMillion(b *testing.B) {
+	table := millionRowTable(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		filtered := table.Filter("Engineering")
+		filtered.SortByColumn(3, true)
+		filtered.GetPage(0)
+	}
+}
+
+func BenchmarkColdSortMillion(b *testing.B) {
+	table := millionRowTable(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		table.Invalidate() // Include building the cached sort keys
+		table.SortByColumn(1, false)
+	}
+}
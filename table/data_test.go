@@ -1,6 +1,7 @@
 package table
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -142,6 +143,53 @@ func TestSetDataWithMap(t *testing.T) {
 	}
 }
 
+func TestDottedColumnKeys(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type User struct {
+		Name    string
+		Address *Address
+	}
+	type Order struct {
+		ID   int
+		User User
+	}
+
+	table := NewWithColumns([]Column{
+		*NewColumn("user.name", "Name"),
+		*NewColumn("User.Address.City", "City"),
+	})
+	err := table.SetData([]Order{
+		{ID: 1, User: User{Name: "Ada", Address: &Address{City: "London"}}},
+		{ID: 2, User: User{Name: "Grace"}},
+	})
+	if err != nil {
+		t.Fatalf("SetData failed: %v", err)
+	}
+	if got := table.Rows[0].Cells; got[0].Value != "Ada" || got[1].Value != "London" {
+		t.Errorf("Expected struct paths resolved, got %#v", got)
+	}
+	if got := table.Rows[1].Cells[1].Value; got != "" {
+		t.Errorf("Expected a nil pointer on the path to give an empty value, got %#v", got)
+	}
+
+	// Nested maps, and keys that contain dots themselves
+	table = NewWithColumns([]Column{
+		*NewColumn("user.address.city", "City"),
+		*NewColumn("a.b", "A.B"),
+	})
+	err = table.SetData([]map[string]interface{}{
+		{"user": map[string]interface{}{"address": map[string]string{"city": "Paris"}}, "a.b": 1},
+	})
+	if err != nil {
+		t.Fatalf("SetData failed: %v", err)
+	}
+	if got := table.Rows[0].Cells; got[0].Value != "Paris" || got[1].Value != 1 {
+		t.Errorf("Expected map paths resolved, got %#v", got)
+	}
+}
+
 func TestSetDataInvalidInput(t *testing.T) {
 	table := New()
 
@@ -269,30 +317,30 @@ func TestFilter(t *testing.T) {
 		t.Fatal("Filter returned nil")
 	}
 
-	if len(filtered.Rows) != 1 {
-		t.Errorf("Expected 1 filtered row, got %d", len(filtered.Rows))
+	if len(filtered.CurrentRows()) != 1 {
+		t.Errorf("Expected 1 filtered row, got %d", len(filtered.CurrentRows()))
 	}
 
-	if filtered.Rows[0].Cells[1].Value != "Alice Johnson" {
-		t.Errorf("Expected filtered row to contain Alice Johnson, got %v", filtered.Rows[0].Cells[1].Value)
+	if filtered.CurrentRows()[0].Cells[1].Value != "Alice Johnson" {
+		t.Errorf("Expected filtered row to contain Alice Johnson, got %v", filtered.CurrentRows()[0].Cells[1].Value)
 	}
 
 	// Filter by department
 	filtered = table.Filter("Engineering")
-	if len(filtered.Rows) != 1 {
-		t.Errorf("Expected 1 filtered row for Engineering, got %d", len(filtered.Rows))
+	if len(filtered.CurrentRows()) != 1 {
+		t.Errorf("Expected 1 filtered row for Engineering, got %d", len(filtered.CurrentRows()))
 	}
 
 	// Filter with no matches
 	filtered = table.Filter("NonExistent")
-	if len(filtered.Rows) != 0 {
-		t.Errorf("Expected 0 filtered rows for non-existent term, got %d", len(filtered.Rows))
+	if len(filtered.CurrentRows()) != 0 {
+		t.Errorf("Expected 0 filtered rows for non-existent term, got %d", len(filtered.CurrentRows()))
 	}
 
 	// Case insensitive search
 	filtered = table.Filter("alice")
-	if len(filtered.Rows) != 1 {
-		t.Errorf("Expected 1 filtered row for case insensitive search, got %d", len(filtered.Rows))
+	if len(filtered.CurrentRows()) != 1 {
+		t.Errorf("Expected 1 filtered row for case insensitive search, got %d", len(filtered.CurrentRows()))
 	}
 }
 
@@ -341,6 +389,40 @@ func TestGetPage(t *testing.T) {
 	}
 }
 
+func TestPageBreaks(t *testing.T) {
+	employees := make([]Employee, 10)
+	for i := range employees {
+		employees[i] = Employee{ID: i + 1}
+	}
+
+	table := New().WithPageSize(5)
+	table.SetData(employees)
+	table.PageBreaks = []int{0, 2, 7}
+
+	if table.GetTotalPages() != 3 {
+		t.Errorf("Expected 3 pages, got %d", table.GetTotalPages())
+	}
+
+	expected := []struct{ start, rows int }{{0, 2}, {2, 5}, {7, 3}}
+	for page, want := range expected {
+		if start := table.GetPageStart(page); start != want.start {
+			t.Errorf("Page %d: expected start %d, got %d", page, want.start, start)
+		}
+		if rows := len(table.GetPage(page)); rows != want.rows {
+			t.Errorf("Page %d: expected %d rows, got %d", page, want.rows, rows)
+		}
+	}
+	if rows := len(table.GetPage(3)); rows != 0 {
+		t.Errorf("Expected 0 rows past the last page, got %d", rows)
+	}
+
+	// New data drops breaks computed for the old rows
+	table.SetData(employees)
+	if table.PageBreaks != nil || table.GetTotalPages() != 2 {
+		t.Errorf("Expected SetData to reset page breaks, got %v", table.PageBreaks)
+	}
+}
+
 func TestGetTotalPages(t *testing.T) {
 	table := New().WithPageSize(10)
 
@@ -455,6 +537,53 @@ func TestStructTagParsing(t *testing.T) {
 	}
 }
 
+func TestStructTagWidthConstraints(t *testing.T) {
+	type Layout struct {
+		ID    int    `table:"ID,width:4"`
+		Notes string `table:"Notes,minwidth:10,maxwidth:40,weight:2.5,flex"`
+		Other string
+	}
+
+	table := New()
+	table.SetData([]Layout{{1, "note", "x"}})
+
+	id, notes, other := table.Columns[0], table.Columns[1], table.Columns[2]
+	if id.Width != 4 {
+		t.Errorf("Expected tag width 4 to be kept, got %d", id.Width)
+	}
+	if notes.MinWidth != 10 || notes.MaxWidth != 40 {
+		t.Errorf("Expected width bounds 10..40, got %d..%d", notes.MinWidth, notes.MaxWidth)
+	}
+	if notes.Weight != 2.5 || !notes.Flexible {
+		t.Errorf("Expected weight 2.5 and flexible, got %v and %t", notes.Weight, notes.Flexible)
+	}
+	if other.Width != 15 || other.Flexible {
+		t.Errorf("Expected untagged column to use defaults, got width %d flexible %t", other.Width, other.Flexible)
+	}
+}
+
+func TestStructTagWrap(t *testing.T) {
+	type Note struct {
+		Title   string `table:"Title,wrap"`
+		Body    string `table:"Body,maxlines:3"`
+		Summary string
+	}
+
+	table := New()
+	table.SetData([]Note{{"a", "b", "c"}})
+
+	title, body, summary := table.Columns[0], table.Columns[1], table.Columns[2]
+	if !title.Wrap || title.MaxLines != 0 {
+		t.Errorf("Expected unlimited wrapping, got wrap %t max %d", title.Wrap, title.MaxLines)
+	}
+	if !body.Wrap || body.MaxLines != 3 {
+		t.Errorf("Expected wrapping over 3 lines, got wrap %t max %d", body.Wrap, body.MaxLines)
+	}
+	if summary.Wrap {
+		t.Error("Expected untagged column not to wrap")
+	}
+}
+
 func TestCompareCells(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -524,3 +653,159 @@ func TestBuilderPatternChaining(t *testing.T) {
 		t.Errorf("Expected 1 row, got %d", len(table.Rows))
 	}
 }
+
+func TestSortByKeys(t *testing.T) {
+	employees := []Employee{
+		{1, "Alice", "Sales", 55000.0, "2021-01-15", true},
+		{2, "Bob", "Engineering", 75000.0, "2020-03-20", true},
+		{3, "Carol", "Sales", 65000.0, "2019-11-10", false},
+		{4, "Dave", "Engineering", 75000.0, "2018-05-01", true},
+		{5, "Eve", "Sales", 55000.0, "2022-07-07", false},
+	}
+
+	table := New()
+	table.SetData(employees)
+
+	// Department ascending, then salary descending
+	err := table.SortByKeys(SortKey{Column: 2}, SortKey{Column: 3, Desc: true})
+	if err != nil {
+		t.Fatalf("SortByKeys failed: %v", err)
+	}
+
+	if table.SortBy != 2 || table.SortDesc {
+		t.Errorf("Expected primary key to be column 2 ascending, got %d (desc=%t)", table.SortBy, table.SortDesc)
+	}
+
+	if len(table.SortKeys) != 2 {
+		t.Fatalf("Expected 2 sort keys, got %d", len(table.SortKeys))
+	}
+
+	// Ties on both keys keep their original relative order (2 before 4, 1 before 5)
+	expectedIDs := []int{2, 4, 3, 1, 5}
+	for i, expectedID := range expectedIDs {
+		if table.Rows[i].Cells[0].Value != expectedID {
+			t.Errorf("Row %d: expected ID %d, got %v", i, expectedID, table.Rows[i].Cells[0].Value)
+		}
+	}
+
+	// Invalid keys are rejected
+	if err := table.SortByKeys(SortKey{Column: 42}); err == nil {
+		t.Error("Expected error for invalid column index")
+	}
+}
+
+func TestAddAndRemoveSortKey(t *testing.T) {
+	table := New()
+	table.SetData([]Employee{
+		{1, "Alice", "Sales", 55000.0, "2021-01-15", true},
+		{2, "Bob", "Engineering", 65000.0, "2020-03-20", true},
+		{3, "Carol", "Sales", 75000.0, "2019-11-10", false},
+	})
+
+	table.SortByColumn(2, false)
+	if err := table.AddSortKey(3, true); err != nil {
+		t.Fatalf("AddSortKey failed: %v", err)
+	}
+
+	expectedIDs := []int{2, 3, 1}
+	for i, expectedID := range expectedIDs {
+		if table.Rows[i].Cells[0].Value != expectedID {
+			t.Errorf("Row %d: expected ID %d, got %v", i, expectedID, table.Rows[i].Cells[0].Value)
+		}
+	}
+
+	// Adding an existing key updates its direction in place
+	table.AddSortKey(3, false)
+	if len(table.SortKeys) != 2 || table.SortKeys[1].Desc {
+		t.Errorf("Expected salary key to flip to ascending, got %+v", table.SortKeys)
+	}
+
+	// Removing the primary key promotes the secondary key
+	table.RemoveSortKey(2)
+	if table.SortBy != 3 {
+		t.Errorf("Expected SortBy to be 3 after removing primary key, got %d", table.SortBy)
+	}
+
+	// Removing the last key clears sorting
+	table.RemoveSortKey(3)
+	if table.SortBy != -1 || len(table.SortKeys) != 0 {
+		t.Errorf("Expected sort to be cleared, got SortBy=%d keys=%+v", table.SortBy, table.SortKeys)
+	}
+}
+
+func TestColumnFormatNull(t *testing.T) {
+	col := NewColumn("amount", "Amount").WithType(Float).WithFormatter(CurrencyFormatter)
+	if got := col.Format(nil); got != "" {
+		t.Errorf("Expected nil to format as empty text by default, got %q", got)
+	}
+
+	col.WithNullText("—")
+	if got := col.Format(nil); got != "—" {
+		t.Errorf("Expected nil to show the null text, got %q", got)
+	}
+	if got := col.Format(12.5); got != "$12.50" {
+		t.Errorf("Expected values to use the formatter, got %q", got)
+	}
+}
+
+func TestMapColumnOrder(t *testing.T) {
+	data := []map[string]interface{}{
+		{"name": "Alice", "id": 1, "zone": "west"},
+		{"name": "Bob", "id": 2, "email": "bob@example.com", "age": 41},
+		{"id": 3, "age": nil, "active": true},
+	}
+
+	tests := []struct {
+		name  string
+		table *Table
+		want  []string
+	}{
+		{"sorted", New(), []string{"active", "age", "email", "id", "name", "zone"}},
+		{"first seen", New().WithColumnOrder(FirstSeenColumns), []string{"id", "name", "zone", "age", "email", "active"}},
+		{"explicit", New().WithKeyOrder("name", "missing", "id"), []string{"name", "id", "active", "age", "email", "zone"}},
+		{"explicit then first seen", New().WithColumnOrder(FirstSeenColumns).WithKeyOrder("email"), []string{"email", "id", "name", "zone", "age", "active"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Map iteration is random, so the order must hold on every run
+			for run := 0; run < 20; run++ {
+				tt.table.Columns = nil
+				if err := tt.table.SetData(data); err != nil {
+					t.Fatalf("SetData failed: %v", err)
+				}
+				keys := make([]string, len(tt.table.Columns))
+				for i, col := range tt.table.Columns {
+					keys[i] = col.Key
+				}
+				if !slices.Equal(keys, tt.want) {
+					t.Fatalf("Run %d: expected columns %v, got %v", run, tt.want, keys)
+				}
+			}
+		})
+	}
+}
+
+func TestMapColumnUnion(t *testing.T) {
+	table := New()
+	table.SetData([]interface{}{
+		map[string]interface{}{"name": "Alice", "score": nil},
+		map[string]interface{}{"name": "Bob", "score": 9.5, "active": true},
+	})
+
+	types := map[string]DataType{}
+	for _, col := range table.Columns {
+		types[col.Key] = col.Type
+	}
+
+	// Keys only in later rows get columns, typed by their first non-nil value
+	if len(table.Columns) != 3 {
+		t.Fatalf("Expected 3 columns from the union of keys, got %d", len(table.Columns))
+	}
+	if types["score"] != Float || types["active"] != Boolean || types["name"] != String {
+		t.Errorf("Expected types from the first non-nil values, got %v", types)
+	}
+	if got := table.GetCellValue(1, 0); got != "true" {
+		t.Errorf("Expected the later row's value in the new column, got %q", got)
+	}
+}
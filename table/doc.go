@@ -16,6 +16,7 @@
 //   - Struct tag configuration for column metadata
 //   - Multi-type sorting with custom comparison functions
 //   - Case-insensitive filtering and search
+//   - Column-scoped filter queries with typed comparisons (status:done priority>=2)
 //   - Efficient pagination for large datasets
 //   - Built-in formatters for common data types
 //   - Custom formatters and renderers support
@@ -66,6 +67,12 @@
 //   - sortable/!sortable: Enable/disable sorting
 //   - searchable/!searchable: Enable/disable search filtering
 //   - width:N: Set column width in characters
+//   - minwidth:N/maxwidth:N: Bound the rendered column width
+//   - align:left|center|right|decimal: Align cell text (numbers default to right)
+//   - wrap: Word-wrap long text over several lines instead of truncating it
+//   - maxlines:N: Wrap over at most N lines
+//   - weight:N: Relative share of space for proportional widths
+//   - flex: Give the column leftover space in fill mode
 //   - format:type: Apply built-in formatters (currency, date, percent, etc.)
 //
 // # Formatters
@@ -84,8 +91,10 @@
 //
 // The table package is optimized for performance with large datasets:
 //   - Efficient pagination avoids loading all data into memory
-//   - Sorting uses optimized comparison functions for each data type
-//   - Filtering operates on paginated data for better responsiveness
+//   - Sorting compares cell values parsed once per column and cached by row ID
+//   - Filtering matches against formatted text cached the same way, and
+//     filtered tables share rows and cached values with their source
+//   - Call Invalidate after changing cells or formatters in place
 //   - Reflection-based operations are cached to minimize overhead
 //
 // # Thread Safety
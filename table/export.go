@@ -0,0 +1,269 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ExportOptions configures WriteMarkdown, WriteHTML, WriteText and WriteJSON
+type ExportOptions struct {
+	AllRows bool // Write every row in its original order instead of the current view
+}
+
+// exportGrid holds a table's display text ready for export
+type exportGrid struct {
+	headers []string
+	aligns  []Alignment
+	cells   [][]string
+}
+
+// exportText formats the rows to export with each column's Formatter,
+// without ANSI escape sequences
+func (t *Table) exportText(opts ExportOptions) (*exportGrid, error) {
+	rows, err := t.exportRows(opts.AllRows)
+	if err != nil {
+		return nil, err
+	}
+
+	grid := &exportGrid{
+		headers: make([]string, len(t.Columns)),
+		aligns:  make([]Alignment, len(t.Columns)),
+		cells:   make([][]string, len(rows)),
+	}
+	for i, col := range t.Columns {
+		grid.headers[i] = ansi.Strip(col.Header)
+		grid.aligns[i] = col.ResolvedAlign()
+	}
+	for rowIndex, row := range rows {
+		grid.cells[rowIndex] = make([]string, len(t.Columns))
+		for i, col := range t.Columns {
+			grid.cells[rowIndex][i] = ansi.Strip(exportValue(col, row, i, true))
+		}
+	}
+	return grid, nil
+}
+
+// widths returns the display width of each column's widest text
+func (g *exportGrid) widths(minWidth int) []int {
+	widths := make([]int, len(g.headers))
+	for i, header := range g.headers {
+		widths[i] = max(minWidth, DisplayWidth(header))
+	}
+	for _, row := range g.cells {
+		for i, text := range row {
+			widths[i] = max(widths[i], DisplayWidth(text))
+		}
+	}
+	return widths
+}
+
+// WriteMarkdown writes the table as a GitHub-flavoured Markdown table: the
+// current view unless opts.AllRows is set, with formatted values and each
+// column's alignment in the delimiter row. Decimal alignment becomes right
+// alignment.
+func (t *Table) WriteMarkdown(w io.Writer, opts ExportOptions) error {
+	grid, err := t.exportText(opts)
+	if err != nil {
+		return err
+	}
+
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	for _, row := range append([][]string{grid.headers}, grid.cells...) {
+		for i := range row {
+			row[i] = escape.Replace(row[i])
+		}
+	}
+
+	var b strings.Builder
+	widths := grid.widths(3)
+	writeRow := func(row []string) {
+		b.WriteString("|")
+		for i, text := range row {
+			align := grid.aligns[i]
+			if align == AlignDecimal {
+				align = AlignRight
+			}
+			b.WriteString(" " + PadText(text, widths[i], align) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(grid.headers)
+	b.WriteString("|")
+	for i, width := range widths {
+		b.WriteString(" " + markdownDelimiter(width, grid.aligns[i]) + " |")
+	}
+	b.WriteString("\n")
+	for _, row := range grid.cells {
+		writeRow(row)
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// markdownDelimiter returns a delimiter row cell marking an alignment
+func markdownDelimiter(width int, align Alignment) string {
+	switch align {
+	case AlignCenter:
+		return ":" + strings.Repeat("-", width-2) + ":"
+	case AlignRight, AlignDecimal:
+		return strings.Repeat("-", width-1) + ":"
+	default:
+		return strings.Repeat("-", width)
+	}
+}
+
+// WriteHTML writes the table as a standalone HTML table: the current view
+// unless opts.AllRows is set, with escaped formatted values and each
+// column's alignment as an inline style. Line breaks in cells become <br>.
+func (t *Table) WriteHTML(w io.Writer, opts ExportOptions) error {
+	grid, err := t.exportText(opts)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeCell := func(tag, text string, align Alignment) {
+		style := ""
+		switch align {
+		case AlignCenter:
+			style = ` style="text-align: center"`
+		case AlignRight, AlignDecimal:
+			style = ` style="text-align: right"`
+		}
+		text = strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+		fmt.Fprintf(&b, "      <%s%s>%s</%s>\n", tag, style, text, tag)
+	}
+
+	b.WriteString("<table>\n  <thead>\n    <tr>\n")
+	for i, header := range grid.headers {
+		writeCell("th", header, grid.aligns[i])
+	}
+	b.WriteString("    </tr>\n  </thead>\n  <tbody>\n")
+	for _, row := range grid.cells {
+		b.WriteString("    <tr>\n")
+		for i, text := range row {
+			writeCell("td", text, grid.aligns[i])
+		}
+		b.WriteString("    </tr>\n")
+	}
+	b.WriteString("  </tbody>\n</table>\n")
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// WriteText writes the table as plain monospace text without ANSI escape
+// sequences: the current view unless opts.AllRows is set, with formatted
+// values padded to each column's alignment, decimal points lined up in
+// AlignDecimal columns, and a dashed rule under the headers. Line breaks in
+// cells become spaces.
+func (t *Table) WriteText(w io.Writer, opts ExportOptions) error {
+	grid, err := t.exportText(opts)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range append([][]string{grid.headers}, grid.cells...) {
+		for i := range row {
+			row[i] = strings.Join(strings.Fields(row[i]), " ")
+		}
+	}
+	for i, align := range grid.aligns {
+		if align != AlignDecimal {
+			continue
+		}
+		values := make([]string, len(grid.cells))
+		for rowIndex, row := range grid.cells {
+			values[rowIndex] = row[i]
+		}
+		for rowIndex, aligned := range AlignDecimals(values) {
+			grid.cells[rowIndex][i] = aligned
+		}
+	}
+
+	var b strings.Builder
+	widths := grid.widths(1)
+	writeRow := func(row []string) {
+		line := make([]string, len(row))
+		for i, text := range row {
+			line[i] = PadText(text, widths[i], grid.aligns[i])
+		}
+		b.WriteString(strings.TrimRight(strings.Join(line, "  "), " ") + "\n")
+	}
+
+	writeRow(grid.headers)
+	rule := make([]string, len(widths))
+	for i, width := range widths {
+		rule[i] = strings.Repeat("-", width)
+	}
+	b.WriteString(strings.Join(rule, "  ") + "\n")
+	for _, row := range grid.cells {
+		writeRow(row)
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the table as a JSON array with one object per row, keyed
+// by column key in column order: the current view unless opts.AllRows is
+// set. Values are raw rather than formatted, so numbers and booleans keep
+// their JSON types; values JSON cannot represent are written as text.
+func (t *Table) WriteJSON(w io.Writer, opts ExportOptions) error {
+	rows, err := t.exportRows(opts.AllRows)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		keys[i] = JSONValue(col.Key)
+	}
+
+	var b strings.Builder
+	b.WriteString("[")
+	for rowIndex, row := range rows {
+		if rowIndex > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for i := range t.Columns {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			var value interface{}
+			if i < len(row.Cells) {
+				value = row.Cells[i].Value
+			}
+			b.WriteString(keys[i] + ": " + JSONValue(value))
+		}
+		b.WriteString("}")
+	}
+	if len(rows) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// JSONValue returns a value encoded as JSON without HTML escaping, or as a
+// JSON string of its raw text if it has no JSON encoding
+func JSONValue(value interface{}) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		b.Reset()
+		_ = enc.Encode(RawValue(value))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
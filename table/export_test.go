@@ -0,0 +1,120 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+// exportTestTable returns a table of products sorted by price
+func exportTestTable() *Table {
+	table := NewWithColumns([]Column{
+		*NewColumn("name", "Name"),
+		*NewColumn("price", "Price").WithType(Float).WithFormatter(CurrencyFormatter).WithAlign(AlignDecimal),
+		*NewColumn("stock", "Stock").WithType(Integer),
+		*NewColumn("note", "Note").WithAlign(AlignCenter).WithNullText("-"),
+	})
+	table.AddRow("Widget | XL", 1250.5, 3, "new\nline")
+	table.AddRow("Gadget", 5.0, 10, nil)
+	table.AddRow("Gizmo <b>", 9.99, 7, nil)
+	table.SortByColumn(1, false)
+	return table
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var out strings.Builder
+	if err := exportTestTable().Filter("i").WriteMarkdown(&out, ExportOptions{}); err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	want := `| Name         |    Price | Stock |    Note     |
+| ------------ | -------: | ----: | :---------: |
+| Gizmo <b>    |    $9.99 |     7 |      -      |
+| Widget \| XL | $1250.50 |     3 | new<br>line |
+`
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var out strings.Builder
+	if err := exportTestTable().WriteHTML(&out, ExportOptions{AllRows: true}); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+	html := out.String()
+
+	for _, want := range []string{
+		"<table>\n  <thead>\n    <tr>\n      <th>Name</th>\n",
+		`<th style="text-align: right">Price</th>`,
+		`<td style="text-align: center">new<br>line</td>`,
+		"<td>Gizmo &lt;b&gt;</td>",
+		"  </tbody>\n</table>\n",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", want, html)
+		}
+	}
+
+	// AllRows writes rows in their original order instead of sorted by price
+	if !(strings.Index(html, "Widget") < strings.Index(html, "Gadget") && strings.Index(html, "Gadget") < strings.Index(html, "Gizmo")) {
+		t.Errorf("Expected every row in original order, got:\n%s", html)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var out strings.Builder
+	if err := exportTestTable().Filter("i").WriteText(&out, ExportOptions{}); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	want := `Name            Price  Stock    Note
+-----------  --------  -----  --------
+Gizmo <b>       $9.99      7     -
+Widget | XL  $1250.50      3  new line
+`
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+	if strings.Contains(out.String(), "\x1b") {
+		t.Error("Expected no ANSI escape sequences")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out strings.Builder
+	if err := exportTestTable().Filter("i").WriteJSON(&out, ExportOptions{}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+
+	want := `[
+  {"name": "Gizmo <b>", "price": 9.99, "stock": 7, "note": null},
+  {"name": "Widget | XL", "price": 1250.5, "stock": 3, "note": "new\nline"}
+]
+`
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+
+	out.Reset()
+	if err := NewWithColumns(nil).WriteJSON(&out, ExportOptions{}); err != nil || out.String() != "[]\n" {
+		t.Errorf("Expected an empty array, got %q (%v)", out.String(), err)
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, "null"},
+		{"<a & b>", `"<a & b>"`},
+		{int64(3), "3"},
+		{complex(1, 2), `"(1+2i)"`}, // No JSON encoding, so written as text
+	}
+
+	for _, tt := range tests {
+		if got := JSONValue(tt.value); got != tt.want {
+			t.Errorf("JSONValue(%v) = %s, expected %s", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,1161 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CellErrorText is shown in place of a computed cell whose expression failed
+const CellErrorText = "#ERR"
+
+// ExprError describes a problem with a column expression and where it occurred
+type ExprError struct {
+	Pos int // Byte offset in the expression
+	Msg string
+}
+
+// Error implements the error interface
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("col %d: %s", e.Pos+1, e.Msg)
+}
+
+// CellError is the value of a computed cell whose expression failed for its
+// row, such as on a division by zero. Column.Format shows it as
+// CellErrorText, and Cell.Err returns it.
+type CellError struct {
+	Column string // Key of the computed column
+	Err    error
+}
+
+// Error implements the error interface
+func (e *CellError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Column, e.Err)
+}
+
+// Unwrap returns the evaluation error
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalText exports the error as its message
+func (e *CellError) MarshalText() ([]byte, error) {
+	return []byte(e.Error()), nil
+}
+
+// Err returns the error of a computed cell whose expression failed, or nil
+func (c Cell) Err() error {
+	if err, ok := c.Value.(*CellError); ok {
+		return err
+	}
+	return nil
+}
+
+// Expr is a column expression compiled against a table's columns
+type Expr struct {
+	Source string
+	Type   DataType // Type of the values the expression gives
+	eval   exprFunc
+}
+
+// exprFunc evaluates a compiled expression node against a row's cells
+type exprFunc func(cells []Cell) (interface{}, error)
+
+// Eval evaluates the expression against a row's cells. Nil values propagate
+// as in SQL, so the result is nil if a column the expression needs is empty.
+func (e *Expr) Eval(cells []Cell) (interface{}, error) {
+	return e.eval(cells)
+}
+
+// nullType is the type of the null literal, which fits any other type
+const nullType DataType = -1
+
+// exprNode is a node in a parsed expression
+type exprNode struct {
+	kind  exprKind
+	pos   int
+	text  string      // Operator, column or function name
+	value interface{} // Literal value
+	args  []*exprNode
+}
+
+// exprKind is the kind of an expression node
+type exprKind int
+
+const (
+	exprLiteral exprKind = iota
+	exprColumn
+	exprCall
+	exprUnary
+	exprBinary
+)
+
+// exprToken is a lexical token of an expression
+type exprToken struct {
+	kind  exprTokenKind
+	text  string
+	value interface{}
+	pos   int
+}
+
+// exprTokenKind is the kind of an expression token
+type exprTokenKind int
+
+const (
+	tokenEOF exprTokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOp
+)
+
+// exprOps lists operator spellings, longest first so "<=" wins over "<"
+var exprOps = []string{"==", "!=", "<>", "<=", ">=", "&&", "||", "+", "-", "*", "/", "%", "=", "<", ">", "!", "(", ")", ","}
+
+// tokenizeExpr splits an expression into tokens
+func tokenizeExpr(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(source) && source[i+1] >= '0' && source[i+1] <= '9':
+			start := i
+			for i < len(source) && (source[i] >= '0' && source[i] <= '9' || source[i] == '.') {
+				i++
+			}
+			text := source[start:i]
+			var value interface{}
+			if n, err := strconv.Atoi(text); err == nil {
+				value = n
+			} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+				value = f
+			} else {
+				return nil, &ExprError{Pos: start, Msg: fmt.Sprintf("invalid number %q", text)}
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: text, value: value, pos: start})
+
+		case c == '\'' || c == '"' || c == '`':
+			start := i
+			var text strings.Builder
+			for i++; i < len(source) && source[i] != c; i++ {
+				if source[i] == '\\' && i+1 < len(source) {
+					i++
+				}
+				text.WriteByte(source[i])
+			}
+			if i >= len(source) {
+				return nil, &ExprError{Pos: start, Msg: "unterminated quote"}
+			}
+			i++
+			kind := tokenString
+			if c == '`' {
+				kind = tokenIdent // `Unit Price` names a column with spaces
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: text.String(), pos: start})
+
+		case isIdentByte(c) && !(c >= '0' && c <= '9'):
+			start := i
+			for i < len(source) && isIdentByte(source[i]) {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: source[start:i], pos: start})
+
+		default:
+			op := ""
+			for _, candidate := range exprOps {
+				if strings.HasPrefix(source[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, &ExprError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", c)}
+			}
+			tokens = append(tokens, exprToken{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, exprToken{kind: tokenEOF, pos: len(source)}), nil
+}
+
+// isIdentByte reports whether a byte may appear in a column or function
+// name. Dots allow nested keys such as user.name.
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// exprParser is a recursive descent parser over expression tokens
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+// binaryLevels lists binary operators from the loosest binding to the
+// tightest; words are matched case-insensitively
+var binaryLevels = [][]string{
+	{"or", "||"},
+	{"and", "&&"},
+	{"=", "==", "!=", "<>", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// ParseExpr checks the syntax of a column expression.
+//
+// Expressions combine column names (by Key or Header, case-insensitively;
+// quote names with spaces in backticks), numbers, 'strings', true, false and
+// null with these operators, loosest first:
+//
+//	or ||   and &&   not !
+//	= == != <> < <= > >=
+//	+ -     (+ joins text if either side is a String)
+//	* / %   (/ always gives a Float)
+//	-x      (negation)
+//
+// and these functions: if(cond, then, else), coalesce(a, b, ...),
+// upper, lower, trim, len, concat(a, b, ...), contains(text, part), abs,
+// round(x) or round(x, digits), floor, ceil, min(a, b, ...), max(a, b, ...),
+// year, month, day and days(from, to).
+func ParseExpr(source string) error {
+	_, err := parseExpr(source)
+	return err
+}
+
+// parseExpr parses an expression into a tree
+func parseExpr(source string) (*exprNode, error) {
+	tokens, err := tokenizeExpr(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, &ExprError{Pos: 0, Msg: "empty expression"}
+	}
+	node, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.kind != tokenEOF {
+		return nil, &ExprError{Pos: token.pos, Msg: fmt.Sprintf("unexpected %q", token.text)}
+	}
+	return node, nil
+}
+
+// peek returns the current token
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+// next returns the current token and moves past it
+func (p *exprParser) next() exprToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+// isOp reports whether a token is one of the given operators or words
+func (token exprToken) isOp(ops ...string) bool {
+	if token.kind != tokenOp && token.kind != tokenIdent {
+		return false
+	}
+	for _, op := range ops {
+		if token.kind == tokenOp && token.text == op || token.kind == tokenIdent && strings.EqualFold(token.text, op) && isWordOp(op) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordOp reports whether an operator is spelled as a word
+func isWordOp(op string) bool {
+	return op == "and" || op == "or" || op == "not"
+}
+
+// parseBinary parses the binary operators of a level and every tighter level
+func (p *exprParser) parseBinary(level int) (*exprNode, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isOp(binaryLevels[level]...) {
+		op := p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprNode{kind: exprBinary, pos: op.pos, text: canonicalOp(op.text), args: []*exprNode{left, right}}
+	}
+	return left, nil
+}
+
+// canonicalOp returns the usual spelling of an operator
+func canonicalOp(op string) string {
+	switch strings.ToLower(op) {
+	case "or":
+		return "||"
+	case "and":
+		return "&&"
+	case "not":
+		return "!"
+	case "==":
+		return "="
+	case "<>":
+		return "!="
+	default:
+		return op
+	}
+}
+
+// parseUnary parses negation and logical not
+func (p *exprParser) parseUnary() (*exprNode, error) {
+	if token := p.peek(); token.isOp("-", "!", "not") {
+		p.next()
+		var operand *exprNode
+		var err error
+		if token.text == "-" {
+			operand, err = p.parseUnary()
+		} else {
+			// not binds looser than comparisons, so "not a = b" negates a = b
+			operand, err = p.parseBinary(2)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{kind: exprUnary, pos: token.pos, text: canonicalOp(token.text), args: []*exprNode{operand}}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses literals, columns, function calls and parentheses
+func (p *exprParser) parsePrimary() (*exprNode, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenNumber:
+		return &exprNode{kind: exprLiteral, pos: token.pos, value: token.value}, nil
+
+	case tokenString:
+		return &exprNode{kind: exprLiteral, pos: token.pos, value: token.text}, nil
+
+	case tokenIdent:
+		if p.peek().isOp("(") {
+			return p.parseCall(token)
+		}
+		switch strings.ToLower(token.text) {
+		case "true":
+			return &exprNode{kind: exprLiteral, pos: token.pos, value: true}, nil
+		case "false":
+			return &exprNode{kind: exprLiteral, pos: token.pos, value: false}, nil
+		case "null":
+			return &exprNode{kind: exprLiteral, pos: token.pos}, nil
+		}
+		return &exprNode{kind: exprColumn, pos: token.pos, text: token.text}, nil
+
+	case tokenOp:
+		if token.text == "(" {
+			node, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			if closing := p.next(); !closing.isOp(")") {
+				return nil, &ExprError{Pos: closing.pos, Msg: "missing ')'"}
+			}
+			return node, nil
+		}
+		return nil, &ExprError{Pos: token.pos, Msg: fmt.Sprintf("unexpected %q", token.text)}
+
+	default:
+		return nil, &ExprError{Pos: token.pos, Msg: "unexpected end of expression"}
+	}
+}
+
+// parseCall parses the arguments of a function call
+func (p *exprParser) parseCall(name exprToken) (*exprNode, error) {
+	p.next() // (
+	node := &exprNode{kind: exprCall, pos: name.pos, text: strings.ToLower(name.text)}
+	if p.peek().isOp(")") {
+		p.next()
+		return node, nil
+	}
+	for {
+		arg, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		node.args = append(node.args, arg)
+
+		token := p.next()
+		if token.isOp(")") {
+			return node, nil
+		}
+		if !token.isOp(",") {
+			return nil, &ExprError{Pos: token.pos, Msg: fmt.Sprintf("expected ',' or ')' in %s()", node.text)}
+		}
+	}
+}
+
+// CompileExpr parses an expression and binds it to this table's columns,
+// checking that every column exists and that operators and functions get
+// values of the DataTypes they accept. See ParseExpr for the syntax.
+func (t *Table) CompileExpr(source string) (*Expr, error) {
+	return t.compileExpr(source, len(t.Columns))
+}
+
+// compileExpr compiles an expression for the column at index self, which
+// may only read computed columns before it
+func (t *Table) compileExpr(source string, self int) (*Expr, error) {
+	node, err := parseExpr(source)
+	if err != nil {
+		return nil, err
+	}
+	c := &exprCompiler{table: t, self: self}
+	typ, eval, err := c.compile(node)
+	if err != nil {
+		return nil, err
+	}
+	if typ == nullType {
+		typ = String
+	}
+	return &Expr{Source: source, Type: typ, eval: eval}, nil
+}
+
+// exprCompiler type-checks expression nodes and turns them into closures
+type exprCompiler struct {
+	table *Table
+	self  int
+}
+
+// compile returns a node's result type and evaluator
+func (c *exprCompiler) compile(node *exprNode) (DataType, exprFunc, error) {
+	switch node.kind {
+	case exprLiteral:
+		value := node.value
+		return literalType(value), func([]Cell) (interface{}, error) { return value, nil }, nil
+
+	case exprColumn:
+		return c.compileColumn(node)
+
+	case exprUnary:
+		return c.compileUnary(node)
+
+	case exprBinary:
+		return c.compileBinary(node)
+
+	default:
+		return c.compileCall(node)
+	}
+}
+
+// literalType returns the type of a literal value
+func literalType(value interface{}) DataType {
+	switch value.(type) {
+	case nil:
+		return nullType
+	case int:
+		return Integer
+	case float64:
+		return Float
+	case bool:
+		return Boolean
+	default:
+		return String
+	}
+}
+
+// compileColumn binds a column reference, reading its cells as the
+// column's DataType
+func (c *exprCompiler) compileColumn(node *exprNode) (DataType, exprFunc, error) {
+	colIndex := c.table.findColumn(node.text)
+	if colIndex < 0 {
+		return 0, nil, &ExprError{Pos: node.pos, Msg: fmt.Sprintf("unknown column %q", node.text)}
+	}
+	col := c.table.Columns[colIndex]
+	if colIndex == c.self {
+		return 0, nil, &ExprError{Pos: node.pos, Msg: fmt.Sprintf("column %q refers to itself", node.text)}
+	}
+	if col.Expr != "" && colIndex > c.self {
+		return 0, nil, &ExprError{Pos: node.pos, Msg: fmt.Sprintf("column %q is computed after this one", node.text)}
+	}
+
+	return col.Type, func(cells []Cell) (interface{}, error) {
+		if colIndex >= len(cells) {
+			return nil, nil
+		}
+		return exprValue(cells[colIndex].Value, col)
+	}, nil
+}
+
+// exprValue converts a cell value to the Go type expressions use for the
+// column's DataType: int, float64, string, bool or time.Time
+func exprValue(value interface{}, col Column) (interface{}, error) {
+	if err, ok := value.(*CellError); ok {
+		return nil, err.Err
+	}
+	if s, ok := value.(string); value == nil || ok && s == "" && col.Type != String {
+		return nil, nil
+	}
+
+	var ok bool
+	switch col.Type {
+	case Integer:
+		var n int64
+		if n, ok = integerValue(value); ok {
+			return int(n), nil
+		}
+		var f float64
+		if f, ok = numericValue(value); ok && f == math.Trunc(f) {
+			return int(f), nil
+		}
+	case Float:
+		var f float64
+		if f, ok = numericValue(value); ok {
+			return f, nil
+		}
+	case Date:
+		var tm time.Time
+		if tm, ok = dateValue(value); ok {
+			return tm, nil
+		}
+	case Boolean:
+		var b bool
+		if b, ok = boolValue(value); ok {
+			return b, nil
+		}
+	default:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return DefaultFormatter(value), nil
+	}
+	return nil, fmt.Errorf("%s: %v is not a valid %s", col.Header, value, col.Type)
+}
+
+// compileUnary compiles negation and logical not
+func (c *exprCompiler) compileUnary(node *exprNode) (DataType, exprFunc, error) {
+	typ, operand, err := c.compile(node.args[0])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if node.text == "!" {
+		if err := c.expect(node.args[0], typ, "!", Boolean); err != nil {
+			return 0, nil, err
+		}
+		return Boolean, func(cells []Cell) (interface{}, error) {
+			b, err := evalBool(operand, cells)
+			return !b, err
+		}, nil
+	}
+
+	if err := c.expect(node.args[0], typ, "-", Integer, Float); err != nil {
+		return 0, nil, err
+	}
+	return typ, func(cells []Cell) (interface{}, error) {
+		value, err := operand(cells)
+		switch n := value.(type) {
+		case int:
+			return -n, err
+		case float64:
+			return -n, err
+		default:
+			return nil, err
+		}
+	}, nil
+}
+
+// expect checks that an operand has one of the allowed types; null fits any
+func (c *exprCompiler) expect(node *exprNode, typ DataType, what string, allowed ...DataType) error {
+	if typ == nullType {
+		return nil
+	}
+	for _, a := range allowed {
+		if typ == a {
+			return nil
+		}
+	}
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		names[i] = a.String()
+	}
+	return &ExprError{Pos: node.pos, Msg: fmt.Sprintf("%s expects %s, got %s", what, strings.Join(names, " or "), typ)}
+}
+
+// evalBool evaluates a Boolean operand, counting null as false
+func evalBool(eval exprFunc, cells []Cell) (bool, error) {
+	value, err := eval(cells)
+	b, _ := value.(bool)
+	return b, err
+}
+
+// isNumeric reports whether a type is Integer or Float
+func isNumeric(typ DataType) bool {
+	return typ == Integer || typ == Float
+}
+
+// compileBinary compiles a binary operator
+func (c *exprCompiler) compileBinary(node *exprNode) (DataType, exprFunc, error) {
+	leftType, left, err := c.compile(node.args[0])
+	if err != nil {
+		return 0, nil, err
+	}
+	rightType, right, err := c.compile(node.args[1])
+	if err != nil {
+		return 0, nil, err
+	}
+	op := node.text
+
+	switch op {
+	case "&&", "||":
+		if err := c.expect(node.args[0], leftType, op, Boolean); err != nil {
+			return 0, nil, err
+		}
+		if err := c.expect(node.args[1], rightType, op, Boolean); err != nil {
+			return 0, nil, err
+		}
+		return Boolean, func(cells []Cell) (interface{}, error) {
+			a, err := evalBool(left, cells)
+			if err != nil || a == (op == "||") {
+				return a, err
+			}
+			return evalBool(right, cells)
+		}, nil
+
+	case "=", "!=", "<", "<=", ">", ">=":
+		// Dates compare with date strings, as in due < '2024-06-01'
+		if leftType == Date && rightType == String {
+			rightType, right, err = c.dateLiteral(node.args[1])
+		} else if leftType == String && rightType == Date {
+			leftType, left, err = c.dateLiteral(node.args[0])
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if _, ok := unifyTypes(leftType, rightType); !ok {
+			return 0, nil, &ExprError{Pos: node.pos, Msg: fmt.Sprintf("cannot compare %s with %s", leftType, rightType)}
+		}
+		return Boolean, binaryFunc(left, right, func(a, b interface{}) (interface{}, error) {
+			return applyQueryOp(comparisonOps[op], compareExprValues(a, b)), nil
+		}), nil
+	}
+
+	resultType, err := c.arithmeticType(node, leftType, rightType)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resultType, binaryFunc(left, right, func(a, b interface{}) (interface{}, error) {
+		return arithmetic(op, a, b, resultType)
+	}), nil
+}
+
+// dateLiteral compiles a string literal compared with a date as a date
+func (c *exprCompiler) dateLiteral(node *exprNode) (DataType, exprFunc, error) {
+	text, ok := node.value.(string)
+	if node.kind != exprLiteral || !ok {
+		return 0, nil, &ExprError{Pos: node.pos, Msg: "cannot compare Date with String"}
+	}
+	date, err := parseDate(text)
+	if err != nil {
+		return 0, nil, &ExprError{Pos: node.pos, Msg: fmt.Sprintf("%q is not a valid Date", text)}
+	}
+	return Date, func([]Cell) (interface{}, error) { return date, nil }, nil
+}
+
+// comparisonOps maps expression comparisons to query operators
+var comparisonOps = map[string]QueryOp{
+	"=":  OpEqual,
+	"!=": OpNotEqual,
+	"<":  OpLess,
+	"<=": OpLessEqual,
+	">":  OpGreater,
+	">=": OpGreaterEqual,
+}
+
+// binaryFunc evaluates both operands and applies fn, giving nil if either
+// is nil
+func binaryFunc(left, right exprFunc, fn func(a, b interface{}) (interface{}, error)) exprFunc {
+	return func(cells []Cell) (interface{}, error) {
+		a, err := left(cells)
+		if err != nil {
+			return nil, err
+		}
+		b, err := right(cells)
+		if err != nil || a == nil || b == nil {
+			return nil, err
+		}
+		return fn(a, b)
+	}
+}
+
+// arithmeticType returns the result type of an arithmetic operator
+func (c *exprCompiler) arithmeticType(node *exprNode, left, right DataType) (DataType, error) {
+	op := node.text
+	switch {
+	case op == "+" && (left == String || right == String):
+		return String, nil
+	case left == Date && right == Integer && (op == "+" || op == "-"):
+		return Date, nil
+	case left == Date && right == Date && op == "-":
+		return Integer, nil
+	}
+
+	if err := c.expect(node.args[0], left, op, Integer, Float); err != nil {
+		return 0, err
+	}
+	if err := c.expect(node.args[1], right, op, Integer, Float); err != nil {
+		return 0, err
+	}
+	switch {
+	case op == "/" || left == Float || right == Float:
+		return Float, nil
+	default:
+		return Integer, nil
+	}
+}
+
+// arithmetic applies an arithmetic operator to non-nil operands
+func arithmetic(op string, a, b interface{}, resultType DataType) (interface{}, error) {
+	switch resultType {
+	case String:
+		return exprText(a) + exprText(b), nil
+
+	case Date:
+		days := b.(int)
+		if op == "-" {
+			days = -days
+		}
+		return a.(time.Time).AddDate(0, 0, days), nil
+
+	case Integer:
+		if tm, ok := a.(time.Time); ok {
+			return int(tm.Sub(b.(time.Time)).Hours() / 24), nil
+		}
+		x, y := a.(int), b.(int)
+		switch op {
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		default:
+			if y == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return x % y, nil
+		}
+
+	default:
+		x, _ := numericValue(a)
+		y, _ := numericValue(b)
+		switch op {
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		case "/":
+			if y == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		default:
+			if y == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return math.Mod(x, y), nil
+		}
+	}
+}
+
+// exprText formats a value for joining into text
+func exprText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format("2006-01-02")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// compareExprValues compares two non-nil values of unified types
+func compareExprValues(a, b interface{}) int {
+	switch x := a.(type) {
+	case string:
+		return strings.Compare(x, b.(string))
+	case time.Time:
+		return x.Compare(b.(time.Time))
+	case bool:
+		y := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case x:
+			return 1
+		default:
+			return -1
+		}
+	default:
+		x2, _ := numericValue(a)
+		y2, _ := numericValue(b)
+		return compareFloats(x2, y2)
+	}
+}
+
+// unifyTypes returns the type two values can share: their own if they
+// match, Float for mixed numbers, and the other type for null
+func unifyTypes(a, b DataType) (DataType, bool) {
+	switch {
+	case a == b:
+		return a, true
+	case a == nullType:
+		return b, true
+	case b == nullType:
+		return a, true
+	case isNumeric(a) && isNumeric(b):
+		return Float, true
+	default:
+		return 0, false
+	}
+}
+
+// convertExprValue converts a value to a unified type, widening integers
+// to floats
+func convertExprValue(value interface{}, typ DataType) interface{} {
+	if n, ok := value.(int); ok && typ == Float {
+		return float64(n)
+	}
+	return value
+}
+
+// exprFunction describes a built-in function's arguments and result
+type exprFunction struct {
+	minArgs, maxArgs int        // maxArgs < 0 for any number
+	args             []DataType // Accepted types of every argument (nil for any)
+	result           func(args []DataType) DataType
+	call             func(args []interface{}) (interface{}, error)
+}
+
+// sameType gives the type of the first argument
+func sameType(args []DataType) DataType {
+	return args[0]
+}
+
+// fixedType gives a fixed type whatever the arguments
+func fixedType(typ DataType) func([]DataType) DataType {
+	return func([]DataType) DataType { return typ }
+}
+
+// exprFunctions are the built-in functions, except if and coalesce, which
+// choose between lazily evaluated arguments. Calls get non-nil arguments.
+var exprFunctions = map[string]exprFunction{
+	"upper": {1, 1, []DataType{String}, sameType, func(args []interface{}) (interface{}, error) {
+		return strings.ToUpper(args[0].(string)), nil
+	}},
+	"lower": {1, 1, []DataType{String}, sameType, func(args []interface{}) (interface{}, error) {
+		return strings.ToLower(args[0].(string)), nil
+	}},
+	"trim": {1, 1, []DataType{String}, sameType, func(args []interface{}) (interface{}, error) {
+		return strings.TrimSpace(args[0].(string)), nil
+	}},
+	"len": {1, 1, []DataType{String}, fixedType(Integer), func(args []interface{}) (interface{}, error) {
+		return utf8.RuneCountInString(args[0].(string)), nil
+	}},
+	"contains": {2, 2, []DataType{String}, fixedType(Boolean), func(args []interface{}) (interface{}, error) {
+		return strings.Contains(strings.ToLower(args[0].(string)), strings.ToLower(args[1].(string))), nil
+	}},
+	"abs": {1, 1, []DataType{Integer, Float}, sameType, func(args []interface{}) (interface{}, error) {
+		if n, ok := args[0].(int); ok {
+			return max(n, -n), nil
+		}
+		return math.Abs(args[0].(float64)), nil
+	}},
+	"round": {1, 2, []DataType{Integer, Float}, func(args []DataType) DataType {
+		if len(args) == 2 {
+			return Float
+		}
+		return Integer
+	}, func(args []interface{}) (interface{}, error) {
+		x, _ := numericValue(args[0])
+		if len(args) == 1 {
+			return int(math.Round(x)), nil
+		}
+		scale := math.Pow(10, float64(exprInt(args[1])))
+		return math.Round(x*scale) / scale, nil
+	}},
+	"floor": {1, 1, []DataType{Integer, Float}, fixedType(Integer), func(args []interface{}) (interface{}, error) {
+		x, _ := numericValue(args[0])
+		return int(math.Floor(x)), nil
+	}},
+	"ceil": {1, 1, []DataType{Integer, Float}, fixedType(Integer), func(args []interface{}) (interface{}, error) {
+		x, _ := numericValue(args[0])
+		return int(math.Ceil(x)), nil
+	}},
+	"year": {1, 1, []DataType{Date}, fixedType(Integer), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Year(), nil
+	}},
+	"month": {1, 1, []DataType{Date}, fixedType(Integer), func(args []interface{}) (interface{}, error) {
+		return int(args[0].(time.Time).Month()), nil
+	}},
+	"day": {1, 1, []DataType{Date}, fixedType(Integer), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Day(), nil
+	}},
+	"days": {2, 2, []DataType{Date}, fixedType(Integer), func(args []interface{}) (interface{}, error) {
+		return int(args[1].(time.Time).Sub(args[0].(time.Time)).Hours() / 24), nil
+	}},
+}
+
+// exprInt returns an Integer or Float argument as an int
+func exprInt(value interface{}) int {
+	f, _ := numericValue(value)
+	return int(f)
+}
+
+// compileCall compiles a function call
+func (c *exprCompiler) compileCall(node *exprNode) (DataType, exprFunc, error) {
+	types := make([]DataType, len(node.args))
+	args := make([]exprFunc, len(node.args))
+	for i, arg := range node.args {
+		var err error
+		if types[i], args[i], err = c.compile(arg); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	argCount := func(minArgs, maxArgs int) error {
+		if len(args) >= minArgs && (maxArgs < 0 || len(args) <= maxArgs) {
+			return nil
+		}
+		want := strconv.Itoa(minArgs)
+		switch {
+		case maxArgs < 0:
+			want = "at least " + want
+		case maxArgs != minArgs:
+			want += " or " + strconv.Itoa(maxArgs)
+		}
+		return &ExprError{Pos: node.pos, Msg: fmt.Sprintf("%s() takes %s arguments, got %d", node.text, want, len(args))}
+	}
+
+	switch node.text {
+	case "if":
+		if err := argCount(3, 3); err != nil {
+			return 0, nil, err
+		}
+		if err := c.expect(node.args[0], types[0], "if() condition", Boolean); err != nil {
+			return 0, nil, err
+		}
+		typ, ok := unifyTypes(types[1], types[2])
+		if !ok {
+			return 0, nil, &ExprError{Pos: node.pos, Msg: fmt.Sprintf("if() branches give %s and %s", types[1], types[2])}
+		}
+		return typ, func(cells []Cell) (interface{}, error) {
+			cond, err := evalBool(args[0], cells)
+			if err != nil {
+				return nil, err
+			}
+			branch := args[2]
+			if cond {
+				branch = args[1]
+			}
+			value, err := branch(cells)
+			return convertExprValue(value, typ), err
+		}, nil
+
+	case "coalesce", "min", "max":
+		if err := argCount(1, -1); err != nil {
+			return 0, nil, err
+		}
+		typ := types[0]
+		for i, argType := range types[1:] {
+			var ok bool
+			if typ, ok = unifyTypes(typ, argType); !ok {
+				return 0, nil, &ExprError{Pos: node.args[i+1].pos, Msg: fmt.Sprintf("%s() arguments mix %s and %s", node.text, types[0], argType)}
+			}
+		}
+		if node.text == "coalesce" {
+			return typ, coalesceFunc(args, typ), nil
+		}
+		if err := c.expect(node, typ, node.text+"()", Integer, Float, String, Date); err != nil {
+			return 0, nil, err
+		}
+		return typ, extremeFunc(args, typ, node.text == "max"), nil
+
+	case "concat":
+		return String, func(cells []Cell) (interface{}, error) {
+			var b strings.Builder
+			for _, arg := range args {
+				value, err := arg(cells)
+				if err != nil {
+					return nil, err
+				}
+				b.WriteString(exprText(value))
+			}
+			return b.String(), nil
+		}, nil
+	}
+
+	fn, ok := exprFunctions[node.text]
+	if !ok {
+		return 0, nil, &ExprError{Pos: node.pos, Msg: fmt.Sprintf("unknown function %s()", node.text)}
+	}
+	if err := argCount(fn.minArgs, fn.maxArgs); err != nil {
+		return 0, nil, err
+	}
+	for i, arg := range node.args {
+		allowed := fn.args
+		if node.text == "round" && i == 1 {
+			allowed = []DataType{Integer}
+		}
+		if err := c.expect(arg, types[i], node.text+"()", allowed...); err != nil {
+			return 0, nil, err
+		}
+	}
+	resultType := fn.result(types)
+	if resultType == nullType {
+		resultType = fn.args[0] // upper(null) and the like
+	}
+	return resultType, func(cells []Cell) (interface{}, error) {
+		values := make([]interface{}, len(args))
+		for i, arg := range args {
+			value, err := arg(cells)
+			if err != nil || value == nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return fn.call(values)
+	}, nil
+}
+
+// coalesceFunc returns the first non-nil argument
+func coalesceFunc(args []exprFunc, typ DataType) exprFunc {
+	return func(cells []Cell) (interface{}, error) {
+		for _, arg := range args {
+			value, err := arg(cells)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				return convertExprValue(value, typ), nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+// extremeFunc returns the least or greatest non-nil argument
+func extremeFunc(args []exprFunc, typ DataType, greatest bool) exprFunc {
+	return func(cells []Cell) (interface{}, error) {
+		var best interface{}
+		for _, arg := range args {
+			value, err := arg(cells)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				continue
+			}
+			value = convertExprValue(value, typ)
+			if best == nil {
+				best = value
+				continue
+			}
+			if result := compareExprValues(value, best); greatest && result > 0 || !greatest && result < 0 {
+				best = value
+			}
+		}
+		return best, nil
+	}
+}
+
+// compiledExprs returns the compiled expression of each computed column (nil
+// for other columns), compiling them again only when an Expr has changed.
+// Each column's Type is set to its expression's result type.
+func (t *Table) compiledExprs() ([]*Expr, error) {
+	if len(t.exprs) == len(t.Columns) {
+		current := true
+		for i, col := range t.Columns {
+			if expr := t.exprs[i]; (expr == nil) != (col.Expr == "") || expr != nil && expr.Source != col.Expr {
+				current = false
+				break
+			}
+		}
+		if current {
+			return t.exprs, nil
+		}
+	}
+
+	exprs := make([]*Expr, len(t.Columns))
+	for i, col := range t.Columns {
+		if col.Expr == "" {
+			continue
+		}
+		expr, err := t.compileExpr(col.Expr, i)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", col.Key, err)
+		}
+		exprs[i] = expr
+		t.Columns[i].Type = expr.Type
+	}
+	t.exprs = exprs
+	return exprs, nil
+}
+
+// evalExprs fills in a row's computed cells, in column order so computed
+// columns can read those before them. Failures become CellError values.
+func (t *Table) evalExprs(cells []Cell, exprs []*Expr) {
+	for i, expr := range exprs {
+		if expr != nil {
+			t.evalExpr(cells, i, expr)
+		}
+	}
+}
+
+// evalExpr fills in one computed cell of a row
+func (t *Table) evalExpr(cells []Cell, colIndex int, expr *Expr) {
+	value, err := expr.Eval(cells)
+	if err != nil {
+		value = &CellError{Column: t.Columns[colIndex].Key, Err: err}
+	}
+	cells[colIndex] = Cell{Value: value, Type: expr.Type}
+}
+
+// WithExpr makes the column computed from the other columns of each row by
+// an expression such as "price * qty" (see ParseExpr). The column's Type is
+// set to the type the expression gives when rows are added.
+func (c *Column) WithExpr(expr string) *Column {
+	c.Expr = expr
+	return c
+}
+
+// ParseExprColumn parses a computed column from a "key=expression" spec, as
+// given on a command line, e.g. "total=price * qty". The key is also the
+// header. The expression's syntax is checked; its columns and types are
+// checked when the column is added to a table.
+func ParseExprColumn(spec string) (*Column, error) {
+	key, expr, ok := strings.Cut(spec, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return nil, fmt.Errorf("expected key=expression, got %q", spec)
+	}
+	if err := ParseExpr(expr); err != nil {
+		return nil, fmt.Errorf("column %s: %w", key, err)
+	}
+	return NewColumn(key, key).WithExpr(strings.TrimSpace(expr)), nil
+}
@@ -0,0 +1,226 @@
+package table
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// orderTable returns order lines with a computed total
+func orderTable() *Table {
+	return NewWithColumns([]Column{
+		*NewColumn("name", "Name"),
+		*NewColumn("price", "Price").WithType(Float),
+		*NewColumn("qty", "Qty").WithType(Integer),
+		*NewColumn("total", "Total").WithExpr("price * qty"),
+	})
+}
+
+func TestExprColumns(t *testing.T) {
+	table := orderTable()
+	if err := table.AddRow("apple", 0.5, 4); err != nil {
+		t.Fatalf("AddRow failed: %v", err)
+	}
+	if err := table.AddRow("pear", 2.0, nil, "ignored"); err != nil {
+		t.Fatalf("AddRow with every column failed: %v", err)
+	}
+
+	if table.Columns[3].Type != Float {
+		t.Errorf("Expected the total to be typed Float, got %v", table.Columns[3].Type)
+	}
+	if got := table.Rows[0].Cells[3].Value; got != 2.0 {
+		t.Errorf("Expected a total of 2.0, got %v", got)
+	}
+	if got := table.Rows[1].Cells[3].Value; got != nil {
+		t.Errorf("Expected a nil quantity to give a nil total, got %v", got)
+	}
+	if err := table.AddRow("plum"); err == nil {
+		t.Error("Expected an error for too few values")
+	}
+
+	// Computed columns sort and aggregate like any other
+	table.SortByColumn(3, true)
+	if table.Rows[0].Cells[0].Value != "apple" {
+		t.Error("Expected the highest total first")
+	}
+	if got := table.WithFooter(FooterView).FooterText()[3]; got != "2.00" {
+		t.Errorf("Expected a footer total of 2.00, got %q", got)
+	}
+}
+
+func TestExprEval(t *testing.T) {
+	table := NewWithColumns([]Column{
+		*NewColumn("name", "Name"),
+		*NewColumn("score", "Score").WithType(Integer),
+		*NewColumn("due", "Due date").WithType(Date),
+		*NewColumn("active", "Active").WithType(Boolean),
+	})
+	table.AddRow("ada", 95, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), true)
+	cells := table.Rows[0].Cells
+
+	tests := []struct {
+		expr string
+		want interface{}
+		typ  DataType
+	}{
+		{"upper(name)", "ADA", String},
+		{"if(score > 90, 'A', 'B')", "A", String},
+		{"IF(Score >= 96 OR NOT active, 1, 2.5)", 2.5, Float},
+		{"score % 10 + 1", 6, Integer},
+		{"score / 2", 47.5, Float},
+		{"-score + len(name) * 2", -89, Integer},
+		{"name + ' scored ' + score", "ada scored 95", String},
+		{"concat(name, '-', active)", "ada-true", String},
+		{"due > '2024-05-31' and due < '2024-06-02'", true, Boolean},
+		{"year(due + 31)", 2024, Integer},
+		{"month(due - 1)", 5, Integer},
+		{"days(due, due + 7)", 7, Integer},
+		{"round(score / 7, 2)", 13.57, Float},
+		{"max(score, 3.5, 100)", 100.0, Float},
+		{"coalesce(null, name)", "ada", String},
+		{"`Due date` = due", true, Boolean},
+		{"contains(name, 'D')", true, Boolean},
+		{"null + 1", nil, Integer},
+	}
+	for _, tt := range tests {
+		expr, err := table.CompileExpr(tt.expr)
+		if err != nil {
+			t.Errorf("%s: compile failed: %v", tt.expr, err)
+			continue
+		}
+		got, err := expr.Eval(cells)
+		if err != nil || got != tt.want || expr.Type != tt.typ {
+			t.Errorf("%s: expected %v (%v), got %v (%v, %v)", tt.expr, tt.want, tt.typ, got, expr.Type, err)
+		}
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	table := orderTable()
+
+	tests := []struct {
+		expr string
+		msg  string
+	}{
+		{"", "col 1: empty expression"},
+		{"price *", "col 8: unexpected end of expression"},
+		{"(price", "col 7: missing ')'"},
+		{"name = 'x", "col 8: unterminated quote"},
+		{"price # 2", "col 7: unexpected character '#'"},
+		{"cost * 2", `col 1: unknown column "cost"`},
+		{"upper(price)", "col 7: upper() expects String, got Float"},
+		{"price + true", "col 9: + expects Integer or Float, got Boolean"},
+		{"if(qty, 1, 2)", "col 4: if() condition expects Boolean, got Integer"},
+		{"if(qty > 1, 'a', 2)", "col 1: if() branches give String and Integer"},
+		{"name > 3", "col 6: cannot compare String with Integer"},
+		{"round()", "col 1: round() takes 1 or 2 arguments, got 0"},
+		{"sqrt(qty)", "col 1: unknown function sqrt()"},
+	}
+	for _, tt := range tests {
+		_, err := table.CompileExpr(tt.expr)
+		var exprErr *ExprError
+		if !errors.As(err, &exprErr) || err.Error() != tt.msg {
+			t.Errorf("%q: expected %q, got %v", tt.expr, tt.msg, err)
+		}
+	}
+}
+
+func TestExprCellErrors(t *testing.T) {
+	table := NewWithColumns([]Column{
+		*NewColumn("a", "A").WithType(Integer),
+		*NewColumn("b", "B").WithType(Integer),
+		*NewColumn("ratio", "Ratio").WithExpr("a % b"),
+		*NewColumn("double", "Double").WithExpr("ratio * 2"),
+	})
+	table.AddRow(7, 2)
+	table.AddRow(7, 0)
+	table.AddRow(7, "lots")
+
+	if got := table.Rows[0].Cells[3].Value; got != 2 {
+		t.Errorf("Expected a computed column to read an earlier one, got %v", got)
+	}
+
+	cell := table.Rows[1].Cells[2]
+	var cellErr *CellError
+	if !errors.As(cell.Err(), &cellErr) || cellErr.Column != "ratio" || cellErr.Err.Error() != "division by zero" {
+		t.Errorf("Expected a division by zero in the ratio cell, got %v", cell.Err())
+	}
+	if got := table.GetCellValue(1, 2); got != CellErrorText {
+		t.Errorf("Expected %s for a failed cell, got %q", CellErrorText, got)
+	}
+	if table.Rows[1].Cells[3].Err() == nil {
+		t.Error("Expected the error to carry into a column computed from the failed one")
+	}
+	if err := table.Rows[2].Cells[2].Err(); err == nil || !strings.Contains(err.Error(), "B: lots is not a valid Integer") {
+		t.Errorf("Expected a conversion error, got %v", err)
+	}
+	if table.Rows[0].Cells[2].Err() != nil {
+		t.Error("Expected no error for a computed value")
+	}
+
+	// Columns may only read computed columns before them
+	bad := NewWithColumns([]Column{
+		*NewColumn("x", "X").WithExpr("y + 1"),
+		*NewColumn("y", "Y").WithExpr("1"),
+	})
+	if err := bad.AddRow(); err == nil || !strings.Contains(err.Error(), "computed after this one") {
+		t.Errorf("Expected an ordering error, got %v", err)
+	}
+}
+
+func TestExprStructTag(t *testing.T) {
+	type Line struct {
+		Item  string
+		Price float64
+		Qty   int
+		Total struct{} `table:"Total,format:currency,expr:if(qty > 2, price * qty * 0.9, price * qty)"`
+	}
+
+	table := New()
+	if err := table.SetData([]Line{{Item: "pen", Price: 2, Qty: 5}, {Item: "ink", Price: 4, Qty: 1}}); err != nil {
+		t.Fatalf("SetData failed: %v", err)
+	}
+	if got := table.GetCellValue(0, 3); got != "$9.00" {
+		t.Errorf("Expected a discounted total of $9.00, got %q", got)
+	}
+	if got := table.GetCellValue(1, 3); got != "$4.00" {
+		t.Errorf("Expected a total of $4.00, got %q", got)
+	}
+
+	if err := New().WithColumns([]Column{*NewColumn("x", "X").WithExpr("nope(1)")}).SetData([]Line{{}}); err == nil {
+		t.Error("Expected SetData to report an invalid expression")
+	}
+}
+
+func TestAddExprColumn(t *testing.T) {
+	table := orderTable()
+	table.AddRow("apple", 0.5, 4)
+	table.AddRow("pear", 2.0, 3)
+	table.SortByColumn(0, true)
+
+	col, err := ParseExprColumn("label = upper(name) + ' x' + qty")
+	if err != nil {
+		t.Fatalf("ParseExprColumn failed: %v", err)
+	}
+	if err := table.AddColumn(*col); err != nil {
+		t.Fatalf("AddColumn failed: %v", err)
+	}
+	if got := table.GetCellValue(0, 4); got != "PEAR x3" {
+		t.Errorf("Expected the sorted rows to get the new column, got %q", got)
+	}
+	if got := table.UnsortedOrder[0].Cells[4].Value; got != "APPLE x4" {
+		t.Errorf("Expected the original order to get the new column, got %v", got)
+	}
+
+	if _, err := ParseExprColumn("price * 2"); err == nil {
+		t.Error("Expected an error for a spec without a key")
+	}
+	if _, err := ParseExprColumn("x = price *"); err == nil {
+		t.Error("Expected a syntax error")
+	}
+	bad, _ := ParseExprColumn("y = missing + 1")
+	if err := table.AddColumn(*bad); err == nil || len(table.Columns) != 5 {
+		t.Error("Expected an unknown column to be reported and the column left out")
+	}
+}
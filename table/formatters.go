@@ -165,17 +165,17 @@ func addCommas(s string) string {
 	return string(result)
 }
 
-// TruncateFormatter creates a formatter that truncates strings to a maximum length
+// TruncateFormatter creates a formatter that truncates strings to a maximum
+// display width, ending with DefaultEllipsis
 func TruncateFormatter(maxLength int) Formatter {
+	return TruncateFormatterWithEllipsis(maxLength, DefaultEllipsis)
+}
+
+// TruncateFormatterWithEllipsis creates a formatter that truncates strings to
+// a maximum display width, ending with the given ellipsis
+func TruncateFormatterWithEllipsis(maxLength int, ellipsis string) Formatter {
 	return func(value interface{}) string {
-		str := fmt.Sprintf("%v", value)
-		if len(str) <= maxLength {
-			return str
-		}
-		if maxLength <= 3 {
-			return str[:maxLength]
-		}
-		return str[:maxLength-3] + "..."
+		return TruncateText(fmt.Sprintf("%v", value), maxLength, ellipsis)
 	}
 }
 
@@ -249,6 +249,30 @@ func TestTruncateFormatter(t *testing.T) {
 	}
 }
 
+func TestTruncateFormatterWithEllipsis(t *testing.T) {
+	formatter := TruncateFormatterWithEllipsis(6, "…")
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{"short string", "hello", "hello"},
+		{"long string", "hello world", "hello…"},
+		{"cjk", "東京都渋谷区", "東京…"},
+		{"emoji", "🎉🎉🎉🎉", "🎉🎉…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatter(tt.input)
+			if result != tt.expected {
+				t.Errorf("TruncateFormatterWithEllipsis(6, \"…\")(%v) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestPrefixFormatter(t *testing.T) {
 	formatter := PrefixFormatter("👤 ")
 
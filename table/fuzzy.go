@@ -0,0 +1,170 @@
+package table
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Fuzzy scoring weights, modelled on fzf's v1 algorithm
+const (
+	fuzzyScoreMatch        = 16
+	fuzzyScoreGapStart     = -3
+	fuzzyScoreGapExtension = -1
+	fuzzyBonusBoundary     = 8
+	fuzzyBonusConsecutive  = 4
+	fuzzyBonusFirstChar    = 2 // Multiplier for the bonus of the first pattern char
+)
+
+// FuzzyMatch reports whether every rune of pattern appears in text in order,
+// ignoring case. It returns a score (higher is better) and the rune indexes
+// of the matched characters in text.
+//
+// Like fzf, it finds the first occurrence of the pattern as a subsequence and
+// then scans backwards from its end to pick the shortest match, rewarding
+// matches on word boundaries and runs of consecutive characters.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return 0, nil, true
+	}
+	textRunes := []rune(text)
+	lowerRunes := []rune(strings.ToLower(text))
+	if len(lowerRunes) != len(textRunes) {
+		// Lowercasing changed the rune count; fall back to per-rune folding
+		lowerRunes = make([]rune, len(textRunes))
+		for i, r := range textRunes {
+			lowerRunes[i] = unicode.ToLower(r)
+		}
+	}
+
+	// Forward pass: find where the first full subsequence match ends
+	pi := 0
+	end := -1
+	for i, r := range lowerRunes {
+		if r == patternRunes[pi] {
+			pi++
+			if pi == len(patternRunes) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward pass: shrink the match to the latest possible start
+	positions := make([]int, len(patternRunes))
+	pi = len(patternRunes) - 1
+	for i := end; i >= 0 && pi >= 0; i-- {
+		if lowerRunes[i] == patternRunes[pi] {
+			positions[pi] = i
+			pi--
+		}
+	}
+
+	return scoreFuzzyMatch(textRunes, positions), positions, true
+}
+
+// scoreFuzzyMatch scores a set of matched positions within text
+func scoreFuzzyMatch(text []rune, positions []int) int {
+	score := 0
+	chunkBonus := 0 // Bonus of the first character in the current consecutive run
+	for i, pos := range positions {
+		score += fuzzyScoreMatch
+
+		bonus := fuzzyBoundaryBonus(text, pos)
+		switch {
+		case i == 0:
+			chunkBonus = bonus
+			bonus *= fuzzyBonusFirstChar
+		case pos == positions[i-1]+1:
+			// Consecutive characters share the bonus of the run's first character
+			bonus = max(bonus, chunkBonus, fuzzyBonusConsecutive)
+		default:
+			gap := pos - positions[i-1] - 1
+			score += fuzzyScoreGapStart + fuzzyScoreGapExtension*(gap-1)
+			chunkBonus = bonus
+		}
+		score += bonus
+	}
+	return score
+}
+
+// fuzzyBoundaryBonus rewards matches at the start of a word or camelCase hump
+func fuzzyBoundaryBonus(text []rune, pos int) int {
+	if pos == 0 {
+		return fuzzyBonusBoundary
+	}
+	prev, cur := text[pos-1], text[pos]
+	switch {
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev):
+		return fuzzyBonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return fuzzyBonusBoundary - 1
+	default:
+		return 0
+	}
+}
+
+// FuzzyFilter returns a new table with rows whose searchable cells fuzzy-match
+// every whitespace-separated word of pattern, ordered by descending score.
+// Rows with equal scores keep the table's current order.
+func (t *Table) FuzzyFilter(pattern string) *Table {
+	words := strings.Fields(pattern)
+	if len(words) == 0 {
+		return t
+	}
+	if t.source != nil {
+		// Sources filter by query, so rows are narrowed but not ranked
+		return t.Filter(pattern)
+	}
+
+	scores := make(map[int]int)
+	filtered := t.filterRows(func(row Row) bool {
+		score, ok := t.fuzzyScoreRow(row, words)
+		if ok {
+			scores[row.ID] = score
+		}
+		return ok
+	})
+
+	base := filtered.parent.UnsortedOrder
+	slices.SortStableFunc(filtered.order, func(a, b int32) int {
+		return cmp.Compare(scores[base[b].ID], scores[base[a].ID])
+	})
+	filtered.original = slices.Clone(filtered.order)
+	filtered.refilter = func() *Table {
+		return t.FuzzyFilter(pattern)
+	}
+
+	// Rows are ranked by score rather than by the column sort spec
+	filtered.SortBy = -1
+	filtered.SortDesc = false
+	filtered.SortKeys = nil
+
+	return filtered
+}
+
+// fuzzyScoreRow sums the best score of each word across searchable cells
+func (t *Table) fuzzyScoreRow(row Row, words []string) (int, bool) {
+	total := 0
+	for _, word := range words {
+		best, found := 0, false
+		for i := range row.Cells {
+			if i >= len(t.Columns) || !t.Columns[i].Searchable {
+				continue
+			}
+			if score, _, ok := FuzzyMatch(word, t.cellText(row, i)); ok && (!found || score > best) {
+				best, found = score, true
+			}
+		}
+		if !found {
+			return 0, false
+		}
+		total += best
+	}
+	return total, true
+}
@@ -0,0 +1,90 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		text      string
+		matched   bool
+		positions []int
+	}{
+		{"abc", "abc", true, []int{0, 1, 2}},
+		{"ABC", "a_b_c", true, []int{0, 2, 4}},
+		{"fb", "foo bar", true, []int{0, 4}},
+		{"oba", "foo bar", true, []int{2, 4, 5}},
+		{"xyz", "foo bar", false, nil},
+		{"ba", "ab", false, nil},
+		{"", "anything", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.text, func(t *testing.T) {
+			_, positions, ok := FuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.matched {
+				t.Fatalf("FuzzyMatch(%q, %q) matched = %t, expected %t", tt.pattern, tt.text, ok, tt.matched)
+			}
+			if ok && !reflect.DeepEqual(positions, tt.positions) {
+				t.Errorf("FuzzyMatch(%q, %q) positions = %v, expected %v", tt.pattern, tt.text, positions, tt.positions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	score := func(pattern, text string) int {
+		s, _, ok := FuzzyMatch(pattern, text)
+		if !ok {
+			t.Fatalf("FuzzyMatch(%q, %q) should match", pattern, text)
+		}
+		return s
+	}
+
+	// Consecutive characters beat scattered ones
+	if score("bar", "foo bar") <= score("bar", "b_a_r") {
+		t.Error("Consecutive match should score higher than a scattered match")
+	}
+
+	// Word boundaries beat mid-word matches
+	if score("fb", "foo bar") <= score("fb", "xfxb") {
+		t.Error("Word-boundary match should score higher than a mid-word match")
+	}
+
+	// Shorter gaps beat longer ones
+	if score("ac", "abc") <= score("ac", "abbbbc") {
+		t.Error("Shorter gap should score higher")
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	tbl := newTicketTable()
+	tbl.SortByColumn(2, false)
+
+	filtered := tbl.FuzzyFilter("fxbug")
+	if len(filtered.CurrentRows()) != 2 {
+		t.Fatalf("Expected 2 fuzzy matches, got %d", len(filtered.CurrentRows()))
+	}
+
+	// Equal scores keep the current (priority) order
+	if filtered.CurrentRows()[0].Cells[0].Value != "Fix login bug" {
+		t.Errorf("Expected 'Fix login bug' first, got %v", filtered.CurrentRows()[0].Cells[0].Value)
+	}
+	if filtered.SortBy != -1 {
+		t.Errorf("Fuzzy results should not report a column sort, got SortBy %d", filtered.SortBy)
+	}
+
+	// Better matches rank first regardless of the sort order
+	filtered = tbl.FuzzyFilter("dark")
+	if len(filtered.CurrentRows()) != 1 || filtered.CurrentRows()[0].Cells[0].Value != "Add dark mode" {
+		t.Errorf("Expected only 'Add dark mode', got %d rows", len(filtered.CurrentRows()))
+	}
+
+	// Every word has to match
+	filtered = tbl.FuzzyFilter("fix zzz")
+	if len(filtered.CurrentRows()) != 0 {
+		t.Errorf("Expected no matches, got %d", len(filtered.CurrentRows()))
+	}
+}
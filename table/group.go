@@ -0,0 +1,234 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Group symbols shown before a group's heading
+const (
+	ExpandedSymbol  = "▾"
+	CollapsedSymbol = "▸"
+)
+
+// Group is a set of rows that share a value in one of the grouping columns.
+// Groups nest when a table is grouped by several columns.
+type Group struct {
+	Column     int           // Index of the grouping column
+	Value      interface{}   // Value the group's rows share
+	Label      string        // Column header and formatted value, e.g. "Status: Open"
+	Path       string        // Identifies the group among all groups, for collapse state
+	Depth      int           // Nesting level, 0 for the outermost groups
+	Rows       []Row         // Every row in the group, subgroups included, in the table's order
+	Groups     []*Group      // Subgroups, or nil for the innermost level
+	Aggregates []interface{} // Each column's aggregate over Rows (see Column.Aggregate)
+	Collapsed  bool          // The group's rows are hidden in a GroupView
+}
+
+// Count returns the number of rows in the group
+func (g *Group) Count() int {
+	return len(g.Rows)
+}
+
+// Heading returns the text of the group's header row: its label and row
+// count after an expanded or collapsed symbol, indented by depth
+func (g *Group) Heading() string {
+	symbol := ExpandedSymbol
+	if g.Collapsed {
+		symbol = CollapsedSymbol
+	}
+	return fmt.Sprintf("%s%s %s (%d)", strings.Repeat("  ", g.Depth), symbol, g.Label, len(g.Rows))
+}
+
+// GroupBy groups the rows by the columns with the given keys, nested in
+// the order given. Rows keep the table's sort order within their group, and
+// groups are ordered by value, following the column's sort direction if it
+// is a sort key. Calling GroupBy with no keys removes the grouping.
+func (t *Table) GroupBy(columnKeys ...string) error {
+	columns := make([]int, 0, len(columnKeys))
+	for _, key := range columnKeys {
+		index := t.columnIndex(key)
+		if index < 0 {
+			return fmt.Errorf("unknown column: %s", key)
+		}
+		columns = append(columns, index)
+	}
+
+	t.GroupColumns = nil
+	if len(columns) > 0 {
+		t.GroupColumns = columns
+	}
+	return nil
+}
+
+// columnIndex returns the index of the column with a key, or -1
+func (t *Table) columnIndex(key string) int {
+	for i, col := range t.Columns {
+		if col.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Groups returns the table's rows grouped by GroupColumns, or nil if the
+// table is not grouped or is backed by a DataSource
+func (t *Table) Groups() []*Group {
+	if len(t.GroupColumns) == 0 || t.source != nil {
+		return nil
+	}
+	for _, colIndex := range t.GroupColumns {
+		if colIndex >= len(t.Columns) {
+			return nil
+		}
+	}
+	return t.buildGroups(t.CurrentRows(), 0, "")
+}
+
+// buildGroups groups rows by the grouping column at a depth, and their
+// subgroups by the columns after it
+func (t *Table) buildGroups(rows []Row, depth int, parentPath string) []*Group {
+	colIndex := t.GroupColumns[depth]
+	col := t.Columns[colIndex]
+
+	var groups []*Group
+	byKey := make(map[string]*Group)
+	for _, row := range rows {
+		var value interface{}
+		if colIndex < len(row.Cells) {
+			value = row.Cells[colIndex].Value
+		}
+
+		key := distinctKey(value)
+		group, ok := byKey[key]
+		if !ok {
+			group = &Group{
+				Column: colIndex,
+				Value:  value,
+				Label:  col.Header + ": " + valueLabel(col, value),
+				Path:   parentPath + "/" + key,
+				Depth:  depth,
+			}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Rows = append(group.Rows, row)
+	}
+
+	desc := false
+	if sortKey, ok := t.GetSortKey(colIndex); ok {
+		desc = sortKey.Desc
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		result := compareCells(Cell{Value: groups[i].Value, Type: col.Type}, Cell{Value: groups[j].Value, Type: col.Type})
+		if desc {
+			return result > 0
+		}
+		return result < 0
+	})
+
+	for _, group := range groups {
+		group.Aggregates = t.aggregate(group.Rows)
+		if depth+1 < len(t.GroupColumns) {
+			group.Groups = t.buildGroups(group.Rows, depth+1, group.Path)
+		}
+	}
+	return groups
+}
+
+// GroupView returns a table that lists each group's header row followed by
+// its subgroups or rows, leaving out the contents of groups whose Path is
+// collapsed. Header rows have a Group and cells holding the group's
+// aggregates, and negative IDs so they never clash with data rows. Tables
+// that are not grouped are returned as they are.
+func (t *Table) GroupView(collapsed map[string]bool) *Table {
+	groups := t.Groups()
+	if groups == nil {
+		return t
+	}
+
+	view := NewWithColumns(t.Columns)
+	view.PageSize = t.PageSize
+	view.Footer = t.Footer
+	view.GroupColumns = slices.Clone(t.GroupColumns)
+	view.SortBy = t.SortBy
+	view.SortDesc = t.SortDesc
+	view.SortKeys = slices.Clone(t.SortKeys)
+	view.cache = t.columnCache()
+	view.parent = t.parent
+	view.ungrouped = t
+
+	var rows []Row
+	var add func(groups []*Group)
+	add = func(groups []*Group) {
+		for _, group := range groups {
+			group.Collapsed = collapsed[group.Path]
+			cells := make([]Cell, len(t.Columns))
+			for i, col := range t.Columns {
+				cells[i] = Cell{Value: group.Aggregates[i], Type: col.Type}
+			}
+			rows = append(rows, Row{ID: -1 - len(rows), Cells: cells, Group: group})
+
+			switch {
+			case group.Collapsed:
+			case group.Groups != nil:
+				add(group.Groups)
+			default:
+				rows = append(rows, group.Rows...)
+			}
+		}
+	}
+	add(groups)
+
+	view.Rows = slices.Clip(rows)
+	view.UnsortedOrder = slices.Clip(rows)
+	view.TotalRows = len(rows)
+	return view
+}
+
+// IsGroupHeader reports whether the row is a group's header row in a
+// GroupView rather than a data row
+func (r Row) IsGroupHeader() bool {
+	return r.Group != nil
+}
+
+// distinctKey returns a key telling apart the distinct values of a column,
+// nil included
+func distinctKey(value interface{}) string {
+	if value == nil {
+		return "\x00nil"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// valueLabel returns a value formatted by its column, or "(none)" for values
+// that format as empty text
+func valueLabel(col Column, value interface{}) string {
+	if label := col.Format(value); label != "" {
+		return label
+	}
+	return "(none)"
+}
+
+// aggregate returns each column's aggregate over rows, with nil for columns
+// without one
+func (t *Table) aggregate(rows []Row) []interface{} {
+	values := make([]interface{}, len(t.Columns))
+	cells := make([]Cell, 0, len(rows))
+	for i, col := range t.Columns {
+		agg := col.aggFunc()
+		if agg == nil {
+			continue
+		}
+		cells = cells[:0]
+		for _, row := range rows {
+			if i < len(row.Cells) {
+				cells = append(cells, row.Cells[i])
+			}
+		}
+		values[i] = agg(cells)
+	}
+	return values
+}
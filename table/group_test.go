@@ -0,0 +1,130 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	table := newTicketTable()
+	table.Columns[2].Aggregate = AggMax
+	table.AddRow("Triage", nil, 2, "dave", 0.5, "2024-03-01", false)
+	if err := table.GroupBy("missing"); err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+	if err := table.GroupBy("Status"); err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	groups := table.Groups()
+	if len(groups) != 4 {
+		t.Fatalf("Expected 4 groups, got %d", len(groups))
+	}
+
+	// Groups are ordered by value, nulls first
+	want := []struct {
+		label  string
+		count  int
+		points interface{}
+	}{
+		{"Status: (none)", 1, 0.5},
+		{"Status: done", 1, 2.5},
+		{"Status: in progress", 1, 13.0},
+		{"Status: todo", 2, 9.0},
+	}
+	for i, w := range want {
+		group := groups[i]
+		if group.Label != w.label || group.Count() != w.count {
+			t.Errorf("Group %d: expected %s (%d), got %s (%d)", i, w.label, w.count, group.Label, group.Count())
+		}
+		if group.Aggregates[4] != w.points {
+			t.Errorf("Group %d: expected %v points, got %v", i, w.points, group.Aggregates[4])
+		}
+		if group.Groups != nil {
+			t.Errorf("Group %d: expected no subgroups", i)
+		}
+	}
+	if groups[3].Aggregates[2] != 10 || groups[3].Aggregates[0] != nil {
+		t.Errorf("Expected max priority 10 and no title aggregate, got %v", groups[3].Aggregates)
+	}
+
+	if err := table.GroupBy(); err != nil || table.Groups() != nil {
+		t.Error("Expected GroupBy with no keys to remove the grouping")
+	}
+}
+
+func TestNestedGroups(t *testing.T) {
+	table := newTicketTable()
+	table.AddRow("Polish", "done", 1, "alice", 3.0, "2024-03-01", true)
+	table.GroupBy("Status", "Priority")
+
+	todo := table.Groups()[2]
+	if len(todo.Groups) != 2 {
+		t.Fatalf("Expected 2 priority groups, got %d", len(todo.Groups))
+	}
+	first := todo.Groups[0]
+	if first.Label != "Priority: 1" || first.Count() != 1 || first.Depth != 1 {
+		t.Errorf("Expected 1 priority 1 ticket at depth 1, got %s (%d) at %d", first.Label, first.Count(), first.Depth)
+	}
+	if first.Path == table.Groups()[0].Groups[0].Path {
+		t.Error("Expected paths to tell apart groups with the same value")
+	}
+	if got := first.Heading(); got != "  ▾ Priority: 1 (1)" {
+		t.Errorf("Expected an indented heading, got %q", got)
+	}
+}
+
+func TestGroupSorting(t *testing.T) {
+	table := newTicketTable()
+	table.GroupBy("Status")
+
+	// Sorting applies inside each group
+	table.SortByColumn(4, false)
+	todo := table.Groups()[2]
+	var titles []string
+	for _, row := range todo.Rows {
+		titles = append(titles, row.Cells[0].Value.(string))
+	}
+	if got := strings.Join(titles, ","); got != "Fix logout bug,Write docs" {
+		t.Errorf("Expected todo tickets by points, got %s", got)
+	}
+
+	// Sorting by the grouping column orders the groups
+	table.SortByColumn(1, true)
+	if got := table.Groups()[0].Label; got != "Status: todo" {
+		t.Errorf("Expected todo first when sorted descending, got %s", got)
+	}
+}
+
+func TestGroupView(t *testing.T) {
+	table := newTicketTable().WithFooter(FooterView)
+	table.GroupBy("Status")
+
+	view := table.GroupView(nil)
+	if len(view.Rows) != 7 {
+		t.Fatalf("Expected 3 headers and 4 rows, got %d", len(view.Rows))
+	}
+	if !view.Rows[0].IsGroupHeader() || view.Rows[1].IsGroupHeader() || view.Rows[0].ID >= 0 {
+		t.Error("Expected a header row with a negative ID before each group's rows")
+	}
+
+	todo := view.Rows[4].Group
+	collapsed := table.GroupView(map[string]bool{todo.Path: true})
+	if len(collapsed.Rows) != 5 {
+		t.Fatalf("Expected the todo group's rows to be hidden, got %d rows", len(collapsed.Rows))
+	}
+	if !collapsed.Rows[4].Group.Collapsed || !strings.HasPrefix(collapsed.Rows[4].Group.Heading(), CollapsedSymbol) {
+		t.Error("Expected the todo group to be collapsed")
+	}
+
+	// The footer still covers the collapsed rows and skips the headers
+	if got := collapsed.FooterText()[4]; got != "24.50" {
+		t.Errorf("Expected a footer of 24.50 points, got %q", got)
+	}
+
+	// Filtered tables stay grouped
+	filtered := table.Filter("status:todo").GroupView(nil)
+	if len(filtered.Rows) != 3 || filtered.Rows[0].Group.Count() != 2 {
+		t.Errorf("Expected one group of 2 todo tickets, got %d rows", len(filtered.Rows))
+	}
+}
@@ -0,0 +1,271 @@
+package table
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JSONOptions configures ReadJSON and ReadNDJSON
+type JSONOptions struct {
+	// SummarizeArray turns an array into a cell value. Defaults to
+	// SummarizeArray.
+	SummarizeArray func(values []interface{}) string
+}
+
+// SummarizeArray is the default summary of a JSON array: scalar elements
+// joined with commas, or an item count if any element is an object or array
+func SummarizeArray(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case map[string]interface{}, []interface{}:
+			if len(values) == 1 {
+				return "[1 item]"
+			}
+			return fmt.Sprintf("[%d items]", len(values))
+		case nil:
+			parts[i] = "null"
+		default:
+			parts[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ReadJSON reads a table from a JSON array of objects.
+//
+// Nested objects are flattened into dotted column keys, so {"user":
+// {"name": "Ada"}} gives a "user.name" column, and arrays are summarized
+// with opts.SummarizeArray. Columns keep the order their keys first appear
+// in. Column types come from the values: booleans, integers and other
+// numbers give Boolean, Integer and Float columns, and strings that all
+// parse as dates give a Date column. Missing keys and null are nil cells.
+func ReadJSON(r io.Reader, opts JSONOptions) (*Table, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('[') {
+		return nil, fmt.Errorf("expected a JSON array of objects")
+	}
+
+	reader := newJSONReader(opts)
+	for dec.More() {
+		if err := reader.readRecord(dec); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return reader.table(), nil
+}
+
+// ReadNDJSON reads a table from newline-delimited JSON, one object per line.
+// Objects are flattened and typed as by ReadJSON.
+func ReadNDJSON(r io.Reader, opts JSONOptions) (*Table, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	reader := newJSONReader(opts)
+	for {
+		err := reader.readRecord(dec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reader.table(), nil
+}
+
+// jsonReader collects flattened JSON records and the keys they use
+type jsonReader struct {
+	opts    JSONOptions
+	keys    []string
+	seen    map[string]bool
+	records []map[string]interface{}
+}
+
+func newJSONReader(opts JSONOptions) *jsonReader {
+	if opts.SummarizeArray == nil {
+		opts.SummarizeArray = SummarizeArray
+	}
+	return &jsonReader{opts: opts, seen: make(map[string]bool)}
+}
+
+// readRecord reads the next object from a decoder as a flattened record. It
+// returns io.EOF if the input ends before the object starts, and
+// io.ErrUnexpectedEOF if it ends inside it.
+func (jr *jsonReader) readRecord(dec *json.Decoder) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("record %d: expected a JSON object, got %v", len(jr.records)+1, token)
+	}
+
+	record := make(map[string]interface{})
+	if err := jr.readObject(dec, "", record); err != nil {
+		if errors.Is(err, io.EOF) {
+			// Only the end of input before a record is a clean end
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("record %d: %w", len(jr.records)+1, err)
+	}
+	jr.records = append(jr.records, record)
+	return nil
+}
+
+// readObject reads the members of an object whose opening brace has been
+// read, storing leaves in record under keys starting with prefix
+func (jr *jsonReader) readObject(dec *json.Decoder, prefix string, record map[string]interface{}) error {
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := prefix + token.(string)
+
+		token, err = dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'):
+			if err := jr.readObject(dec, key+".", record); err != nil {
+				return err
+			}
+			continue
+		case json.Delim('['):
+			values, err := readJSONArray(dec)
+			if err != nil {
+				return err
+			}
+			record[key] = jr.opts.SummarizeArray(values)
+		default:
+			record[key] = token
+		}
+
+		if !jr.seen[key] {
+			jr.seen[key] = true
+			jr.keys = append(jr.keys, key)
+		}
+	}
+
+	// Closing brace
+	_, err := dec.Token()
+	return err
+}
+
+// readJSONArray reads the elements of an array whose opening bracket has
+// been read
+func readJSONArray(dec *json.Decoder) ([]interface{}, error) {
+	values := []interface{}{}
+	for dec.More() {
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	// Closing bracket
+	_, err := dec.Token()
+	return values, err
+}
+
+// table builds a table from the records read, typing each column by its
+// values
+func (jr *jsonReader) table() *Table {
+	columns := make([]Column, len(jr.keys))
+	for i, key := range jr.keys {
+		col := NewColumn(key, key)
+		col.Type = jsonColumnType(jr.records, key)
+		col.Width = New().getDefaultWidth(col.Type)
+		columns[i] = *col
+	}
+
+	t := NewWithColumns(columns)
+	for id, record := range jr.records {
+		cells := make([]Cell, len(columns))
+		for i, col := range columns {
+			record[col.Key] = jsonValue(record[col.Key], col.Type)
+			cells[i] = Cell{Value: record[col.Key], Type: col.Type}
+		}
+		row := Row{ID: id, Cells: cells, Data: record}
+		t.Rows = append(t.Rows, row)
+		t.UnsortedOrder = append(t.UnsortedOrder, row)
+		t.originalData = append(t.originalData, record)
+		t.TotalRows++
+	}
+	return t
+}
+
+// jsonColumnType returns the DataType that fits every non-null value of a
+// key: Boolean, Integer or Float for booleans and numbers, Date for strings
+// that all parse as dates, and String otherwise
+func jsonColumnType(records []map[string]interface{}, key string) DataType {
+	var dataType DataType
+	seen := false
+
+	for _, record := range records {
+		var valueType DataType
+		switch v := record[key].(type) {
+		case nil:
+			continue
+		case bool:
+			valueType = Boolean
+		case json.Number:
+			valueType = Float
+			if csvParses(v.String(), Integer) {
+				valueType = Integer
+			}
+		case string:
+			valueType = String
+			if csvParses(v, Date) {
+				valueType = Date
+			}
+		}
+
+		switch {
+		case !seen:
+			dataType, seen = valueType, true
+		case dataType == valueType:
+		case dataType == Integer && valueType == Float, dataType == Float && valueType == Integer:
+			dataType = Float
+		default:
+			return String
+		}
+	}
+	return dataType
+}
+
+// jsonValue converts a decoded JSON value to suit its column's type
+func jsonValue(value interface{}, dataType DataType) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+
+	switch dataType {
+	case Integer:
+		if n, err := number.Int64(); err == nil {
+			return n
+		}
+	case Float:
+		if f, err := number.Float64(); err == nil {
+			return f
+		}
+	}
+	return number.String()
+}
@@ -0,0 +1,130 @@
+package table
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const usersJSON = `[
+	{"id": 1, "user": {"name": "Ada", "address": {"city": "London"}}, "score": 9.5, "tags": ["admin", "ops"], "active": true, "joined": "2024-01-15"},
+	{"id": 2, "user": {"name": "Grace", "address": null}, "score": 7, "tags": [], "active": false, "joined": "2023-06-01"},
+	{"id": 3, "user": {"name": "Linus"}, "score": null, "tags": [{"k": 1}], "extra": "x"}
+]`
+
+func TestReadJSON(t *testing.T) {
+	table, err := ReadJSON(strings.NewReader(usersJSON), JSONOptions{})
+	if err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+
+	// Columns keep the order their keys first appear in
+	wantKeys := []string{"id", "user.name", "user.address.city", "score", "tags", "active", "joined", "user.address", "extra"}
+	keys := table.GetColumnNames()
+	if len(keys) != len(wantKeys) {
+		t.Fatalf("Expected columns %v, got %v", wantKeys, keys)
+	}
+	for i, want := range wantKeys {
+		if keys[i] != want {
+			t.Errorf("Column %d: expected %q, got %q", i, want, keys[i])
+		}
+	}
+
+	wantTypes := map[string]DataType{
+		"id": Integer, "user.name": String, "score": Float,
+		"tags": String, "active": Boolean, "joined": Date, "user.address": String,
+	}
+	for i, col := range table.Columns {
+		if want, ok := wantTypes[col.Key]; ok && col.Type != want {
+			t.Errorf("Column %s: expected type %v, got %v", keys[i], want, col.Type)
+		}
+	}
+
+	first := table.Rows[0].Cells
+	if first[0].Value != int64(1) || first[2].Value != "London" || first[3].Value != 9.5 || first[5].Value != true {
+		t.Errorf("Expected typed values, got %#v", first)
+	}
+	if table.Rows[1].Cells[3].Value != 7.0 {
+		t.Errorf("Expected an integer in a Float column to become a float, got %#v", table.Rows[1].Cells[3].Value)
+	}
+
+	// Arrays are summarized
+	for i, want := range []string{"admin, ops", "", "[1 item]"} {
+		if got := table.Rows[i].Cells[4].Value; got != want {
+			t.Errorf("Row %d tags: expected %q, got %q", i, want, got)
+		}
+	}
+
+	// Missing keys and null are nil
+	if !table.Rows[2].Cells[3].IsNull() || !table.Rows[2].Cells[2].IsNull() || !table.Rows[0].Cells[8].IsNull() {
+		t.Error("Expected null and missing values to be nil")
+	}
+}
+
+func TestReadJSONOptions(t *testing.T) {
+	opts := JSONOptions{SummarizeArray: func(values []interface{}) string {
+		return strings.Repeat("*", len(values))
+	}}
+	table, err := ReadJSON(strings.NewReader(`[{"tags": [1, 2, 3]}]`), opts)
+	if err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	if got := table.Rows[0].Cells[0].Value; got != "***" {
+		t.Errorf("Expected a custom summary, got %q", got)
+	}
+
+	for _, input := range []string{`{"a": 1}`, `[1, 2]`, `[{"a": 1}`} {
+		if _, err := ReadJSON(strings.NewReader(input), JSONOptions{}); err == nil {
+			t.Errorf("Expected an error for %s", input)
+		}
+	}
+}
+
+func TestReadNDJSON(t *testing.T) {
+	input := `{"id": 1, "meta": {"source": "api"}}
+
+{"id": 2, "meta": {"source": "cli"}, "ok": true}
+`
+	table, err := ReadNDJSON(strings.NewReader(input), JSONOptions{})
+	if err != nil {
+		t.Fatalf("ReadNDJSON failed: %v", err)
+	}
+	if table.TotalRows != 2 || len(table.Columns) != 3 {
+		t.Fatalf("Expected 2 rows and 3 columns, got %d and %v", table.TotalRows, table.GetColumnNames())
+	}
+	if got := table.Rows[1].Cells[1].Value; got != "cli" {
+		t.Errorf("Expected meta.source cli, got %v", got)
+	}
+
+	if _, err := ReadNDJSON(strings.NewReader("{\"id\": 1}\n[2]\n"), JSONOptions{}); err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("Expected an error naming record 2, got %v", err)
+	}
+
+	// A record cut off mid-object is an error, not the end of the input
+	if _, err := ReadNDJSON(strings.NewReader("{\"a\":1}\n{\"a\":"), JSONOptions{}); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected an unexpected EOF for a cut-off record, got %v", err)
+	}
+	for _, input := range []string{"{\"a\":1}\n{", "{\"a\":1}\n{\"a\":{\"b\":2,"} {
+		if _, err := ReadNDJSON(strings.NewReader(input), JSONOptions{}); err == nil {
+			t.Errorf("%q: expected an error for a cut-off record", input)
+		}
+	}
+}
+
+func TestSummarizeArray(t *testing.T) {
+	tests := []struct {
+		values []interface{}
+		want   string
+	}{
+		{[]interface{}{}, ""},
+		{[]interface{}{"a", 1.5, true, nil}, "a, 1.5, true, null"},
+		{[]interface{}{"a", []interface{}{1}}, "[2 items]"},
+	}
+
+	for _, tt := range tests {
+		if got := SummarizeArray(tt.values); got != tt.want {
+			t.Errorf("SummarizeArray(%v) = %q, expected %q", tt.values, got, tt.want)
+		}
+	}
+}
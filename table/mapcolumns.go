@@ -0,0 +1,130 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ColumnOrder decides the order of columns inferred from maps, whose keys
+// have no order of their own
+type ColumnOrder int
+
+const (
+	// SortedColumns orders columns by key
+	SortedColumns ColumnOrder = iota
+	// FirstSeenColumns orders columns by the first row each key appears in.
+	// Keys that first appear in the same row are sorted.
+	FirstSeenColumns
+)
+
+// WithColumnOrder sets how columns inferred from maps are ordered (builder pattern)
+func (t *Table) WithColumnOrder(order ColumnOrder) *Table {
+	t.ColumnOrder = order
+	return t
+}
+
+// WithKeyOrder puts columns inferred from maps in the given key order
+// (builder pattern). Keys not listed follow in the table's ColumnOrder, and
+// listed keys that no row has are skipped.
+func (t *Table) WithKeyOrder(keys ...string) *Table {
+	t.KeyOrder = keys
+	return t
+}
+
+// inferColumnsFromMaps infers columns from the union of keys across maps,
+// ordered by KeyOrder and ColumnOrder. Each column's type comes from the
+// first non-nil value for its key.
+func (t *Table) inferColumnsFromMaps(maps []reflect.Value) ([]Column, error) {
+	var keys []string
+	types := make(map[string]reflect.Type)
+
+	for _, m := range maps {
+		if m.Kind() != reflect.Map {
+			return nil, fmt.Errorf("expected map, got %s", m.Type())
+		}
+
+		var newKeys []string
+		iter := m.MapRange()
+		for iter.Next() {
+			key := fmt.Sprintf("%v", iter.Key().Interface())
+			if _, seen := types[key]; !seen {
+				newKeys = append(newKeys, key)
+				types[key] = nil
+			}
+			if types[key] == nil {
+				types[key] = concreteType(iter.Value())
+			}
+		}
+
+		// Map iteration order is random, so sort keys new to this row
+		slices.Sort(newKeys)
+		keys = append(keys, newKeys...)
+	}
+
+	if t.ColumnOrder == SortedColumns {
+		slices.Sort(keys)
+	}
+	keys = orderKeys(keys, t.KeyOrder)
+
+	columns := make([]Column, len(keys))
+	for i, key := range keys {
+		col := Column{
+			Key:        key,
+			Header:     key,
+			Sortable:   true,
+			Searchable: true,
+			Formatter:  DefaultFormatter,
+			Type:       String,
+		}
+		if goType := types[key]; goType != nil {
+			col.Type = t.inferDataType(goType)
+		}
+		col.Width = t.getDefaultWidth(col.Type)
+		columns[i] = col
+	}
+	return columns, nil
+}
+
+// concreteType returns the type of the value a map entry holds, or nil if
+// the entry holds a nil interface
+func concreteType(value reflect.Value) reflect.Type {
+	if value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	return value.Type()
+}
+
+// orderKeys moves the explicitly ordered keys that are present to the front
+func orderKeys(keys, explicit []string) []string {
+	if len(explicit) == 0 {
+		return keys
+	}
+
+	ordered := make([]string, 0, len(keys))
+	for _, key := range explicit {
+		if slices.Contains(keys, key) && !slices.Contains(ordered, key) {
+			ordered = append(ordered, key)
+		}
+	}
+	for _, key := range keys {
+		if !slices.Contains(ordered, key) {
+			ordered = append(ordered, key)
+		}
+	}
+	return ordered
+}
+
+// indirect unwraps interfaces and pointers around a value
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v
+		}
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,391 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// MutationKind is the kind of change a MutationEvent reports
+type MutationKind int
+
+const (
+	RowInserted MutationKind = iota
+	RowUpdated
+	RowDeleted
+)
+
+// String returns the name of the mutation kind
+func (k MutationKind) String() string {
+	switch k {
+	case RowInserted:
+		return "inserted"
+	case RowUpdated:
+		return "updated"
+	case RowDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("MutationKind(%d)", int(k))
+	}
+}
+
+// MutationEvent reports a change to one row of a table
+type MutationEvent struct {
+	Kind  MutationKind
+	Row   Row // The row after the change, or the deleted row
+	Old   Row // The row before an update
+	Index int // Position of the row in the table's original order (UnsortedOrder)
+}
+
+// mutationListener is a function subscribed to a table's mutations
+type mutationListener struct {
+	id int
+	fn func(MutationEvent)
+}
+
+// Subscribe calls listener after every row the table inserts, updates or
+// deletes, including rows added with AddRow, and returns a function that
+// unsubscribes it. SetData replaces every row without events.
+func (t *Table) Subscribe(listener func(MutationEvent)) (unsubscribe func()) {
+	t.nextListener++
+	id := t.nextListener
+	t.listeners = append(t.listeners, mutationListener{id: id, fn: listener})
+	return func() {
+		t.listeners = slices.DeleteFunc(slices.Clone(t.listeners), func(l mutationListener) bool {
+			return l.id == id
+		})
+	}
+}
+
+// emit sends an event to every listener
+func (t *Table) emit(event MutationEvent) {
+	for _, listener := range t.listeners {
+		listener.fn(event)
+	}
+}
+
+// checkMutable reports why the table's rows cannot be changed directly
+func (t *Table) checkMutable() error {
+	switch {
+	case t.source != nil:
+		return fmt.Errorf("cannot change rows of a table backed by a data source")
+	case t.parent != nil || t.ungrouped != nil:
+		return fmt.Errorf("cannot change rows of a view; change the table it was made from")
+	default:
+		return nil
+	}
+}
+
+// InsertRowAt inserts a row of explicit values (as AddRow takes them) at a
+// position in the table's original order, from 0 to the number of rows. A
+// sorted table also places the row in sorted position. The row gets a new
+// ID that no other row has had.
+func (t *Table) InsertRowAt(index int, values ...interface{}) error {
+	if err := t.checkMutable(); err != nil {
+		return err
+	}
+	if index < 0 || index > len(t.UnsortedOrder) {
+		return fmt.Errorf("row index out of range: %d", index)
+	}
+	cells, err := t.rowCells(values)
+	if err != nil {
+		return err
+	}
+
+	t.insertRow(index, cells, values)
+	return nil
+}
+
+// UpdateRow replaces the values of the row with an ID (as AddRow takes
+// them), moving it if the table is sorted
+func (t *Table) UpdateRow(id int, values ...interface{}) error {
+	if err := t.checkMutable(); err != nil {
+		return err
+	}
+	index := t.rowIndex(id)
+	if index < 0 {
+		return fmt.Errorf("unknown row ID: %d", id)
+	}
+	cells, err := t.rowCells(values)
+	if err != nil {
+		return err
+	}
+
+	t.replaceRow(index, cells, values)
+	return nil
+}
+
+// DeleteRow removes the row with an ID. Other rows keep their IDs.
+func (t *Table) DeleteRow(id int) error {
+	if err := t.checkMutable(); err != nil {
+		return err
+	}
+	index := t.rowIndex(id)
+	if index < 0 {
+		return fmt.Errorf("unknown row ID: %d", id)
+	}
+
+	row := t.UnsortedOrder[index]
+	if pos := t.sortedIndex(row, index); pos >= 0 {
+		t.Rows = removeRow(t.Rows, pos)
+	}
+	t.UnsortedOrder = removeRow(t.UnsortedOrder, index)
+	if len(t.originalData) == len(t.UnsortedOrder)+1 {
+		t.originalData = slices.Delete(t.originalData, index, index+1)
+	}
+	if t.positions != nil {
+		t.positions.remove(id, index)
+	}
+	t.TotalRows--
+	t.PageBreaks = nil
+	t.footer = nil
+	t.version++
+	if t.cache != nil {
+		t.cache.rows = t.UnsortedOrder
+	}
+
+	t.emit(MutationEvent{Kind: RowDeleted, Row: row, Index: index})
+	return nil
+}
+
+// Upsert inserts or updates rows from data: a struct or map, or a slice of
+// them, read like SetData reads its rows. An item whose keyColumn value
+// matches a row's replaces that row; others are added at the end.
+func (t *Table) Upsert(keyColumn string, data interface{}) error {
+	if err := t.checkMutable(); err != nil {
+		return err
+	}
+	keyIndex := t.columnIndex(keyColumn)
+	if keyIndex < 0 {
+		return fmt.Errorf("unknown column: %s", keyColumn)
+	}
+	exprs, err := t.compiledExprs()
+	if err != nil {
+		return err
+	}
+
+	items := []interface{}{data}
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice {
+		items = make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	}
+
+	// Index the rows by key once; the first row with a key wins
+	byKey := make(map[string]int, len(t.UnsortedOrder))
+	for _, row := range t.UnsortedOrder {
+		key := distinctKeyAt(row, keyIndex)
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = row.ID
+		}
+	}
+
+	for _, item := range items {
+		cells := make([]Cell, len(t.Columns))
+		for i, col := range t.Columns {
+			if col.Expr == "" {
+				cells[i] = Cell{Value: t.valueFromData(item, col), Type: col.Type}
+			}
+		}
+		t.evalExprs(cells, exprs)
+
+		key := distinctKey(cells[keyIndex].Value)
+		if id, ok := byKey[key]; ok {
+			t.replaceRow(t.rowIndex(id), cells, item)
+			continue
+		}
+		row := t.insertRow(len(t.UnsortedOrder), cells, item)
+		byKey[key] = row.ID
+	}
+	return nil
+}
+
+// insertRow inserts a new row at a position in the original order
+func (t *Table) insertRow(index int, cells []Cell, data interface{}) Row {
+	row := Row{ID: t.newRowID(), Cells: cells, Data: data}
+
+	t.Rows = slices.Insert(t.Rows, t.rowPosition(row, index), row)
+	t.UnsortedOrder = slices.Insert(t.UnsortedOrder, index, row)
+	if len(t.originalData) == len(t.UnsortedOrder)-1 {
+		t.originalData = slices.Insert(t.originalData, index, data)
+	}
+	if t.positions != nil {
+		t.positions.insert(row.ID, index, len(t.UnsortedOrder))
+	}
+	t.TotalRows++
+	t.version++
+	t.rowChanged(row)
+
+	t.emit(MutationEvent{Kind: RowInserted, Row: row, Index: index})
+	return row
+}
+
+// replaceRow replaces the cells of the row at a position in the original order
+func (t *Table) replaceRow(index int, cells []Cell, data interface{}) {
+	old := t.UnsortedOrder[index]
+	row := Row{ID: old.ID, Cells: cells, Data: data}
+
+	t.UnsortedOrder[index] = row
+	if len(t.originalData) == len(t.UnsortedOrder) {
+		t.originalData[index] = data
+	}
+	if pos := t.sortedIndex(old, index); pos >= 0 {
+		t.Rows = t.moveRow(pos, row)
+	}
+	t.version++
+	t.rowChanged(row)
+
+	t.emit(MutationEvent{Kind: RowUpdated, Row: row, Old: old, Index: index})
+}
+
+// rowPosition returns where a new row goes in Rows: in sorted position, or
+// at its position in the original order when Rows follows it
+func (t *Table) rowPosition(row Row, index int) int {
+	if len(t.SortKeys) == 0 {
+		return min(index, len(t.Rows))
+	}
+	return sort.Search(len(t.Rows), func(i int) bool {
+		return compareRows(t.Rows[i], row, t.SortKeys) > 0
+	})
+}
+
+// moveRow replaces the row at a position in Rows with its new version, and
+// moves it if it is no longer in sorted position
+func (t *Table) moveRow(pos int, row Row) []Row {
+	keys := t.SortKeys
+	inPlace := len(keys) == 0 ||
+		(pos == 0 || compareRows(t.Rows[pos-1], row, keys) <= 0) &&
+			(pos == len(t.Rows)-1 || compareRows(row, t.Rows[pos+1], keys) <= 0)
+	if inPlace {
+		t.Rows[pos] = row
+		return t.Rows
+	}
+
+	rows := removeRow(t.Rows, pos)
+	t.Rows = rows
+	return slices.Insert(rows, t.rowPosition(row, pos), row)
+}
+
+// rowChanged updates the values cached for a row that was added or changed
+func (t *Table) rowChanged(row Row) {
+	t.PageBreaks = nil
+	t.footer = nil
+	if t.cache == nil {
+		return
+	}
+	if !t.cache.enabled {
+		t.cache = nil // Rebuilt on demand, as the new IDs may allow it
+		return
+	}
+	t.cache.rows = t.UnsortedOrder
+	t.cache.updateRow(t, row)
+}
+
+// newRowID returns an ID no row of the table has had
+func (t *Table) newRowID() int {
+	if t.nextID == 0 {
+		for _, row := range t.UnsortedOrder {
+			t.nextID = max(t.nextID, row.ID+1)
+		}
+	}
+	id := t.nextID
+	t.nextID++
+	return id
+}
+
+// rowIndex returns the position in UnsortedOrder of the row with an ID, or
+// -1. If IDs repeat, it returns the first row with the ID.
+func (t *Table) rowIndex(id int) int {
+	if positions := t.rowPositions(); positions != nil {
+		if pos, ok := positions.get(id); ok {
+			return pos
+		}
+		return -1
+	}
+	return slices.IndexFunc(t.UnsortedOrder, func(row Row) bool { return row.ID == id })
+}
+
+// sortedIndex returns the position in Rows of a row at a position in the
+// original order: the same position when Rows follows the original order,
+// or else found by binary search among the rows that sort equal to it
+func (t *Table) sortedIndex(row Row, index int) int {
+	if len(t.SortKeys) == 0 {
+		if index < len(t.Rows) && t.Rows[index].ID == row.ID {
+			return index
+		}
+	} else {
+		start := sort.Search(len(t.Rows), func(i int) bool {
+			return compareRows(t.Rows[i], row, t.SortKeys) >= 0
+		})
+		for i := start; i < len(t.Rows) && compareRows(t.Rows[i], row, t.SortKeys) == 0; i++ {
+			if t.Rows[i].ID == row.ID {
+				return i
+			}
+		}
+	}
+
+	// Rows were reordered or edited without a sort (see Invalidate)
+	return slices.IndexFunc(t.Rows, func(other Row) bool { return other.ID == row.ID })
+}
+
+// removeRow removes the row at a position, moving the rows after it down in
+// place
+func removeRow(rows []Row, pos int) []Row {
+	copy(rows[pos:], rows[pos+1:])
+	rows[len(rows)-1] = Row{}
+	return rows[:len(rows)-1]
+}
+
+// ApplyMutation brings a filtered view (see Filter, FilterQuery,
+// FilterColumns and FuzzyFilter) up to date with a mutation of the table it
+// was filtered from, without filtering every row again. Inserted and
+// updated rows are kept if they match the view, in sorted position or else
+// in the table's order; deleted rows are dropped. New rows of a fuzzy
+// filter are not ranked by score. A view that missed an earlier mutation
+// filters the table again when it is next read instead. Tables that are not
+// filtered views are left as they are.
+func (t *Table) ApplyMutation(event MutationEvent) {
+	if !t.isView() || t.version != t.parent.version-1 {
+		return
+	}
+	t.version = t.parent.version
+	t.PageBreaks = nil
+	t.footer = nil
+
+	pos := int32(event.Index)
+	switch event.Kind {
+	case RowInserted:
+		shiftPositions(t.order, pos, 1)
+		shiftPositions(t.original, pos, 1)
+		if t.matches(event.Row) {
+			t.addPosition(event.Row, pos)
+		}
+
+	case RowDeleted:
+		t.order = dropPosition(t.order, pos)
+		t.original = dropPosition(t.original, pos)
+
+	case RowUpdated:
+		at := slices.Index(t.order, pos)
+		keep := t.matches(event.Row)
+		switch {
+		case keep && at >= 0:
+			t.viewMove(at, event.Row)
+		case keep:
+			t.addPosition(event.Row, pos)
+		case at >= 0:
+			t.order = slices.Delete(t.order, at, at+1)
+			t.original = slices.DeleteFunc(t.original, func(other int32) bool { return other == pos })
+		}
+	}
+	t.TotalRows = len(t.order)
+}
+
+// addPosition adds a row of the parent to a view: in the parent's order, and
+// in sorted position if the view is sorted
+func (t *Table) addPosition(row Row, pos int32) {
+	t.original = slices.Insert(t.original, countBefore(t.original, pos), pos)
+	t.order = slices.Insert(t.order, t.viewInsertAt(row, pos), pos)
+}
@@ -0,0 +1,267 @@
+package table
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Stock is a test row for upserts keyed by SKU
+type Stock struct {
+	SKU string `table:"SKU"`
+	Qty int    `table:"Qty"`
+}
+
+// stockTable returns stock levels sorted by quantity
+func stockTable() *Table {
+	table := New()
+	table.SetData([]Stock{{"a", 5}, {"b", 1}, {"c", 9}})
+	table.SortByColumn(1, false)
+	return table
+}
+
+// columnValues returns the values of a column, in row order
+func columnValues(rows []Row, colIndex int) []interface{} {
+	values := make([]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = row.Cells[colIndex].Value
+	}
+	return values
+}
+
+func TestInsertRowAt(t *testing.T) {
+	table := stockTable()
+
+	if err := table.InsertRowAt(1, "d", 3); err != nil {
+		t.Fatalf("InsertRowAt failed: %v", err)
+	}
+	if got := columnValues(table.Rows, 0); !slices.Equal(got, []interface{}{"b", "d", "a", "c"}) {
+		t.Errorf("Expected the row in sorted position, got %v", got)
+	}
+	if got := columnValues(table.UnsortedOrder, 0); !slices.Equal(got, []interface{}{"a", "d", "b", "c"}) {
+		t.Errorf("Expected the row at index 1 of the original order, got %v", got)
+	}
+	if table.TotalRows != 4 || table.UnsortedOrder[1].ID != 3 {
+		t.Errorf("Expected 4 rows and a new ID of 3, got %d rows and ID %d", table.TotalRows, table.UnsortedOrder[1].ID)
+	}
+
+	// AddRow appends, also in sorted position
+	table.AddRow("e", 0)
+	if table.Rows[0].Cells[0].Value != "e" || table.UnsortedOrder[4].Cells[0].Value != "e" {
+		t.Error("Expected AddRow to append in sorted position")
+	}
+
+	if err := table.InsertRowAt(9, "f", 1); err == nil || err.Error() != "row index out of range: 9" {
+		t.Errorf("Expected an index error, got %v", err)
+	}
+	if err := table.InsertRowAt(0, "f"); err == nil {
+		t.Error("Expected an error for too few values")
+	}
+}
+
+func TestUpdateAndDeleteRow(t *testing.T) {
+	table := stockTable()
+	id := table.Rows[0].ID // b, 1
+
+	if err := table.UpdateRow(id, "b", 7); err != nil {
+		t.Fatalf("UpdateRow failed: %v", err)
+	}
+	if got := columnValues(table.Rows, 0); !slices.Equal(got, []interface{}{"a", "b", "c"}) {
+		t.Errorf("Expected the row to move to its sorted position, got %v", got)
+	}
+	if table.Rows[1].ID != id || table.UnsortedOrder[1].Cells[1].Value != 7 {
+		t.Error("Expected the row to keep its ID and original position")
+	}
+
+	// The cache follows the update
+	if got := columnValues(table.Filter("qty>6").CurrentRows(), 0); !slices.Equal(got, []interface{}{"b", "c"}) {
+		t.Errorf("Expected a filter to see the new quantity, got %v", got)
+	}
+	table.SortByColumn(1, true)
+	if table.Rows[1].ID != id {
+		t.Error("Expected a new sort to see the new quantity")
+	}
+
+	if err := table.DeleteRow(id); err != nil {
+		t.Fatalf("DeleteRow failed: %v", err)
+	}
+	if got := columnValues(table.Rows, 0); !slices.Equal(got, []interface{}{"c", "a"}) {
+		t.Errorf("Expected b to be deleted, got %v", got)
+	}
+	if table.TotalRows != 2 || len(table.UnsortedOrder) != 2 {
+		t.Errorf("Expected 2 rows, got %d", table.TotalRows)
+	}
+
+	// IDs of deleted rows are not reused
+	table.AddRow("d", 2)
+	if got := table.UnsortedOrder[2].ID; got != 3 {
+		t.Errorf("Expected a new ID of 3, got %d", got)
+	}
+
+	if err := table.UpdateRow(id, "b", 1); err == nil || err.Error() != "unknown row ID: 1" {
+		t.Errorf("Expected an unknown ID error, got %v", err)
+	}
+	if err := table.DeleteRow(id); err == nil {
+		t.Error("Expected an error deleting a deleted row")
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	table := stockTable()
+
+	err := table.Upsert("SKU", []Stock{{"c", 0}, {"z", 4}, {"z", 6}})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if got := columnValues(table.Rows, 0); !slices.Equal(got, []interface{}{"c", "b", "a", "z"}) {
+		t.Errorf("Expected c updated and z added once, got %v", got)
+	}
+	if got := table.UnsortedOrder[3].Cells[1].Value; got != 6 {
+		t.Errorf("Expected the second z to update the first, got %v", got)
+	}
+
+	// A single map works too
+	if err := table.Upsert("SKU", map[string]interface{}{"SKU": "a", "Qty": 2}); err != nil {
+		t.Fatalf("Upsert of a map failed: %v", err)
+	}
+	if table.TotalRows != 4 || table.UnsortedOrder[0].Cells[1].Value != 2 {
+		t.Error("Expected a to be updated in place")
+	}
+
+	if err := table.Upsert("Missing", Stock{}); err == nil {
+		t.Error("Expected an error for an unknown key column")
+	}
+}
+
+func TestMutationEvents(t *testing.T) {
+	table := stockTable()
+	var events []string
+	unsubscribe := table.Subscribe(func(event MutationEvent) {
+		events = append(events, event.Kind.String()+" "+event.Row.Cells[0].Value.(string))
+		if event.Kind == RowUpdated && event.Old.Cells[1].Value != 5 {
+			t.Errorf("Expected the old row with an update, got %v", event.Old)
+		}
+	})
+
+	table.AddRow("d", 2)
+	table.UpdateRow(0, "a", 6)
+	table.Upsert("SKU", Stock{"e", 3})
+	table.DeleteRow(1)
+	unsubscribe()
+	table.DeleteRow(2)
+
+	want := []string{"inserted d", "updated a", "inserted e", "deleted b"}
+	if !slices.Equal(events, want) {
+		t.Errorf("Expected events %v, got %v", want, events)
+	}
+}
+
+func TestMutationErrors(t *testing.T) {
+	view := stockTable().Filter("a")
+	if err := view.AddRow("x", 1); err == nil || !strings.Contains(err.Error(), "view") {
+		t.Errorf("Expected a view to refuse new rows, got %v", err)
+	}
+
+	table := New()
+	src, _ := NewSliceSource([]Stock{{"a", 1}})
+	table.SetSource(src)
+	if err := table.DeleteRow(0); err == nil || !strings.Contains(err.Error(), "data source") {
+		t.Errorf("Expected a data source table to refuse changes, got %v", err)
+	}
+}
+
+func TestApplyMutation(t *testing.T) {
+	table := stockTable()
+	least := 2.0
+	view := table.FilterColumns(map[int]ColumnFilter{1: NumberRange{Min: &least}})
+	search := view.Filter("a")
+	table.Subscribe(view.ApplyMutation)
+	table.Subscribe(search.ApplyMutation)
+
+	table.InsertRowAt(0, "d", 4)       // Matches
+	table.InsertRowAt(0, "ab", 3)      // Matches both
+	table.UpdateRow(2, "c", 0)         // No longer matches
+	table.UpdateRow(1, "b", 8)         // Now matches
+	table.Upsert("SKU", Stock{"a", 6}) // Still matches, same place
+
+	if got := columnValues(view.CurrentRows(), 0); !slices.Equal(got, []interface{}{"ab", "d", "a", "b"}) {
+		t.Errorf("Expected the view to stay filtered and sorted, got %v", got)
+	}
+	if got := columnValues(view.originalRows(), 0); !slices.Equal(got, []interface{}{"ab", "d", "a", "b"}) {
+		t.Errorf("Expected the view's original order to follow the table's, got %v", got)
+	}
+	if got := columnValues(search.CurrentRows(), 0); !slices.Equal(got, []interface{}{"ab", "a"}) {
+		t.Errorf("Expected the search of the view to match both, got %v", got)
+	}
+	if view.TotalRows != 4 || search.TotalRows != 2 {
+		t.Errorf("Expected 4 and 2 rows, got %d and %d", view.TotalRows, search.TotalRows)
+	}
+
+	table.DeleteRow(0)
+	if got := columnValues(search.CurrentRows(), 0); !slices.Equal(got, []interface{}{"ab"}) {
+		t.Errorf("Expected a deleted row to leave the views, got %v", got)
+	}
+
+	// Tables that are not filtered views ignore mutations
+	other := stockTable()
+	other.ApplyMutation(MutationEvent{Kind: RowDeleted, Row: other.Rows[0]})
+	if other.TotalRows != 3 {
+		t.Error("Expected a table that is not a view to be left alone")
+	}
+}
+
+func TestUpdateComputedRow(t *testing.T) {
+	table := orderTable()
+	table.AddRow("apple", 0.5, 4)
+
+	if err := table.UpdateRow(0, "apple", 0.5, 10); err != nil {
+		t.Fatalf("UpdateRow failed: %v", err)
+	}
+	if got := table.Rows[0].Cells[3].Value; got != 5.0 {
+		t.Errorf("Expected the total to be recomputed, got %v", got)
+	}
+
+	type Order struct {
+		Name  string  `table:"name"`
+		Price float64 `table:"price"`
+		Qty   int     `table:"qty"`
+	}
+	if err := table.Upsert("name", Order{"apple", 1, 3}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if got := table.Rows[0].Cells[3].Value; got != 3.0 {
+		t.Errorf("Expected an upsert to recompute the total, got %v", got)
+	}
+}
+
+func TestRowPositionsFollowMutations(t *testing.T) {
+	for _, sparse := range []bool{false, true} {
+		table := stockTable()
+		if sparse {
+			for i := range table.UnsortedOrder {
+				table.UnsortedOrder[i].ID = 1000 + i
+			}
+			table.Invalidate()
+			table.SortByColumn(1, false)
+		}
+		table.rowIndex(0) // Build the lookup, so mutations keep it up to date
+
+		table.InsertRowAt(1, "d", 3)
+		table.AddRow("e", 0)
+		table.DeleteRow(table.UnsortedOrder[2].ID)
+		table.UpdateRow(table.UnsortedOrder[0].ID, "a", 8)
+		table.InsertRowAt(0, "f", 4)
+
+		for pos, row := range table.UnsortedOrder {
+			if got := table.rowIndex(row.ID); got != pos {
+				t.Errorf("sparse=%v: expected row %d at %d, got %d", sparse, row.ID, pos, got)
+			}
+		}
+		if got := columnValues(table.Rows, 0); !slices.Equal(got, []interface{}{"e", "d", "f", "a", "c"}) {
+			t.Errorf("sparse=%v: expected rows sorted by quantity, got %v", sparse, got)
+		}
+		if table.rowIndex(-5) != -1 {
+			t.Errorf("sparse=%v: expected -1 for an unknown ID", sparse)
+		}
+	}
+}
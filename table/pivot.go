@@ -0,0 +1,203 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PivotTotalKey is the key and header of a pivot table's row total column,
+// and the label of its footer of column totals
+const PivotTotalKey = "Total"
+
+// Pivot turns a long-format table into a crosstab: one row per distinct
+// value of rowKey, one column per distinct value of colKey, and in each cell
+// agg over the valueKey cells of the source rows with that pair of values.
+// Pairs without source rows are nil. A Total column holds agg over each
+// row's source rows, and the footer (FooterAll) holds agg over each
+// column's source rows and the grand total, so totals of averages and
+// distinct counts are correct rather than sums of the cells. Column keys
+// are the value labels, with a suffix ("Total_2") where a label would
+// repeat the rowKey column's key, PivotTotalKey or another column's key.
+//
+// A nil agg uses the value column's aggregate (see Column.Aggregate), or
+// AggCount if it has none. Rows and columns are ordered by value, as groups
+// are. The source's current rows are used, so pivoting a filtered table
+// pivots the filtered rows.
+func Pivot(src *Table, rowKey, colKey, valueKey string, agg AggFunc) (*Table, error) {
+	if src.source != nil {
+		return nil, fmt.Errorf("cannot pivot a table backed by a data source")
+	}
+
+	indexes := make([]int, 3)
+	for i, key := range []string{rowKey, colKey, valueKey} {
+		if indexes[i] = src.columnIndex(key); indexes[i] < 0 {
+			return nil, fmt.Errorf("unknown column: %s", key)
+		}
+	}
+	rowCol, colCol, valueCol := src.Columns[indexes[0]], src.Columns[indexes[1]], src.Columns[indexes[2]]
+	if agg == nil {
+		agg = valueCol.aggFunc()
+	}
+	if agg == nil {
+		agg = AggCount
+	}
+
+	srcRows := src.CurrentRows()
+	rowValues, rowsByRow := distinctValues(srcRows, indexes[0], rowCol.Type)
+	colValues, rowsByCol := distinctValues(srcRows, indexes[1], colCol.Type)
+	rowsByPair := make(map[[2]string][]Row)
+	for _, row := range srcRows {
+		pair := [2]string{distinctKeyAt(row, indexes[0]), distinctKeyAt(row, indexes[1])}
+		rowsByPair[pair] = append(rowsByPair[pair], row)
+	}
+	valueCells := func(rows []Row) []Cell {
+		cells := make([]Cell, 0, len(rows))
+		for _, row := range rows {
+			if indexes[2] < len(row.Cells) {
+				cells = append(cells, row.Cells[indexes[2]])
+			}
+		}
+		return cells
+	}
+
+	// Aggregate every cell first, so column types can follow the results
+	grid := make([][]interface{}, len(rowValues))
+	for i, rowValue := range rowValues {
+		grid[i] = make([]interface{}, len(colValues)+1)
+		for j, colValue := range colValues {
+			if rows, ok := rowsByPair[[2]string{distinctKey(rowValue), distinctKey(colValue)}]; ok {
+				grid[i][j] = agg(valueCells(rows))
+			}
+		}
+		grid[i][len(colValues)] = agg(valueCells(rowsByRow[distinctKey(rowValue)]))
+	}
+	totals := make([]interface{}, len(colValues)+1)
+	for j, colValue := range colValues {
+		totals[j] = agg(valueCells(rowsByCol[distinctKey(colValue)]))
+	}
+	totals[len(colValues)] = agg(valueCells(srcRows))
+
+	// Columns: the row values, one per column value, and the row totals
+	first := rowCol
+	first.Aggregate = constantAgg(PivotTotalKey)
+	first.NoAggregate = false
+	first.Expr = "" // The values are copied, not computed again
+	first.Sortable, first.Searchable = true, true
+	columns := []Column{first}
+	used := map[string]bool{strings.ToLower(first.Key): true, strings.ToLower(PivotTotalKey): true}
+	for j, colValue := range colValues {
+		col := pivotColumn(valueLabel(colCol, colValue), valueCol, grid, j, totals[j])
+		col.Key = uniqueKey(col.Key, used)
+		columns = append(columns, col)
+	}
+	columns = append(columns, pivotColumn(PivotTotalKey, valueCol, grid, len(colValues), totals[len(colValues)]))
+
+	pivot := NewWithColumns(columns).WithFooter(FooterAll)
+	pivot.PageSize = src.PageSize
+	for i, rowValue := range rowValues {
+		values := append([]interface{}{rowValue}, grid[i]...)
+		for j, value := range values {
+			if n, ok := value.(Count); ok {
+				values[j] = int(n)
+			}
+		}
+		if err := pivot.AddRow(values...); err != nil {
+			return nil, err
+		}
+	}
+	return pivot, nil
+}
+
+// pivotColumn returns a pivot column holding the aggregates in one column of
+// the grid, typed and formatted to suit them, with a constant footer total
+func pivotColumn(header string, valueCol Column, grid [][]interface{}, j int, total interface{}) Column {
+	col := NewColumn(header, header)
+	col.NullText = ""
+
+	counts, integers, numbers := true, true, true
+	for _, row := range grid {
+		switch value := row[j].(type) {
+		case nil:
+		case Count:
+		default:
+			counts = false
+			if _, ok := integerValue(value); !ok {
+				integers = false
+			}
+			if _, ok := numericValue(value); !ok {
+				numbers = false
+			}
+		}
+	}
+
+	switch {
+	case counts:
+		col.Type = Integer
+	case integers:
+		col.Type = Integer
+		col.Formatter = valueCol.Formatter
+	case numbers:
+		col.Type = Float
+		col.Formatter = valueCol.Formatter
+	default:
+		col.Type = valueCol.Type
+		col.Formatter = valueCol.Formatter
+	}
+	if col.Formatter == nil {
+		col.Formatter = DefaultFormatter
+	}
+
+	col.Width = max(New().getDefaultWidth(col.Type), DisplayWidth(header)+2)
+	col.Align = valueCol.Align
+	col.Aggregate = constantAgg(total)
+	return *col
+}
+
+// uniqueKey returns key, or key with the first free suffix "_2", "_3"... if
+// it is in used (ignoring case, as queries do), and marks the result used
+func uniqueKey(key string, used map[string]bool) string {
+	unique := key
+	for n := 2; used[strings.ToLower(unique)]; n++ {
+		unique = fmt.Sprintf("%s_%d", key, n)
+	}
+	used[strings.ToLower(unique)] = true
+	return unique
+}
+
+// constantAgg returns an aggregate that always gives value, for totals that
+// were computed from rows the table does not hold
+func constantAgg(value interface{}) AggFunc {
+	return func([]Cell) interface{} { return value }
+}
+
+// distinctValues returns the distinct values of a column in the order sorting
+// uses, and the rows holding each, keyed by distinctKey
+func distinctValues(rows []Row, colIndex int, dataType DataType) ([]interface{}, map[string][]Row) {
+	var values []interface{}
+	byKey := make(map[string][]Row)
+	for _, row := range rows {
+		key := distinctKeyAt(row, colIndex)
+		if _, ok := byKey[key]; !ok {
+			var value interface{}
+			if colIndex < len(row.Cells) {
+				value = row.Cells[colIndex].Value
+			}
+			values = append(values, value)
+		}
+		byKey[key] = append(byKey[key], row)
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return compareCells(Cell{Value: values[i], Type: dataType}, Cell{Value: values[j], Type: dataType}) < 0
+	})
+	return values, byKey
+}
+
+// distinctKeyAt returns the distinctKey of a row's cell in a column
+func distinctKeyAt(row Row, colIndex int) string {
+	if colIndex >= len(row.Cells) {
+		return distinctKey(nil)
+	}
+	return distinctKey(row.Cells[colIndex].Value)
+}
@@ -0,0 +1,154 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// salesTable returns sales in long format: one row per region, quarter and sale
+func salesTable() *Table {
+	table := NewWithColumns([]Column{
+		*NewColumn("region", "Region"),
+		*NewColumn("quarter", "Quarter"),
+		*NewColumn("amount", "Amount").WithType(Float).WithFormatter(CurrencyFormatter),
+	})
+	table.AddRow("North", "Q2", 10.0)
+	table.AddRow("South", "Q1", 4.0)
+	table.AddRow("North", "Q1", 6.0)
+	table.AddRow("North", "Q1", 2.0)
+	table.AddRow("South", "Q3", 1.5)
+	return table
+}
+
+// pivotText returns a table's headers, formatted rows and footer
+func pivotText(table *Table) string {
+	lines := []string{strings.Join(table.GetColumnNames(), ",")}
+	for i := range table.Rows {
+		cells := make([]string, len(table.Columns))
+		for j := range table.Columns {
+			cells[j] = table.GetCellValue(i, j)
+		}
+		lines = append(lines, strings.Join(cells, ","))
+	}
+	lines = append(lines, strings.Join(table.FooterText(), ","))
+	return strings.Join(lines, "\n")
+}
+
+func TestPivot(t *testing.T) {
+	pivot, err := Pivot(salesTable(), "region", "quarter", "amount", AggSum)
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+
+	want := `Region,Q1,Q2,Q3,Total
+North,$8.00,$10.00,,$18.00
+South,$4.00,,$1.50,$5.50
+Total,$12.00,$10.00,$1.50,$23.50`
+	if got := pivotText(pivot); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+	if pivot.Columns[1].Type != Float || pivot.Columns[1].Key != "Q1" {
+		t.Errorf("Expected a Float Q1 column, got %+v", pivot.Columns[1])
+	}
+}
+
+func TestPivotTotalsAggregateSourceRows(t *testing.T) {
+	pivot, err := Pivot(salesTable(), "region", "quarter", "amount", AggAvg)
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+
+	// North's total is the mean of its three sales, not of its two cells
+	if got := pivot.GetCellValue(0, 4); got != "$6.00" {
+		t.Errorf("Expected North's average of $6.00, got %s", got)
+	}
+	if got := pivot.FooterText()[4]; got != "$4.70" {
+		t.Errorf("Expected a grand average of $4.70, got %s", got)
+	}
+}
+
+func TestPivotCount(t *testing.T) {
+	// Without an aggregate, a String value column is counted
+	pivot, err := Pivot(salesTable(), "quarter", "region", "region", nil)
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+
+	want := `Quarter,North,South,Total
+Q1,2,1,3
+Q2,1,,1
+Q3,,1,1
+Total,3,2,5`
+	if got := pivotText(pivot); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+	if pivot.Columns[1].Type != Integer {
+		t.Errorf("Expected Integer count columns, got %v", pivot.Columns[1].Type)
+	}
+}
+
+func TestPivotIsAnOrdinaryTable(t *testing.T) {
+	pivot, _ := Pivot(salesTable().Filter("north"), "quarter", "region", "amount", AggSum)
+	if len(pivot.Columns) != 3 {
+		t.Fatalf("Expected the filtered rows to be pivoted, got columns %v", pivot.GetColumnNames())
+	}
+
+	if err := pivot.SortByColumn(1, true); err != nil {
+		t.Fatalf("Sorting the pivot failed: %v", err)
+	}
+	var quarters []interface{}
+	for _, row := range pivot.Rows {
+		quarters = append(quarters, row.Cells[0].Value)
+	}
+	if !reflect.DeepEqual(quarters, []interface{}{"Q2", "Q1"}) {
+		t.Errorf("Expected quarters by amount descending, got %v", quarters)
+	}
+
+	if filtered := pivot.Filter("Q2"); len(filtered.CurrentRows()) != 1 || filtered.FooterText()[1] != "$18.00" {
+		t.Error("Expected search to narrow the rows and keep the totals")
+	}
+
+	var out strings.Builder
+	if err := pivot.WriteCSV(&out, DefaultCSVOptions()); err != nil || !strings.HasPrefix(out.String(), "Quarter,North,Total\nQ2,10,10\n") {
+		t.Errorf("Expected the pivot to export, got %q (%v)", out.String(), err)
+	}
+}
+
+func TestPivotUniqueKeys(t *testing.T) {
+	table := NewWithColumns([]Column{
+		*NewColumn("region", "Region"),
+		*NewColumn("quarter", "Quarter"),
+	})
+	table.AddRow("North", "Total")
+	table.AddRow("North", "Region")
+	table.AddRow("South", "total")
+
+	pivot, err := Pivot(table, "region", "quarter", "quarter", nil)
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+
+	var keys []string
+	for _, col := range pivot.Columns {
+		keys = append(keys, col.Key)
+	}
+	if want := []string{"region", "Region_2", "Total_2", "total_3", "Total"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected keys %v, got %v", want, keys)
+	}
+	if want := "Region,Region,Total,total,Total"; strings.Join(pivot.GetColumnNames(), ",") != want {
+		t.Errorf("Expected the labels as headers, got %v", pivot.GetColumnNames())
+	}
+	if filtered, err := pivot.FilterQuery("total_3>0"); err != nil || filtered.TotalRows != 1 {
+		t.Errorf("Expected a query to reach the renamed column, got %v", err)
+	}
+}
+
+func TestPivotErrors(t *testing.T) {
+	if _, err := Pivot(salesTable(), "region", "missing", "amount", nil); err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+	if _, err := Pivot(newSourceTable(t), "name", "name", "name", nil); err == nil {
+		t.Error("Expected an error for a source-backed table")
+	}
+}
@@ -0,0 +1,597 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryOp is a comparison operator in a filter query term
+type QueryOp int
+
+const (
+	OpMatch        QueryOp = iota // field:value (contains for strings, equals otherwise)
+	OpEqual                       // field=value
+	OpNotEqual                    // field!=value
+	OpGreater                     // field>value
+	OpGreaterEqual                // field>=value
+	OpLess                        // field<value
+	OpLessEqual                   // field<=value
+)
+
+// queryOps lists operator spellings, longest first so ">=" wins over ">"
+var queryOps = []struct {
+	text string
+	op   QueryOp
+}{
+	{">=", OpGreaterEqual},
+	{"<=", OpLessEqual},
+	{"!=", OpNotEqual},
+	{":", OpMatch},
+	{"=", OpEqual},
+	{">", OpGreater},
+	{"<", OpLess},
+}
+
+// String returns the operator as written in a query
+func (op QueryOp) String() string {
+	for _, candidate := range queryOps {
+		if candidate.op == op {
+			return candidate.text
+		}
+	}
+	return "?"
+}
+
+// QueryError describes a problem with a filter query and where it occurred
+type QueryError struct {
+	Pos int // Byte offset in the query string
+	Msg string
+}
+
+// Error implements the error interface
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("col %d: %s", e.Pos+1, e.Msg)
+}
+
+// QueryNode is a node in a parsed filter query
+type QueryNode interface {
+	// String returns the node in query syntax
+	String() string
+}
+
+// AndNode matches rows that match every child
+type AndNode struct {
+	Children []QueryNode
+}
+
+// OrNode matches rows that match at least one child
+type OrNode struct {
+	Children []QueryNode
+}
+
+// NotNode matches rows that do not match its child
+type NotNode struct {
+	Child QueryNode
+}
+
+// TermNode is a single search term. A term without a Field matches the
+// value against every searchable column.
+type TermNode struct {
+	Field  string
+	Op     QueryOp
+	Value  string
+	Quoted bool // Value was written as a quoted phrase
+	Pos    int
+}
+
+// String implements QueryNode
+func (n *AndNode) String() string {
+	return joinQueryNodes(n.Children, " ")
+}
+
+// String implements QueryNode
+func (n *OrNode) String() string {
+	return joinQueryNodes(n.Children, " OR ")
+}
+
+// String implements QueryNode
+func (n *NotNode) String() string {
+	return "-" + n.Child.String()
+}
+
+// String implements QueryNode
+func (n *TermNode) String() string {
+	value := n.Value
+	if n.Quoted {
+		value = strconv.Quote(value)
+	}
+	if n.Field == "" {
+		return value
+	}
+	return n.Field + n.Op.String() + value
+}
+
+// isText reports whether a term whose field is not a column is ordinary text
+// with a ':' or '>' in it rather than a misspelt column: a field of digits,
+// as in "12:30", or a URL scheme, as in "http://x.io"
+func (n *TermNode) isText() bool {
+	if n.Op == OpMatch && strings.HasPrefix(n.Value, "//") {
+		return true
+	}
+	return n.Field != "" && strings.Trim(n.Field, "0123456789") == ""
+}
+
+// bareTerm returns the term as plain text to search every searchable
+// column for, as written: "12:30" or "http://x.io" rather than a column
+// named "12" or "http"
+func (n *TermNode) bareTerm() *TermNode {
+	return &TermNode{Op: OpMatch, Value: n.Field + n.Op.String() + n.Value, Pos: n.Pos}
+}
+
+// joinQueryNodes joins the string forms of nodes with a separator
+func joinQueryNodes(nodes []QueryNode, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = node.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// queryToken is a whitespace-delimited piece of a query
+type queryToken struct {
+	text   string // Token text with quotes removed
+	raw    string // Token text as written
+	pos    int
+	quoted bool // Token started with a quote
+}
+
+// tokenizeQuery splits a query on whitespace, keeping quoted phrases together
+func tokenizeQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+	i := 0
+	for i < len(query) {
+		if query[i] == ' ' || query[i] == '\t' {
+			i++
+			continue
+		}
+
+		start := i
+		var text strings.Builder
+		inQuote := false
+		quoteStart := 0
+		for i < len(query) && (inQuote || (query[i] != ' ' && query[i] != '\t')) {
+			if query[i] == '"' {
+				inQuote = !inQuote
+				quoteStart = i
+				i++
+				continue
+			}
+			text.WriteByte(query[i])
+			i++
+		}
+		if inQuote {
+			return nil, &QueryError{Pos: quoteStart, Msg: "unterminated quote"}
+		}
+
+		raw := query[start:i]
+		tokens = append(tokens, queryToken{
+			text:   text.String(),
+			raw:    raw,
+			pos:    start,
+			quoted: strings.HasPrefix(strings.TrimPrefix(raw, "-"), `"`),
+		})
+	}
+	return tokens, nil
+}
+
+// ParseQuery parses a filter query into an AST.
+//
+// The syntax is a whitespace-separated list of terms that must all match:
+//
+//	alice                bare word, matched against every searchable column
+//	"exact phrase"       quoted phrase, matched against every searchable column
+//	status:done          column match (contains for strings, equals otherwise)
+//	priority>=2          comparison, one of = != > >= < <=
+//	-assignee:bob        negation of any term
+//	a OR b               either term may match
+//
+// Column names refer to a column's Key or Header, case-insensitively.
+func ParseQuery(query string) (QueryNode, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	or := &OrNode{}
+	and := &AndNode{}
+	for i, token := range tokens {
+		if token.raw == "OR" {
+			if len(and.Children) == 0 || i == len(tokens)-1 {
+				return nil, &QueryError{Pos: token.pos, Msg: "OR needs a term on both sides"}
+			}
+			or.Children = append(or.Children, and)
+			and = &AndNode{}
+			continue
+		}
+
+		node, err := parseQueryTerm(token)
+		if err != nil {
+			return nil, err
+		}
+		and.Children = append(and.Children, node)
+	}
+	or.Children = append(or.Children, and)
+
+	if len(or.Children) == 1 {
+		return or.Children[0], nil
+	}
+	return or, nil
+}
+
+// parseQueryTerm parses a single token into a (possibly negated) term
+func parseQueryTerm(token queryToken) (QueryNode, error) {
+	negated := false
+	raw := token.raw
+	text := token.text
+	pos := token.pos
+	if strings.HasPrefix(raw, "-") {
+		negated = true
+		raw = raw[1:]
+		text = text[1:]
+		pos++
+		if raw == "" {
+			return nil, &QueryError{Pos: token.pos, Msg: "'-' must be followed by a term"}
+		}
+	}
+
+	term := &TermNode{Op: OpMatch, Value: text, Quoted: token.quoted, Pos: pos}
+
+	if !token.quoted {
+		if opPos, op, opLen := findQueryOp(raw); opPos >= 0 {
+			if opPos == 0 {
+				return nil, &QueryError{Pos: pos, Msg: fmt.Sprintf("missing column name before '%s'", op)}
+			}
+			term.Field = raw[:opPos]
+			term.Op = op
+			value := raw[opPos+opLen:]
+			term.Quoted = strings.HasPrefix(value, `"`)
+			term.Value = strings.ReplaceAll(value, `"`, "")
+			if term.Value == "" && !term.Quoted {
+				return nil, &QueryError{Pos: pos + opPos + opLen, Msg: fmt.Sprintf("missing value after '%s%s'", term.Field, op)}
+			}
+		}
+	}
+
+	if negated {
+		return &NotNode{Child: term}, nil
+	}
+	return term, nil
+}
+
+// findQueryOp finds the first operator outside quotes in a raw token
+func findQueryOp(raw string) (int, QueryOp, int) {
+	inQuote := false
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '"' {
+			inQuote = !inQuote
+			continue
+		}
+		if inQuote {
+			continue
+		}
+		for _, candidate := range queryOps {
+			if strings.HasPrefix(raw[i:], candidate.text) {
+				return i, candidate.op, len(candidate.text)
+			}
+		}
+	}
+	return -1, OpMatch, 0
+}
+
+// rowMatcher evaluates a compiled query against a row
+type rowMatcher func(row Row) bool
+
+// CompileQuery parses a query and binds it to this table's columns, checking
+// that every column exists and that values parse as the column's DataType.
+func (t *Table) CompileQuery(query string) (func(row Row) bool, error) {
+	node, err := ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	matcher, err := t.compileQueryNode(node)
+	if err != nil {
+		return nil, err
+	}
+	return matcher, nil
+}
+
+// compileQueryNode turns an AST node into a row matcher
+func (t *Table) compileQueryNode(node QueryNode) (rowMatcher, error) {
+	switch n := node.(type) {
+	case *AndNode:
+		children, err := t.compileQueryNodes(n.Children)
+		if err != nil {
+			return nil, err
+		}
+		return func(row Row) bool {
+			for _, child := range children {
+				if !child(row) {
+					return false
+				}
+			}
+			return true
+		}, nil
+
+	case *OrNode:
+		children, err := t.compileQueryNodes(n.Children)
+		if err != nil {
+			return nil, err
+		}
+		return func(row Row) bool {
+			for _, child := range children {
+				if child(row) {
+					return true
+				}
+			}
+			return false
+		}, nil
+
+	case *NotNode:
+		child, err := t.compileQueryNode(n.Child)
+		if err != nil {
+			return nil, err
+		}
+		return func(row Row) bool {
+			return !child(row)
+		}, nil
+
+	case *TermNode:
+		return t.compileQueryTerm(n)
+
+	default:
+		return nil, fmt.Errorf("unsupported query node %T", node)
+	}
+}
+
+// compileQueryNodes compiles a list of nodes
+func (t *Table) compileQueryNodes(nodes []QueryNode) ([]rowMatcher, error) {
+	matchers := make([]rowMatcher, len(nodes))
+	for i, node := range nodes {
+		matcher, err := t.compileQueryNode(node)
+		if err != nil {
+			return nil, err
+		}
+		matchers[i] = matcher
+	}
+	return matchers, nil
+}
+
+// compileQueryTerm binds a term to its column and parses its value. A term
+// whose field is not a column but could not be one either (see isText)
+// matches as plain text.
+func (t *Table) compileQueryTerm(term *TermNode) (rowMatcher, error) {
+	if term.Field == "" {
+		searchTerm := strings.ToLower(term.Value)
+		return func(row Row) bool {
+			return t.rowMatchesSearch(row, searchTerm)
+		}, nil
+	}
+
+	colIndex := t.findColumn(term.Field)
+	if colIndex < 0 {
+		if term.isText() {
+			return t.compileQueryTerm(term.bareTerm())
+		}
+		return nil, &QueryError{Pos: term.Pos, Msg: fmt.Sprintf("unknown column %q", term.Field)}
+	}
+
+	return t.compileComparison(t.Columns[colIndex], colIndex, term)
+}
+
+// QueryTextTerms returns the terms of a query that are searched for as plain
+// text because their field is not a column, such as "12:30" or
+// "http://x.io", so a search bar can say so. It returns nil if the query
+// does not parse.
+func (t *Table) QueryTextTerms(query string) []string {
+	node, err := ParseQuery(query)
+	if err != nil {
+		return nil
+	}
+
+	var terms []string
+	var walk func(node QueryNode)
+	walk = func(node QueryNode) {
+		switch n := node.(type) {
+		case *AndNode:
+			for _, child := range n.Children {
+				walk(child)
+			}
+		case *OrNode:
+			for _, child := range n.Children {
+				walk(child)
+			}
+		case *NotNode:
+			walk(n.Child)
+		case *TermNode:
+			if n.Field != "" && t.findColumn(n.Field) < 0 && n.isText() {
+				terms = append(terms, n.bareTerm().Value)
+			}
+		}
+	}
+	walk(node)
+	return terms
+}
+
+// compileComparison builds a row predicate for a term, comparing the
+// column's cells using the column's DataType
+func (t *Table) compileComparison(col Column, colIndex int, term *TermNode) (rowMatcher, error) {
+	invalid := func(kind string) error {
+		return &QueryError{Pos: term.Pos, Msg: fmt.Sprintf("%s: %q is not a valid %s", col.Header, term.Value, kind)}
+	}
+
+	switch col.Type {
+	case Integer, Float:
+		want, err := strconv.ParseFloat(term.Value, 64)
+		if err != nil {
+			return nil, invalid("number")
+		}
+		return func(row Row) bool {
+			cell, ok := cellValueAt(row, colIndex)
+			if !ok {
+				return false
+			}
+			got, ok := numericValue(cell.Value)
+			return ok && applyQueryOp(term.Op, compareFloats(got, want))
+		}, nil
+
+	case Date:
+		want, err := parseDate(term.Value)
+		if err != nil {
+			return nil, invalid("date")
+		}
+		return func(row Row) bool {
+			cell, ok := cellValueAt(row, colIndex)
+			if !ok {
+				return false
+			}
+			got, ok := dateValue(cell.Value)
+			return ok && applyQueryOp(term.Op, got.Compare(want))
+		}, nil
+
+	case Boolean:
+		want, ok := parseBool(term.Value)
+		if !ok {
+			return nil, invalid("boolean")
+		}
+		if term.Op != OpMatch && term.Op != OpEqual && term.Op != OpNotEqual {
+			return nil, &QueryError{Pos: term.Pos, Msg: fmt.Sprintf("%s: operator '%s' is not supported for booleans", col.Header, term.Op)}
+		}
+		return func(row Row) bool {
+			cell, ok := cellValueAt(row, colIndex)
+			if !ok {
+				return false
+			}
+			got, ok := boolValue(cell.Value)
+			return ok && (got == want) == (term.Op != OpNotEqual)
+		}, nil
+
+	default:
+		want := strings.ToLower(term.Value)
+		return func(row Row) bool {
+			if colIndex >= len(row.Cells) {
+				return false
+			}
+			got := t.cellTextLower(row, colIndex)
+			if term.Op == OpMatch {
+				return strings.Contains(got, want)
+			}
+			return applyQueryOp(term.Op, strings.Compare(got, want))
+		}, nil
+	}
+}
+
+// applyQueryOp checks a comparison result against an operator
+func applyQueryOp(op QueryOp, result int) bool {
+	switch op {
+	case OpMatch, OpEqual:
+		return result == 0
+	case OpNotEqual:
+		return result != 0
+	case OpGreater:
+		return result > 0
+	case OpGreaterEqual:
+		return result >= 0
+	case OpLess:
+		return result < 0
+	case OpLessEqual:
+		return result <= 0
+	default:
+		return false
+	}
+}
+
+// findColumn returns the index of the column whose Key or Header matches name
+func (t *Table) findColumn(name string) int {
+	for i, col := range t.Columns {
+		if strings.EqualFold(col.Key, name) {
+			return i
+		}
+	}
+	for i, col := range t.Columns {
+		if strings.EqualFold(col.Header, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// compareFloats returns -1, 0 or 1
+func compareFloats(a, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// numericValue converts a cell value to float64
+func numericValue(value interface{}) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		return f, err == nil
+	default:
+		f, err := strconv.ParseFloat(fmt.Sprintf("%v", value), 64)
+		return f, err == nil
+	}
+}
+
+// dateValue converts a cell value to time.Time
+func dateValue(value interface{}) (time.Time, bool) {
+	if tm, ok := value.(time.Time); ok {
+		return tm, true
+	}
+	if value == nil {
+		return time.Time{}, false
+	}
+	tm, err := parseDate(value)
+	return tm, err == nil
+}
+
+// boolValue converts a cell value to bool
+func boolValue(value interface{}) (bool, bool) {
+	if b, ok := value.(bool); ok {
+		return b, true
+	}
+	if value == nil {
+		return false, false
+	}
+	return parseBool(fmt.Sprintf("%v", value))
+}
+
+// parseBool parses the boolean spellings accepted in queries
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "y", "1":
+		return true, true
+	case "false", "no", "n", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,181 @@
+package table
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type Ticket struct {
+	Title    string
+	Status   string
+	Priority int
+	Assignee string
+	Estimate float64
+	Due      string
+	Done     bool
+}
+
+func newTicketTable() *Table {
+	columns := []Column{
+		*NewColumn("Title", "Title"),
+		*NewColumn("Status", "Status"),
+		*NewColumn("Priority", "Priority").WithType(Integer),
+		*NewColumn("Assignee", "Assignee"),
+		*NewColumn("Estimate", "Points").WithType(Float),
+		*NewColumn("Due", "Due Date").WithType(Date),
+		*NewColumn("Done", "Done").WithType(Boolean),
+	}
+
+	return NewWithColumns(columns).WithData([]Ticket{
+		{"Fix login bug", "done", 3, "alice", 2.5, "2024-01-10", true},
+		{"Write docs", "todo", 1, "bob", 8, "2024-02-01", false},
+		{"Add dark mode", "in progress", 2, "carol", 13, "2024-01-20", false},
+		{"Fix logout bug", "todo", 10, "bob", 1, "2023-12-24", false},
+	})
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"alice", "alice"},
+		{"status:done priority>=2", "status:done priority>=2"},
+		{"-assignee:bob", "-assignee:bob"},
+		{`"exact phrase"`, `"exact phrase"`},
+		{`title:"login bug"`, `title:"login bug"`},
+		{"a OR b c", "a OR b c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) failed: %v", tt.query, err)
+			}
+			if node.String() != tt.expected {
+				t.Errorf("ParseQuery(%q) = %q, expected %q", tt.query, node.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{`"unterminated`, 0},
+		{"status:", 7},
+		{">=2", 0},
+		{"a -", 2},
+		{"OR a", 0},
+		{"a OR", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			var queryErr *QueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("ParseQuery(%q) should return a QueryError, got %v", tt.query, err)
+			}
+			if queryErr.Pos != tt.pos {
+				t.Errorf("ParseQuery(%q) error at %d, expected %d", tt.query, queryErr.Pos, tt.pos)
+			}
+		})
+	}
+}
+
+func TestFilterQuery(t *testing.T) {
+	tbl := newTicketTable()
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"status:done", []string{"Fix login bug"}},
+		{"priority>=2", []string{"Fix login bug", "Add dark mode", "Fix logout bug"}},
+		// Numeric, not lexical: "10" > "3" as a number
+		{"priority>3", []string{"Fix logout bug"}},
+		{"-assignee:bob", []string{"Fix login bug", "Add dark mode"}},
+		{`"login bug"`, []string{"Fix login bug"}},
+		{"fix bug", []string{"Fix login bug", "Fix logout bug"}},
+		{"estimate<2.5", []string{"Fix logout bug"}},
+		{"due<2024-01-15", []string{"Fix login bug", "Fix logout bug"}},
+		{"done:yes", []string{"Fix login bug"}},
+		{"done!=true status:todo", []string{"Write docs", "Fix logout bug"}},
+		// A quoted token is always a phrase, never a column reference
+		{`"due date">2024-01-15`, nil},
+		{"status:done OR assignee:carol", []string{"Fix login bug", "Add dark mode"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filtered, err := tbl.FilterQuery(tt.query)
+			if err != nil {
+				t.Fatalf("FilterQuery(%q) failed: %v", tt.query, err)
+			}
+			if len(filtered.CurrentRows()) != len(tt.expected) {
+				t.Fatalf("FilterQuery(%q) returned %d rows, expected %d", tt.query, len(filtered.CurrentRows()), len(tt.expected))
+			}
+			for i, title := range tt.expected {
+				if filtered.CurrentRows()[i].Cells[0].Value != title {
+					t.Errorf("Row %d: expected %q, got %v", i, title, filtered.CurrentRows()[i].Cells[0].Value)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterQueryBindErrors(t *testing.T) {
+	tbl := newTicketTable()
+
+	for _, query := range []string{"owner:bob", "stauts:done", "priority>high", "due<soon", "done:maybe", "done>true"} {
+		t.Run(query, func(t *testing.T) {
+			if _, err := tbl.FilterQuery(query); err == nil {
+				t.Errorf("FilterQuery(%q) should fail", query)
+			}
+		})
+	}
+
+	// Filter falls back to a substring match when the query does not compile
+	filtered := tbl.Filter("owner:bob")
+	if len(filtered.CurrentRows()) != 0 {
+		t.Errorf("Expected fallback substring match to find nothing, got %d rows", len(filtered.CurrentRows()))
+	}
+}
+
+func TestFilterQueryPlainText(t *testing.T) {
+	tbl := newTicketTable()
+	tbl.AddRow("Meet at 12:30", "todo", 1, "dave", 1.0, "2024-03-01", false)
+	tbl.AddRow("See https://x.io/a", "todo", 1, "dave", 1.0, "2024-03-01", false)
+
+	for query, title := range map[string]string{"12:30": "Meet at 12:30", "https://x.io/a": "See https://x.io/a"} {
+		filtered, err := tbl.FilterQuery(query)
+		if err != nil {
+			t.Fatalf("FilterQuery(%q) failed: %v", query, err)
+		}
+		if len(filtered.CurrentRows()) != 1 || filtered.CurrentRows()[0].Cells[0].Value != title {
+			t.Errorf("FilterQuery(%q): expected only %q", query, title)
+		}
+	}
+
+	got := tbl.QueryTextTerms(`12:30 status:todo -https://x.io OR 9>8 "a:b" stauts:done`)
+	if want := []string{"12:30", "https://x.io", "9>8"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected text terms %v, got %v", want, got)
+	}
+}
+
+func TestFilterQueryByHeader(t *testing.T) {
+	tbl := newTicketTable()
+
+	filtered, err := tbl.FilterQuery("points>5")
+	if err != nil {
+		t.Fatalf("FilterQuery failed: %v", err)
+	}
+	if len(filtered.CurrentRows()) != 2 {
+		t.Errorf("Expected 2 rows, got %d", len(filtered.CurrentRows()))
+	}
+}
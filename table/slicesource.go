@@ -0,0 +1,103 @@
+package table
+
+import (
+	"slices"
+	"sync"
+)
+
+// SliceSource is a DataSource over rows held in memory. It sorts and filters
+// with the same rules as Table.SortByKeys and Table.FilterQuery, so a table
+// paging through it behaves like one holding the rows itself.
+type SliceSource struct {
+	mu    sync.Mutex // Fetches may run on background goroutines
+	table *Table
+
+	// Last sorted and filtered view, reused while paging through it
+	view       *Table
+	viewSort   []SortKey
+	viewFilter string
+}
+
+// NewSliceSource creates a source over a slice of structs or maps, inferring
+// columns the same way as Table.SetData
+func NewSliceSource(data interface{}) (*SliceSource, error) {
+	tbl := New()
+	if err := tbl.SetData(data); err != nil {
+		return nil, err
+	}
+	return NewTableSource(tbl), nil
+}
+
+// NewTableSource creates a source over the rows of an existing table. The
+// source sorts the table in place, so it should not be used elsewhere.
+func NewTableSource(tbl *Table) *SliceSource {
+	return &SliceSource{table: tbl}
+}
+
+// Schema returns the table's columns
+func (s *SliceSource) Schema() ([]Column, error) {
+	return slices.Clone(s.table.Columns), nil
+}
+
+// Count returns how many rows match the filter
+func (s *SliceSource) Count(filter string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	view, err := s.viewFor(s.viewSort, filter)
+	if err != nil {
+		return 0, err
+	}
+	return view.TotalRows, nil
+}
+
+// Fetch returns a page of rows matching the filter in the requested order
+func (s *SliceSource) Fetch(offset, limit int, sort []SortKey, filter string) ([]Row, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	view, err := s.viewFor(sort, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(view.RowRange(offset, offset+max(limit, 0))), nil
+}
+
+// CanSort reports that the source sorts rows itself
+func (s *SliceSource) CanSort() bool {
+	return true
+}
+
+// CanFilter reports that the source filters rows itself
+func (s *SliceSource) CanFilter() bool {
+	return true
+}
+
+// viewFor returns the rows matching filter in sort order, reusing the last
+// view when neither changed
+func (s *SliceSource) viewFor(sort []SortKey, filter string) (*Table, error) {
+	if s.view != nil && s.viewFilter == filter && slices.Equal(s.viewSort, sort) {
+		return s.view, nil
+	}
+
+	// Filter from the original order so unsorted views are deterministic
+	s.table.ClearSort()
+	view, err := s.table.FilterQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(sort) > 0 {
+		err = view.SortByKeys(sort...)
+	} else {
+		view.ClearSort()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.view = view
+	s.viewSort = slices.Clone(sort)
+	s.viewFilter = filter
+	return view, nil
+}
@@ -0,0 +1,228 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DataSource supplies rows on demand, so a table can page through data that
+// is too large to load up front or that lives elsewhere, such as a database.
+//
+// Filters use the query language described by ParseQuery. Row IDs should be
+// stable for a given sort and filter, and ideally across them, so that marks
+// follow rows. SliceSource keeps the IDs of its table's rows; SQLSource uses
+// the row's position in the result unless its KeyColumn names a column that
+// identifies rows.
+type DataSource interface {
+	// Schema returns the columns that fetched rows have cells for
+	Schema() ([]Column, error)
+
+	// Count returns how many rows match the filter
+	Count(filter string) (int, error)
+
+	// Fetch returns up to limit rows matching the filter, starting at offset,
+	// ordered by the sort keys
+	Fetch(offset, limit int, sort []SortKey, filter string) ([]Row, error)
+}
+
+// SourceCapabilities is implemented by sources that can sort or filter rows
+// themselves. Tables only hand sort keys and filters to sources that report
+// support for them; sources that do not implement it can do neither.
+type SourceCapabilities interface {
+	CanSort() bool
+	CanFilter() bool
+}
+
+// PageQuery describes one page to fetch from a table's DataSource. It holds
+// copies of the table's state, so it can be fetched on another goroutine.
+type PageQuery struct {
+	Page   int
+	Offset int
+	Limit  int
+	Sort   []SortKey
+	Filter string
+	Count  bool // Also count matching rows
+}
+
+// Equal reports whether two queries ask for the same rows
+func (q PageQuery) Equal(other PageQuery) bool {
+	return q.Page == other.Page &&
+		q.Offset == other.Offset &&
+		q.Limit == other.Limit &&
+		q.Filter == other.Filter &&
+		slices.Equal(q.Sort, other.Sort)
+}
+
+// PageResult holds the rows fetched for a PageQuery
+type PageResult struct {
+	Query PageQuery
+	Rows  []Row
+	Total int // Matching rows, if the query asked for a count
+	Err   error
+}
+
+// FetchPage runs a query against a source. It does not touch the table the
+// query came from, so it is safe to call from a tea.Cmd.
+func FetchPage(src DataSource, query PageQuery) PageResult {
+	result := PageResult{Query: query, Total: -1}
+	if query.Count {
+		total, err := src.Count(query.Filter)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Total = total
+	}
+
+	rows, err := src.Fetch(query.Offset, query.Limit, query.Sort, query.Filter)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Rows = rows
+	return result
+}
+
+// SetSource makes the table page lazily through a DataSource. Columns come
+// from the source's Schema, and existing rows and sort keys are dropped. No
+// rows are fetched until LoadPage or StorePage fill a page in.
+func (t *Table) SetSource(src DataSource) error {
+	columns, err := src.Schema()
+	if err != nil {
+		return err
+	}
+
+	t.Columns = columns
+	t.Rows = make([]Row, 0)
+	t.UnsortedOrder = make([]Row, 0)
+	t.SortBy = -1
+	t.SortDesc = false
+	t.SortKeys = nil
+	t.PageBreaks = nil
+	t.TotalRows = 0
+	t.originalData = make([]interface{}, 0)
+	t.cache = nil
+	t.footer = nil
+	t.source = src
+	t.sourceFilter = ""
+	t.sourcePages = nil
+	t.sourceCounted = false
+	return nil
+}
+
+// WithSource sets the table's DataSource (builder pattern). Errors from the
+// source's Schema leave the table without columns.
+func (t *Table) WithSource(src DataSource) *Table {
+	_ = t.SetSource(src)
+	return t
+}
+
+// Source returns the table's DataSource, or nil for tables holding their rows
+func (t *Table) Source() DataSource {
+	return t.source
+}
+
+// SourceFilter returns the filter the table passes to its DataSource
+func (t *Table) SourceFilter() string {
+	return t.sourceFilter
+}
+
+// PageQuery describes the fetch that fills a page of a source-backed table
+func (t *Table) PageQuery(pageNum int) PageQuery {
+	return PageQuery{
+		Page:   pageNum,
+		Offset: pageNum * t.PageSize,
+		Limit:  t.PageSize,
+		Sort:   slices.Clone(t.SortKeys),
+		Filter: t.sourceFilter,
+		Count:  !t.sourceCounted,
+	}
+}
+
+// PageLoaded reports whether a page of a source-backed table has been
+// fetched with the table's current sort, filter and page size
+func (t *Table) PageLoaded(pageNum int) bool {
+	if t.source == nil {
+		return true
+	}
+	result, ok := t.sourcePages[pageNum]
+	return ok && result.Query.Equal(t.PageQuery(pageNum))
+}
+
+// StorePage fills in a page from a fetch result. Results for queries that no
+// longer match the table's sort, filter or page size are discarded, and
+// StorePage reports whether the result was kept.
+func (t *Table) StorePage(result PageResult) bool {
+	if t.source == nil || result.Err != nil {
+		return false
+	}
+	if !result.Query.Equal(t.PageQuery(result.Query.Page)) {
+		return false
+	}
+
+	if t.sourcePages == nil {
+		t.sourcePages = make(map[int]PageResult)
+	}
+	t.sourcePages[result.Query.Page] = result
+	if result.Total >= 0 {
+		t.TotalRows = result.Total
+		t.sourceCounted = true
+	}
+	return true
+}
+
+// LoadPage fetches a page of a source-backed table and waits for the result
+func (t *Table) LoadPage(pageNum int) error {
+	if t.source == nil || t.PageLoaded(pageNum) {
+		return nil
+	}
+	result := FetchPage(t.source, t.PageQuery(pageNum))
+	if result.Err != nil {
+		return result.Err
+	}
+	t.StorePage(result)
+	return nil
+}
+
+// sourcePage returns a fetched page of a source-backed table, or no rows if
+// the page has not been loaded
+func (t *Table) sourcePage(pageNum int) []Row {
+	if !t.PageLoaded(pageNum) {
+		return []Row{}
+	}
+	return t.sourcePages[pageNum].Rows
+}
+
+// sourceCanSort reports whether the table's source can sort rows
+func (t *Table) sourceCanSort() bool {
+	capabilities, ok := t.source.(SourceCapabilities)
+	return ok && capabilities.CanSort()
+}
+
+// sourceCanFilter reports whether the table's source can filter rows
+func (t *Table) sourceCanFilter() bool {
+	capabilities, ok := t.source.(SourceCapabilities)
+	return ok && capabilities.CanFilter()
+}
+
+// filterSource returns a copy of a source-backed table that passes the query
+// to its source. The query is parsed so syntax errors surface immediately.
+func (t *Table) filterSource(query string) (*Table, error) {
+	if _, err := ParseQuery(query); err != nil {
+		return nil, err
+	}
+	if !t.sourceCanFilter() {
+		return nil, fmt.Errorf("data source cannot filter")
+	}
+
+	filtered := NewWithColumns(t.Columns)
+	filtered.PageSize = t.PageSize
+	filtered.Footer = t.Footer
+	filtered.SortBy = t.SortBy
+	filtered.SortDesc = t.SortDesc
+	filtered.SortKeys = slices.Clone(t.SortKeys)
+	filtered.source = t.source
+	filtered.sourceFilter = strings.TrimSpace(query)
+	return filtered, nil
+}
@@ -0,0 +1,174 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+)
+
+// sourceTestData returns 25 employees across three departments
+func sourceTestData() []Employee {
+	departments := []string{"Engineering", "Sales", "Support"}
+	employees := make([]Employee, 25)
+	for i := range employees {
+		employees[i] = Employee{
+			ID:         i + 1,
+			Name:       fmt.Sprintf("Employee %02d", i+1),
+			Department: departments[i%3],
+			Salary:     float64(50000 + (i*7919)%40000),
+			StartDate:  "2021-01-15",
+			Active:     i%2 == 0,
+		}
+	}
+	return employees
+}
+
+func newSourceTable(t *testing.T) *Table {
+	t.Helper()
+	src, err := NewSliceSource(sourceTestData())
+	if err != nil {
+		t.Fatalf("NewSliceSource failed: %v", err)
+	}
+	table := New().WithPageSize(10)
+	if err := table.SetSource(src); err != nil {
+		t.Fatalf("SetSource failed: %v", err)
+	}
+	return table
+}
+
+func TestSetSource(t *testing.T) {
+	table := newSourceTable(t)
+
+	if len(table.Columns) != 6 || table.Columns[1].Header != "Name" {
+		t.Fatalf("Expected columns from the source schema, got %d", len(table.Columns))
+	}
+	if table.PageLoaded(0) || len(table.GetPage(0)) != 0 {
+		t.Error("Expected no rows before the first page is loaded")
+	}
+
+	if err := table.LoadPage(0); err != nil {
+		t.Fatalf("LoadPage failed: %v", err)
+	}
+	if table.TotalRows != 25 || table.GetTotalPages() != 3 {
+		t.Errorf("Expected 25 rows on 3 pages, got %d on %d", table.TotalRows, table.GetTotalPages())
+	}
+	if page := table.GetPage(0); len(page) != 10 || page[0].Cells[1].Value != "Employee 01" {
+		t.Errorf("Expected the first 10 rows, got %d", len(page))
+	}
+
+	// Only requested pages are fetched
+	if table.PageLoaded(2) {
+		t.Error("Expected page 3 not to be loaded yet")
+	}
+	table.LoadPage(2)
+	if page := table.GetPage(2); len(page) != 5 || page[0].Cells[1].Value != "Employee 21" {
+		t.Errorf("Expected the last 5 rows on page 3, got %d", len(page))
+	}
+}
+
+func TestSourceSortAndFilter(t *testing.T) {
+	table := newSourceTable(t)
+	table.LoadPage(0)
+
+	// Sorting hands the keys to the source and drops fetched pages
+	if err := table.SortByColumn(1, true); err != nil {
+		t.Fatalf("SortByColumn failed: %v", err)
+	}
+	if table.PageLoaded(0) {
+		t.Error("Expected sorting to drop fetched pages")
+	}
+	table.LoadPage(0)
+	if got := table.GetPage(0)[0].Cells[1].Value; got != "Employee 25" {
+		t.Errorf("Expected Employee 25 first when sorted descending, got %v", got)
+	}
+
+	// Filtering returns a table that passes the query to the source
+	filtered, err := table.FilterQuery("department:sales")
+	if err != nil {
+		t.Fatalf("FilterQuery failed: %v", err)
+	}
+	if filtered.Source() == nil || filtered.SourceFilter() != "department:sales" {
+		t.Fatal("Expected the filtered table to share the source")
+	}
+	filtered.LoadPage(0)
+	if filtered.TotalRows != 8 {
+		t.Errorf("Expected 8 sales rows, got %d", filtered.TotalRows)
+	}
+	for _, row := range filtered.GetPage(0) {
+		if row.Cells[2].Value != "Sales" {
+			t.Errorf("Expected only Sales rows, got %v", row.Cells[2].Value)
+		}
+	}
+	if got := filtered.GetPage(0)[0].Cells[1].Value; got != "Employee 23" {
+		t.Errorf("Expected the filter to keep the sort order, got %v first", got)
+	}
+
+	// Query syntax errors surface before anything is fetched
+	if _, err := table.FilterQuery(`name:"unterminated`); err == nil {
+		t.Error("Expected an error for an invalid query")
+	}
+}
+
+func TestStorePageDiscardsStaleResults(t *testing.T) {
+	table := newSourceTable(t)
+	query := table.PageQuery(0)
+	result := FetchPage(table.Source(), query)
+
+	// The sort changed while the page was being fetched
+	table.SortByColumn(1, true)
+	if table.StorePage(result) {
+		t.Error("Expected a result for the old sort order to be discarded")
+	}
+
+	result = FetchPage(table.Source(), table.PageQuery(0))
+	if !table.StorePage(result) || !table.PageLoaded(0) {
+		t.Error("Expected a current result to be stored")
+	}
+
+	// Changing the page size makes fetched pages stale
+	table.PageSize = 5
+	if table.PageLoaded(0) {
+		t.Error("Expected pages fetched with another page size to be stale")
+	}
+}
+
+// listSource serves fixed rows and can neither sort nor filter
+type listSource struct {
+	rows []Row
+}
+
+func (s listSource) Schema() ([]Column, error) {
+	return []Column{*NewColumn("name", "Name")}, nil
+}
+
+func (s listSource) Count(filter string) (int, error) {
+	return len(s.rows), nil
+}
+
+func (s listSource) Fetch(offset, limit int, sort []SortKey, filter string) ([]Row, error) {
+	end := min(offset+limit, len(s.rows))
+	return s.rows[min(offset, end):end], nil
+}
+
+func TestSourceWithoutCapabilities(t *testing.T) {
+	table := New()
+	table.SetSource(listSource{rows: []Row{{ID: 0, Cells: []Cell{{Value: "a"}}}}})
+
+	if err := table.SortByColumn(0, false); err == nil {
+		t.Error("Expected an error sorting a source that cannot sort")
+	}
+	if len(table.SortKeys) != 0 {
+		t.Errorf("Expected no sort keys, got %v", table.SortKeys)
+	}
+	if _, err := table.FilterQuery("a"); err == nil {
+		t.Error("Expected an error filtering a source that cannot filter")
+	}
+	if err := table.AddRow("b"); err == nil {
+		t.Error("Expected an error adding rows to a source-backed table")
+	}
+
+	// Setting data switches back to an in-memory table
+	table.SetData([]map[string]interface{}{{"name": "b"}})
+	if table.Source() != nil || len(table.GetPage(0)) != 1 {
+		t.Error("Expected SetData to replace the source")
+	}
+}
@@ -0,0 +1,140 @@
+package table
+
+import (
+	"database/sql"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FromSQLRows reads a result set into a table. Columns keep the order of the
+// result, take their types from the driver's column types, and show NULL for
+// SQL NULL values, which are stored as nil cells (see Cell.IsNull). Values
+// that drivers return as text, such as MySQL numbers, are converted to the
+// column's type.
+//
+// FromSQLRows reads every remaining row but leaves closing rows to the caller.
+func FromSQLRows(rows *sql.Rows) (*Table, error) {
+	columns, err := sqlColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	t := NewWithColumns(columns)
+	for rows.Next() {
+		row, err := scanSQLRow(rows, columns, t.TotalRows)
+		if err != nil {
+			return nil, err
+		}
+		t.Rows = append(t.Rows, row)
+		t.UnsortedOrder = append(t.UnsortedOrder, row)
+		t.originalData = append(t.originalData, row.Data)
+		t.TotalRows++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// sqlColumns infers columns from a result set's column types
+func sqlColumns(rows *sql.Rows) ([]Column, error) {
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]Column, len(types))
+	for i, columnType := range types {
+		col := NewColumn(columnType.Name(), columnType.Name()).WithNullText("NULL")
+		col.Type = sqlDataType(columnType)
+		col.Width = New().getDefaultWidth(col.Type)
+		columns[i] = *col
+	}
+	return columns, nil
+}
+
+// sqlDataType maps a database column type to a DataType, using the type name
+// reported by the driver and falling back to the Go type it scans into
+func sqlDataType(columnType *sql.ColumnType) DataType {
+	// Normalize names such as "DECIMAL(10,2)" and "UNSIGNED BIGINT"
+	name := strings.ToUpper(columnType.DatabaseTypeName())
+	name, _, _ = strings.Cut(name, "(")
+	name = strings.TrimSpace(strings.TrimPrefix(name, "UNSIGNED "))
+
+	switch name {
+	case "INT", "INTEGER", "INT2", "INT4", "INT8", "TINYINT", "SMALLINT",
+		"MEDIUMINT", "BIGINT", "SMALLSERIAL", "SERIAL", "BIGSERIAL":
+		return Integer
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION",
+		"NUMERIC", "DECIMAL", "MONEY":
+		return Float
+	case "BOOL", "BOOLEAN":
+		return Boolean
+	case "DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ", "TIME", "TIMETZ":
+		return Date
+	case "":
+		// Fall back to the scan type below
+	default:
+		return String
+	}
+
+	if scanType := columnType.ScanType(); scanType != nil {
+		if scanType.Kind() == reflect.Ptr {
+			scanType = scanType.Elem()
+		}
+		return New().inferDataType(scanType)
+	}
+	return String
+}
+
+// scanSQLRow reads the current row of a result set into a Row
+func scanSQLRow(rows *sql.Rows, columns []Column, id int) (Row, error) {
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return Row{}, err
+	}
+
+	cells := make([]Cell, len(columns))
+	for i, value := range values {
+		values[i] = sqlValue(value, columns[i].Type)
+		cells[i] = Cell{Value: values[i], Type: columns[i].Type}
+	}
+	return Row{ID: id, Cells: cells, Data: values}, nil
+}
+
+// sqlValue converts a scanned value to suit its column's type. Text is parsed
+// as the column's type where it can be, and integers in Boolean columns, as
+// SQLite stores them, become bools. NULL stays nil.
+func sqlValue(value interface{}, dataType DataType) interface{} {
+	if bytes, ok := value.([]byte); ok {
+		value = string(bytes)
+	}
+
+	switch v := value.(type) {
+	case string:
+		switch dataType {
+		case Integer:
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return n
+			}
+		case Float:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		case Boolean:
+			if b, ok := parseBool(v); ok {
+				return b
+			}
+		}
+	case int64:
+		if dataType == Boolean {
+			return v != 0
+		}
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package table
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestFromSQLRows(t *testing.T) {
+	stub := &stubDB{
+		// Column order that a map would not preserve
+		columns: []string{"zone", "id", "amount", "paid", "due"},
+		types:   []string{"TEXT", "BIGINT", "NUMERIC", "BOOLEAN", "DATE"},
+		rows: [][]driver.Value{
+			// MySQL-style text values are converted to the column types
+			{[]byte("west"), []byte("3"), []byte("12.50"), "1", "2024-03-01"},
+			{"east", int64(1), nil, int64(0), nil},
+			{"north", int64(2), 7.25, int64(1), "2024-01-15"},
+		},
+	}
+	db := openStubDB(t, stub)
+
+	rows, err := db.Query("SELECT zone, id, amount, paid, due FROM invoices")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer rows.Close()
+
+	table, err := FromSQLRows(rows)
+	if err != nil {
+		t.Fatalf("FromSQLRows failed: %v", err)
+	}
+
+	keys := table.GetColumnNames()
+	for i, want := range []string{"zone", "id", "amount", "paid", "due"} {
+		if keys[i] != want {
+			t.Errorf("Column %d: expected %q, got %q", i, want, keys[i])
+		}
+	}
+	for i, want := range []DataType{String, Integer, Float, Boolean, Date} {
+		if table.Columns[i].Type != want {
+			t.Errorf("Column %s: expected type %v, got %v", keys[i], want, table.Columns[i].Type)
+		}
+	}
+
+	if table.TotalRows != 3 || len(table.Rows) != 3 || table.Rows[2].ID != 2 {
+		t.Fatalf("Expected 3 rows with sequential IDs, got %d", table.TotalRows)
+	}
+
+	first := table.Rows[0].Cells
+	if first[1].Value != int64(3) || first[2].Value != 12.5 || first[3].Value != true {
+		t.Errorf("Expected text converted to column types, got %#v", first)
+	}
+	if table.Rows[1].Cells[3].Value != false {
+		t.Errorf("Expected 0 in a Boolean column to become false, got %#v", table.Rows[1].Cells[3].Value)
+	}
+
+	// NULL is kept as nil and shown as NULL
+	if !table.Rows[1].Cells[2].IsNull() || table.Rows[0].Cells[2].IsNull() {
+		t.Error("Expected only the NULL amount to be null")
+	}
+	if got := table.GetCellValue(1, 2); got != "NULL" {
+		t.Errorf("Expected NULL to display as NULL, got %q", got)
+	}
+
+	// NULLs sort before values
+	table.SortByColumn(2, false)
+	if got := table.Rows[0].Cells[0].Value; got != "east" {
+		t.Errorf("Expected the NULL amount to sort first, got %v", got)
+	}
+	table.SortByColumn(4, true)
+	if got := table.Rows[2].Cells[0].Value; got != "east" {
+		t.Errorf("Expected the NULL date to sort last descending, got %v", got)
+	}
+}
+
+func TestSQLValue(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		dataType DataType
+		want     interface{}
+	}{
+		{[]byte("42"), Integer, int64(42)},
+		{[]byte("4.5"), Float, 4.5},
+		{[]byte("abc"), Integer, "abc"}, // Unparseable text is kept
+		{"yes", Boolean, true},
+		{int64(1), Boolean, true},
+		{int64(1), Integer, int64(1)},
+		{[]byte("2024-01-15"), Date, "2024-01-15"},
+		{nil, Integer, nil},
+	}
+
+	for _, tt := range tests {
+		if got := sqlValue(tt.value, tt.dataType); got != tt.want {
+			t.Errorf("sqlValue(%#v, %v) = %#v, expected %#v", tt.value, tt.dataType, got, tt.want)
+		}
+	}
+}
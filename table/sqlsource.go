@@ -0,0 +1,353 @@
+package table
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SQLSource is a DataSource over the result of a SQL query. Sorting, filtering
+// and paging are pushed down to the database by wrapping the query:
+//
+//	SELECT * FROM (<query>) AS q WHERE <filter> ORDER BY <sort> LIMIT n OFFSET m
+//
+// Filter queries are translated to SQL: free-text terms become
+// case-insensitive LIKE matches over the searchable columns, and column terms
+// become comparisons. Column names are quoted with double quotes and text
+// matching uses LOWER(CAST(col AS TEXT)), as SQLite and PostgreSQL expect.
+type SQLSource struct {
+	DB    *sql.DB
+	Query string
+	Args  []interface{}
+
+	// Placeholder returns the bind parameter for the n-th argument, counting
+	// from 1. It defaults to "?"; use DollarPlaceholder for PostgreSQL.
+	Placeholder func(n int) string
+
+	// LikeEscape is the character that escapes '%' and '_' in LIKE
+	// patterns. It defaults to '\'.
+	LikeEscape rune
+
+	// KeyColumn is the key of a column that identifies rows, such as a
+	// primary key. Rows with the same value get the same Row.ID whatever the
+	// sort, filter or page, so marks follow them. Without it, a row's ID is
+	// its position in the sorted, filtered result.
+	KeyColumn string
+
+	columns []Column
+	mu      sync.Mutex     // Fetches may run on background goroutines
+	ids     map[string]int // Row ID of each KeyColumn value fetched
+}
+
+// DollarPlaceholder numbers bind parameters as $1, $2, ...
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// NewSQLSource creates a source over a query and its arguments
+func NewSQLSource(db *sql.DB, query string, args ...interface{}) *SQLSource {
+	return &SQLSource{DB: db, Query: query, Args: args}
+}
+
+// Schema runs the query without fetching rows and infers columns from the
+// result's column types
+func (s *SQLSource) Schema() ([]Column, error) {
+	if s.columns != nil {
+		return s.columns, nil
+	}
+
+	rows, err := s.DB.Query(fmt.Sprintf("SELECT * FROM (%s) AS q LIMIT 0", s.Query), s.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := sqlColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+	s.columns = columns
+	return columns, nil
+}
+
+// Count returns how many rows of the query match the filter
+func (s *SQLSource) Count(filter string) (int, error) {
+	where, args, err := s.where(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS q%s", s.Query, where)
+	if err := s.DB.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Fetch returns a page of the query's rows
+func (s *SQLSource) Fetch(offset, limit int, sort []SortKey, filter string) ([]Row, error) {
+	columns, err := s.Schema()
+	if err != nil {
+		return nil, err
+	}
+	where, args, err := s.where(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	keyIndex := -1
+	if s.KeyColumn != "" {
+		keyIndex = slices.IndexFunc(columns, func(col Column) bool { return col.Key == s.KeyColumn })
+		if keyIndex < 0 {
+			return nil, fmt.Errorf("unknown key column: %s", s.KeyColumn)
+		}
+	}
+
+	var orderBy []string
+	for _, key := range sort {
+		if key.Column < 0 || key.Column >= len(columns) {
+			return nil, fmt.Errorf("invalid column index: %d", key.Column)
+		}
+		direction := "ASC"
+		if key.Desc {
+			direction = "DESC"
+		}
+		orderBy = append(orderBy, quoteIdentifier(columns[key.Column].Key)+" "+direction)
+	}
+
+	query := fmt.Sprintf("SELECT * FROM (%s) AS q%s", s.Query, where)
+	if len(orderBy) > 0 {
+		query += " ORDER BY " + strings.Join(orderBy, ", ")
+	}
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", max(limit, 0), max(offset, 0))
+
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Row
+	for rows.Next() {
+		row, err := scanSQLRow(rows, columns, offset+len(result))
+		if err != nil {
+			return nil, err
+		}
+		if keyIndex >= 0 {
+			row.ID = s.rowID(distinctKeyAt(row, keyIndex))
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// rowID returns the ID of the row with a KeyColumn value, giving values not
+// seen before the next ID
+func (s *SQLSource) rowID(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.ids[key]
+	if !ok {
+		if s.ids == nil {
+			s.ids = make(map[string]int)
+		}
+		id = len(s.ids)
+		s.ids[key] = id
+	}
+	return id
+}
+
+// CanSort reports that the database sorts rows
+func (s *SQLSource) CanSort() bool {
+	return true
+}
+
+// CanFilter reports that the database filters rows
+func (s *SQLSource) CanFilter() bool {
+	return true
+}
+
+// where translates a filter query to a WHERE clause and its arguments, which
+// follow the source's own arguments
+func (s *SQLSource) where(filter string) (string, []interface{}, error) {
+	args := append([]interface{}(nil), s.Args...)
+	if strings.TrimSpace(filter) == "" {
+		return "", args, nil
+	}
+
+	node, err := ParseQuery(filter)
+	if err != nil {
+		return "", nil, err
+	}
+	columns, err := s.Schema()
+	if err != nil {
+		return "", nil, err
+	}
+
+	w := &sqlWhere{
+		table:       NewWithColumns(columns),
+		args:        args,
+		placeholder: s.Placeholder,
+		escape:      s.LikeEscape,
+	}
+	if w.placeholder == nil {
+		w.placeholder = func(int) string { return "?" }
+	}
+	if w.escape == 0 {
+		w.escape = '\\'
+	}
+
+	clause, err := w.node(node)
+	if err != nil || clause == "" {
+		return "", w.args, err
+	}
+	return " WHERE " + clause, w.args, nil
+}
+
+// sqlWhere builds a WHERE clause from a parsed filter query
+type sqlWhere struct {
+	table       *Table // Resolves column names
+	args        []interface{}
+	placeholder func(n int) string
+	escape      rune // LIKE escape character
+}
+
+// bind adds an argument and returns its placeholder
+func (w *sqlWhere) bind(value interface{}) string {
+	w.args = append(w.args, value)
+	return w.placeholder(len(w.args))
+}
+
+// node translates a query AST node
+func (w *sqlWhere) node(node QueryNode) (string, error) {
+	switch n := node.(type) {
+	case *AndNode:
+		return w.join(n.Children, " AND ")
+	case *OrNode:
+		return w.join(n.Children, " OR ")
+	case *NotNode:
+		child, err := w.node(n.Child)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + child + ")", nil
+	case *TermNode:
+		return w.term(n)
+	default:
+		return "", fmt.Errorf("unsupported query node %T", node)
+	}
+}
+
+// join translates nodes and joins them with an operator
+func (w *sqlWhere) join(nodes []QueryNode, op string) (string, error) {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		part, err := w.node(node)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	return "(" + strings.Join(parts, op) + ")", nil
+}
+
+// term translates a single term, checking values against column types like
+// Table.CompileQuery does
+func (w *sqlWhere) term(term *TermNode) (string, error) {
+	if term.Field == "" {
+		var matches []string
+		for _, col := range w.table.Columns {
+			if col.Searchable {
+				matches = append(matches, w.contains(col, term.Value))
+			}
+		}
+		if len(matches) == 0 {
+			return "1 = 0", nil
+		}
+		return "(" + strings.Join(matches, " OR ") + ")", nil
+	}
+
+	colIndex := w.table.findColumn(term.Field)
+	if colIndex < 0 {
+		if term.isText() {
+			return w.term(term.bareTerm())
+		}
+		return "", &QueryError{Pos: term.Pos, Msg: fmt.Sprintf("unknown column %q", term.Field)}
+	}
+	col := w.table.Columns[colIndex]
+	invalid := func(kind string) error {
+		return &QueryError{Pos: term.Pos, Msg: fmt.Sprintf("%s: %q is not a valid %s", col.Header, term.Value, kind)}
+	}
+
+	var value interface{}
+	switch col.Type {
+	case Integer, Float:
+		number, err := strconv.ParseFloat(term.Value, 64)
+		if err != nil {
+			return "", invalid("number")
+		}
+		value = number
+
+	case Date:
+		if _, err := parseDate(term.Value); err != nil {
+			return "", invalid("date")
+		}
+		value = term.Value
+
+	case Boolean:
+		want, ok := parseBool(term.Value)
+		if !ok {
+			return "", invalid("boolean")
+		}
+		if term.Op != OpMatch && term.Op != OpEqual && term.Op != OpNotEqual {
+			return "", &QueryError{Pos: term.Pos, Msg: fmt.Sprintf("%s: operator '%s' is not supported for booleans", col.Header, term.Op)}
+		}
+		value = want
+
+	default:
+		if term.Op == OpMatch {
+			return w.contains(col, term.Value), nil
+		}
+		return fmt.Sprintf("LOWER(CAST(%s AS TEXT)) %s %s", quoteIdentifier(col.Key), sqlOperator(term.Op), w.bind(strings.ToLower(term.Value))), nil
+	}
+
+	return fmt.Sprintf("%s %s %s", quoteIdentifier(col.Key), sqlOperator(term.Op), w.bind(value)), nil
+}
+
+// contains matches a column's text against a case-insensitive substring
+func (w *sqlWhere) contains(col Column, value string) string {
+	pattern := "%" + escapeLike(strings.ToLower(value), w.escape) + "%"
+	return fmt.Sprintf("LOWER(CAST(%s AS TEXT)) LIKE %s ESCAPE '%c'", quoteIdentifier(col.Key), w.bind(pattern), w.escape)
+}
+
+// sqlOperator returns the SQL spelling of a query operator
+func sqlOperator(op QueryOp) string {
+	switch op {
+	case OpNotEqual:
+		return "<>"
+	case OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+		return op.String()
+	default:
+		return "="
+	}
+}
+
+// escapeLike escapes LIKE wildcards with an escape character so they match
+// literally
+func escapeLike(value string, escape rune) string {
+	e := string(escape)
+	return strings.NewReplacer(e, e+e, "%", e+"%", "_", e+"_").Replace(value)
+}
+
+// quoteIdentifier quotes a column name for use in SQL
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,144 @@
+package table
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// openSQLiteEmployees creates a SQLite file of employees and opens it
+func openSQLiteEmployees(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "employees.db"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		CREATE TABLE employees (
+			id INTEGER, name VARCHAR(40), salary DECIMAL(10,2),
+			hired DATE, active BOOLEAN, notes TEXT
+		);
+		INSERT INTO employees VALUES
+			(1, 'Alice', 75000, '2021-01-15', 1, NULL),
+			(2, 'Bob', 65000, '2020-06-01', 0, 'part time'),
+			(3, 'Carol', 82000, '2019-03-20', 1, '50%_off lunch'),
+			(4, 'Dave', 50000, '2022-11-01', 1, 'meets at 12:30'),
+			(5, 'Eve', 70000, '2020-02-10', 0, '50% off');`)
+	if err != nil {
+		t.Fatalf("Creating the table failed: %v", err)
+	}
+	return db
+}
+
+func TestSQLSourceSQLite(t *testing.T) {
+	src := NewSQLSource(openSQLiteEmployees(t), "SELECT * FROM employees WHERE id <> ?", 0)
+
+	// The LIMIT 0 probe reads the declared column types
+	columns, err := src.Schema()
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+	expected := []DataType{Integer, String, Float, Date, Boolean, String}
+	for i, want := range expected {
+		if columns[i].Type != want {
+			t.Errorf("Column %s: expected type %v, got %v", columns[i].Key, want, columns[i].Type)
+		}
+	}
+
+	counts := map[string]int{
+		"":                            5,
+		"ALI":                         1, // Free text is case-insensitive
+		`notes:"50%_off"`:             1, // Wildcards match literally
+		"notes:50%":                   2,
+		"salary>=70000 -active:false": 2,
+		"hired<2020-12-31":            3,
+		"name!=bob":                   4,
+		"12:30":                       1, // Not a column, so plain text
+	}
+	for filter, want := range counts {
+		got, err := src.Count(filter)
+		if err != nil {
+			t.Errorf("Count(%q) failed: %v", filter, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Count(%q): expected %d, got %d", filter, want, got)
+		}
+	}
+
+	// Another escape character works too
+	src.LikeEscape = '!'
+	if got, err := src.Count(`notes:"50%_off"`); err != nil || got != 1 {
+		t.Errorf("Expected one literal match with '!' escapes, got %d (%v)", got, err)
+	}
+	src.LikeEscape = 0
+
+	rows, err := src.Fetch(1, 2, []SortKey{{Column: 2, Desc: true}}, "salary>60000")
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Cells[1].Value != "Alice" || rows[1].Cells[1].Value != "Eve" {
+		t.Errorf("Expected Alice and Eve on the second page by salary, got %v", rows)
+	}
+	if rows[0].ID != 1 || rows[0].Cells[5].Value != nil {
+		t.Errorf("Expected the row's offset as its ID and NULL notes as nil, got %d and %#v", rows[0].ID, rows[0].Cells[5].Value)
+	}
+}
+
+func TestSQLSourceKeyColumn(t *testing.T) {
+	src := NewSQLSource(openSQLiteEmployees(t), "SELECT * FROM employees")
+	src.KeyColumn = "name"
+
+	ids := make(map[string]int)
+	for _, desc := range []bool{false, true} {
+		rows, err := src.Fetch(0, 5, []SortKey{{Column: 2, Desc: desc}}, "")
+		if err != nil {
+			t.Fatalf("Fetch failed: %v", err)
+		}
+		for _, row := range rows {
+			name := row.Cells[1].Value.(string)
+			if id, ok := ids[name]; ok && id != row.ID {
+				t.Errorf("Expected %s to keep ID %d across sorts, got %d", name, id, row.ID)
+			}
+			ids[name] = row.ID
+		}
+	}
+	if len(ids) != 5 {
+		t.Errorf("Expected 5 distinct rows, got %v", ids)
+	}
+
+	// A filtered page keeps the IDs too
+	rows, err := src.Fetch(0, 1, nil, "name:carol")
+	if err != nil || len(rows) != 1 || rows[0].ID != ids["Carol"] {
+		t.Errorf("Expected Carol's ID %d on a filtered page, got %v (%v)", ids["Carol"], rows, err)
+	}
+
+	src.KeyColumn = "missing"
+	if _, err := src.Fetch(0, 1, nil, ""); err == nil || err.Error() != "unknown key column: missing" {
+		t.Errorf("Expected an unknown key column error, got %v", err)
+	}
+}
+
+func TestSQLSourceSQLiteTable(t *testing.T) {
+	table := New().WithPageSize(2)
+	if err := table.SetSource(NewSQLSource(openSQLiteEmployees(t), "SELECT * FROM employees")); err != nil {
+		t.Fatalf("SetSource failed: %v", err)
+	}
+	if err := table.SortByColumn(1, true); err != nil {
+		t.Fatalf("SortByColumn failed: %v", err)
+	}
+	if err := table.LoadPage(0); err != nil {
+		t.Fatalf("LoadPage failed: %v", err)
+	}
+	page := table.GetPage(0)
+	if len(page) != 2 || page[0].Cells[1].Value != "Eve" || page[1].Cells[1].Value != "Dave" {
+		t.Errorf("Expected Eve and Dave first by name descending, got %v", page)
+	}
+	if table.TotalRows != 5 {
+		t.Errorf("Expected 5 rows, got %d", table.TotalRows)
+	}
+}
@@ -0,0 +1,293 @@
+package table
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// These tests run the SQL source against a stub database/sql driver that
+// records each statement and answers with canned rows, to check the exact SQL
+// the source generates and how results are scanned. sqlsource_sqlite_test.go
+// runs the source against a real SQLite database.
+
+type stubQuery struct {
+	query string
+	args  []driver.Value
+}
+
+// stubDB holds canned results and the statements run against them
+type stubDB struct {
+	mu      sync.Mutex
+	columns []string
+	types   []string
+	rows    [][]driver.Value
+	queries []stubQuery
+}
+
+var (
+	stubRegister sync.Once
+	stubDBs      sync.Map // DSN -> *stubDB
+)
+
+// openStubDB registers a stub database under the test's name and opens it
+func openStubDB(t *testing.T, stub *stubDB) *sql.DB {
+	t.Helper()
+	stubRegister.Do(func() {
+		sql.Register("bubbletable-stub", stubDriver{})
+	})
+	stubDBs.Store(t.Name(), stub)
+
+	db, err := sql.Open("bubbletable-stub", t.Name())
+	if err != nil {
+		t.Fatalf("Failed to open stub database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func (s *stubDB) lastQuery() stubQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queries[len(s.queries)-1]
+}
+
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) {
+	stub, _ := stubDBs.Load(name)
+	return stubConn{stub.(*stubDB)}, nil
+}
+
+type stubConn struct{ db *stubDB }
+
+func (c stubConn) Prepare(query string) (driver.Stmt, error) {
+	return stubStmt{db: c.db, query: query}, nil
+}
+func (c stubConn) Close() error              { return nil }
+func (c stubConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type stubStmt struct {
+	db    *stubDB
+	query string
+}
+
+func (s stubStmt) Close() error  { return nil }
+func (s stubStmt) NumInput() int { return -1 }
+func (s stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+
+// Query answers LIMIT 0 probes with no rows, counts with the number of
+// canned rows, and everything else with the canned rows
+func (s stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.queries = append(s.db.queries, stubQuery{query: s.query, args: args})
+
+	switch {
+	case strings.HasPrefix(s.query, "SELECT COUNT(*)"):
+		return &stubRows{columns: []string{"count"}, types: []string{"INTEGER"},
+			rows: [][]driver.Value{{int64(len(s.db.rows))}}}, nil
+	case strings.HasSuffix(s.query, "LIMIT 0"):
+		return &stubRows{columns: s.db.columns, types: s.db.types}, nil
+	default:
+		return &stubRows{columns: s.db.columns, types: s.db.types, rows: s.db.rows}, nil
+	}
+}
+
+type stubRows struct {
+	columns []string
+	types   []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *stubRows) Columns() []string { return r.columns }
+func (r *stubRows) Close() error      { return nil }
+func (r *stubRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.types[index]
+}
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func employeesStub() *stubDB {
+	return &stubDB{
+		columns: []string{"id", "name", "salary", "hired", "active", "notes"},
+		types:   []string{"INTEGER", "VARCHAR(40)", "DECIMAL(10,2)", "DATE", "BOOLEAN", ""},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Alice"), 75000.0, "2021-01-15", true, nil},
+			{int64(2), []byte("Bob"), 65000.0, "2020-06-01", false, "part time"},
+		},
+	}
+}
+
+func TestSQLSourceSchema(t *testing.T) {
+	src := NewSQLSource(openStubDB(t, employeesStub()), "SELECT * FROM employees")
+	columns, err := src.Schema()
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	expected := []DataType{Integer, String, Float, Date, Boolean, String}
+	for i, want := range expected {
+		if columns[i].Type != want {
+			t.Errorf("Column %s: expected type %v, got %v", columns[i].Key, want, columns[i].Type)
+		}
+	}
+	if columns[1].Key != "name" || columns[1].Header != "name" {
+		t.Errorf("Expected column names from the result, got %q", columns[1].Key)
+	}
+}
+
+func TestSQLSourceFetch(t *testing.T) {
+	stub := employeesStub()
+	src := NewSQLSource(openStubDB(t, stub), "SELECT * FROM employees WHERE dept = ?", "eng")
+
+	rows, err := src.Fetch(20, 10, []SortKey{{Column: 2, Desc: true}, {Column: 1}}, "")
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	want := `SELECT * FROM (SELECT * FROM employees WHERE dept = ?) AS q ORDER BY "salary" DESC, "name" ASC LIMIT 10 OFFSET 20`
+	if got := stub.lastQuery(); got.query != want || len(got.args) != 1 || got.args[0] != "eng" {
+		t.Errorf("Expected query %q with the source's argument, got %q %v", want, got.query, got.args)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].ID != 20 || rows[1].ID != 21 {
+		t.Errorf("Expected IDs to follow the offset, got %d and %d", rows[0].ID, rows[1].ID)
+	}
+	if rows[0].Cells[1].Value != "Alice" || rows[0].Cells[1].Type != String {
+		t.Errorf("Expected text scanned as a string, got %#v", rows[0].Cells[1])
+	}
+	if rows[0].Cells[5].Value != nil {
+		t.Errorf("Expected NULL scanned as nil, got %#v", rows[0].Cells[5].Value)
+	}
+}
+
+func TestSQLSourceFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		where  string
+		args   []driver.Value
+	}{
+		{
+			filter: "ali",
+			where:  `WHERE (LOWER(CAST("id" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("name" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("salary" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("hired" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("active" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("notes" AS TEXT)) LIKE ? ESCAPE '\')`,
+			args:   []driver.Value{"%ali%", "%ali%", "%ali%", "%ali%", "%ali%", "%ali%"},
+		},
+		{
+			filter: "salary>=70000 -active:false",
+			where:  `WHERE ("salary" >= ? AND NOT ("active" = ?))`,
+			args:   []driver.Value{70000.0, false},
+		},
+		{
+			filter: `name:"50%_off" OR hired<2021-01-01`,
+			where:  `WHERE (LOWER(CAST("name" AS TEXT)) LIKE ? ESCAPE '\' OR "hired" < ?)`,
+			args:   []driver.Value{`%50\%\_off%`, "2021-01-01"},
+		},
+		{
+			filter: "name!=Bob",
+			where:  `WHERE LOWER(CAST("name" AS TEXT)) <> ?`,
+			args:   []driver.Value{"bob"},
+		},
+		{
+			filter: "12:30",
+			where:  `WHERE (LOWER(CAST("id" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("name" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("salary" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("hired" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("active" AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST("notes" AS TEXT)) LIKE ? ESCAPE '\')`,
+			args:   []driver.Value{"%12:30%", "%12:30%", "%12:30%", "%12:30%", "%12:30%", "%12:30%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			stub := employeesStub()
+			src := NewSQLSource(openStubDB(t, stub), "SELECT * FROM employees")
+
+			if _, err := src.Count(tt.filter); err != nil {
+				t.Fatalf("Count failed: %v", err)
+			}
+			got := stub.lastQuery()
+			want := "SELECT COUNT(*) FROM (SELECT * FROM employees) AS q " + tt.where
+			if got.query != want {
+				t.Errorf("Expected query\n%s\ngot\n%s", want, got.query)
+			}
+			if len(got.args) != len(tt.args) {
+				t.Fatalf("Expected args %v, got %v", tt.args, got.args)
+			}
+			for i := range tt.args {
+				if got.args[i] != tt.args[i] {
+					t.Errorf("Arg %d: expected %#v, got %#v", i, tt.args[i], got.args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSQLSourceLikeEscape(t *testing.T) {
+	stub := employeesStub()
+	src := NewSQLSource(openStubDB(t, stub), "SELECT * FROM employees")
+	src.LikeEscape = '!'
+
+	if _, err := src.Count("name:50%_off!"); err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	got := stub.lastQuery()
+	if !strings.HasSuffix(got.query, `WHERE LOWER(CAST("name" AS TEXT)) LIKE ? ESCAPE '!'`) {
+		t.Errorf("Expected the escape character in the query, got %q", got.query)
+	}
+	if len(got.args) != 1 || got.args[0] != "%50!%!_off!!%" {
+		t.Errorf("Expected wildcards escaped with '!', got %v", got.args)
+	}
+}
+
+func TestSQLSourcePlaceholders(t *testing.T) {
+	stub := employeesStub()
+	src := NewSQLSource(openStubDB(t, stub), "SELECT * FROM employees WHERE dept = $1", "eng")
+	src.Placeholder = DollarPlaceholder
+
+	if _, err := src.Count("id>1"); err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if got := stub.lastQuery().query; !strings.HasSuffix(got, `WHERE "id" > $2`) {
+		t.Errorf("Expected filter arguments numbered after the query's, got %q", got)
+	}
+}
+
+func TestSQLSourceInvalidFilter(t *testing.T) {
+	src := NewSQLSource(openStubDB(t, employeesStub()), "SELECT * FROM employees")
+
+	if _, err := src.Count("salary>lots"); err == nil || !strings.Contains(err.Error(), "not a valid number") {
+		t.Errorf("Expected a type error, got %v", err)
+	}
+	if _, err := src.Count("missing:x"); err == nil || !strings.Contains(err.Error(), "unknown column") {
+		t.Errorf("Expected an unknown column error, got %v", err)
+	}
+}
+
+func TestSQLSourceTable(t *testing.T) {
+	table := New().WithPageSize(10)
+	if err := table.SetSource(NewSQLSource(openStubDB(t, employeesStub()), "SELECT * FROM employees")); err != nil {
+		t.Fatalf("SetSource failed: %v", err)
+	}
+	if err := table.LoadPage(0); err != nil {
+		t.Fatalf("LoadPage failed: %v", err)
+	}
+	if table.TotalRows != 2 || len(table.GetPage(0)) != 2 {
+		t.Errorf("Expected 2 rows, got %d", table.TotalRows)
+	}
+	if err := table.SortByColumn(2, true); err != nil {
+		t.Errorf("Expected the SQL source to sort, got %v", err)
+	}
+}
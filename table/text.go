@@ -0,0 +1,59 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultEllipsis marks text shortened by TruncateText and TruncateFormatter
+const DefaultEllipsis = "..."
+
+// DisplayWidth returns the number of terminal cells s occupies. ANSI escape
+// sequences take no space, East Asian wide characters and emoji take two
+// cells, and grapheme clusters such as combining marks and emoji ZWJ
+// sequences are measured as a single character.
+func DisplayWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// TruncateText shortens s to at most width terminal cells, replacing the cut
+// part with ellipsis. Grapheme clusters are never split and ANSI escape
+// sequences are preserved. If the ellipsis does not leave room for any text,
+// s is cut without one.
+func TruncateText(s string, width int, ellipsis string) string {
+	if width <= 0 {
+		return ""
+	}
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if DisplayWidth(ellipsis) >= width {
+		ellipsis = ""
+	}
+	return ansi.Truncate(s, width, ellipsis)
+}
+
+// WrapText word-wraps s into lines of at most width terminal cells, breaking
+// words longer than a line. With maxLines > 0, text beyond the last line is
+// dropped and the last line ends with ellipsis.
+func WrapText(s string, width, maxLines int, ellipsis string) []string {
+	if width <= 0 {
+		return []string{""}
+	}
+
+	lines := strings.Split(ansi.Wrap(s, width, ""), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return lines
+	}
+
+	lines = lines[:maxLines]
+	last := lines[maxLines-1]
+	if room := width - DisplayWidth(ellipsis); room > 0 {
+		lines[maxLines-1] = ansi.Truncate(last, room, "") + ellipsis
+	}
+	return lines
+}
@@ -0,0 +1,93 @@
+package table
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"ascii", "hello", 5},
+		{"cjk", "日本語", 6},
+		{"combining mark", "café", 4},
+		{"emoji zwj sequence", "👩‍💻", 2},
+		{"styled", "\x1b[31mred\x1b[0m", 3},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayWidth(tt.input); got != tt.expected {
+				t.Errorf("DisplayWidth(%q) = %d, expected %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		ellipsis string
+		expected string
+	}{
+		{"fits", "hello", 5, "...", "hello"},
+		{"ascii", "hello world", 8, "...", "hello..."},
+		{"no room for ellipsis", "hello", 3, "...", "hel"},
+		{"zero width", "hello", 0, "...", ""},
+		{"custom ellipsis", "hello world", 6, "…", "hello…"},
+		{"empty ellipsis", "hello world", 5, "", "hello"},
+		{"cjk", "日本語テキスト", 7, "...", "日本..."},
+		{"cjk odd width", "日本語テキスト", 8, "...", "日本..."},
+		{"combining mark", "café au lait", 5, "…", "café…"},
+		{"emoji zwj sequence", "👩‍💻👩‍💻👩‍💻", 5, "…", "👩‍💻👩‍💻…"},
+		{"emoji zwj no split", "👩‍💻👩‍💻", 3, "", "👩‍💻"},
+		{"styled", "\x1b[31mhello world\x1b[0m", 8, "...", "\x1b[31mhello...\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateText(tt.input, tt.width, tt.ellipsis)
+			if got != tt.expected {
+				t.Errorf("TruncateText(%q, %d, %q) = %q, expected %q", tt.input, tt.width, tt.ellipsis, got, tt.expected)
+			}
+			if DisplayWidth(got) > tt.width {
+				t.Errorf("TruncateText(%q, %d, %q) is %d cells wide", tt.input, tt.width, tt.ellipsis, DisplayWidth(got))
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		maxLines int
+		expected []string
+	}{
+		{"fits", "hello", 10, 0, []string{"hello"}},
+		{"words", "the quick brown fox", 10, 0, []string{"the quick", "brown fox"}},
+		{"long word", "abcdefghij", 4, 0, []string{"abcd", "efgh", "ij"}},
+		{"max lines", "the quick brown fox jumps", 10, 2, []string{"the quick", "brown f..."}},
+		{"cjk", "日本語のテキスト", 6, 0, []string{"日本語", "のテキ", "スト"}},
+		{"newlines", "one\ntwo", 10, 0, []string{"one", "two"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapText(tt.input, tt.width, tt.maxLines, DefaultEllipsis)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("WrapText(%q, %d, %d) = %q, expected %q", tt.input, tt.width, tt.maxLines, got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("WrapText(%q, %d, %d) = %q, expected %q", tt.input, tt.width, tt.maxLines, got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
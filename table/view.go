@@ -0,0 +1,366 @@
+package table
+
+import (
+	"slices"
+	"sort"
+)
+
+// A filtered view (see filterRows) does not copy the rows it keeps. It holds
+// their positions in its parent's UnsortedOrder, once in the view's current
+// order and once in its original order, and reads rows only when they are
+// asked for: a page at a time for GetPage. A view's Rows and UnsortedOrder
+// are nil; read its rows with GetPage, RowRange or CurrentRows.
+//
+// A view is patched by ApplyMutation for each change to its parent. A view
+// that missed a change filters its parent again when it is next read.
+
+// isView reports whether the table is a filtered view
+func (t *Table) isView() bool {
+	return t.matches != nil
+}
+
+// rowCount returns the number of rows in the table's current order
+func (t *Table) rowCount() int {
+	if t.isView() {
+		t.sync()
+		return len(t.order)
+	}
+	return len(t.Rows)
+}
+
+// rowAt returns the row at a position in the table's current order
+func (t *Table) rowAt(i int) Row {
+	if t.isView() {
+		return t.parent.UnsortedOrder[t.order[i]]
+	}
+	return t.Rows[i]
+}
+
+// RowRange returns the rows from start up to end in the table's current
+// (sorted and filtered) order, clamped to the rows there are. A filtered
+// view reads just those rows from the table it was filtered from.
+func (t *Table) RowRange(start, end int) []Row {
+	count := t.rowCount()
+	start = min(max(start, 0), count)
+	end = min(max(end, start), count)
+	if !t.isView() {
+		return t.Rows[start:end]
+	}
+
+	rows := make([]Row, end-start)
+	for i := range rows {
+		rows[i] = t.rowAt(start + i)
+	}
+	return rows
+}
+
+// CurrentRows returns every row in the table's current order: Rows, or the
+// rows a filtered view keeps, read from the table it was filtered from
+func (t *Table) CurrentRows() []Row {
+	return t.RowRange(0, t.rowCount())
+}
+
+// originalRows returns every row in the table's original order
+func (t *Table) originalRows() []Row {
+	if !t.isView() {
+		return t.UnsortedOrder
+	}
+	t.sync()
+	rows := make([]Row, len(t.original))
+	for i, pos := range t.original {
+		rows[i] = t.parent.UnsortedOrder[pos]
+	}
+	return rows
+}
+
+// viewPositions returns the positions in the table's UnsortedOrder (or its
+// parent's, for a view) of the rows matches keeps: in the table's current
+// order and in its original order. Each row is tested once.
+func (t *Table) viewPositions(matches func(row Row) bool) (order, original []int32) {
+	if t.isView() {
+		t.sync()
+		base := t.parent.UnsortedOrder
+		keep := make([]bool, len(base))
+		for _, pos := range t.order {
+			if matches(base[pos]) {
+				keep[pos] = true
+				order = append(order, pos)
+			}
+		}
+		for _, pos := range t.original {
+			if keep[pos] {
+				original = append(original, pos)
+			}
+		}
+		return order, original
+	}
+
+	keep := make([]bool, len(t.UnsortedOrder))
+	for pos, row := range t.UnsortedOrder {
+		if matches(row) {
+			keep[pos] = true
+			original = append(original, int32(pos))
+		}
+	}
+	if len(t.SortKeys) == 0 {
+		return slices.Clone(original), original
+	}
+
+	// Follow the sorted rows, or sort the kept rows the same way if their
+	// IDs cannot be told apart
+	positions := t.rowPositions()
+	if positions == nil {
+		order = slices.Clone(original)
+		t.sortPositions(t.UnsortedOrder, order, t.SortKeys)
+		return order, original
+	}
+	for _, row := range t.Rows {
+		if pos, ok := positions.get(row.ID); ok && keep[pos] {
+			order = append(order, int32(pos))
+		}
+	}
+	return order, original
+}
+
+// sortPositions stably sorts positions of rows in base by sort keys,
+// comparing cached keys when the cache covers the rows
+func (t *Table) sortPositions(base []Row, positions []int32, keys []SortKey) {
+	if cache := t.columnCache(); cache != nil {
+		columns := make([]*sortKeyColumn, len(keys))
+		for i, key := range keys {
+			columns[i] = cache.sortKeyColumn(key.Column)
+		}
+		slices.SortStableFunc(positions, func(a, b int32) int {
+			return compareSortKeys(base[a].ID, base[b].ID, columns, keys)
+		})
+		return
+	}
+	slices.SortStableFunc(positions, func(a, b int32) int {
+		return compareRows(base[a], base[b], keys)
+	})
+}
+
+// sortView orders a view's rows by its sort keys, starting from its
+// original order
+func (t *Table) sortView() {
+	t.order = slices.Clone(t.original)
+	if len(t.SortKeys) > 0 {
+		t.sortPositions(t.parent.UnsortedOrder, t.order, t.SortKeys)
+	}
+	t.PageBreaks = nil
+}
+
+// sync filters a view's parent again if the parent changed without the view
+// being told (see ApplyMutation), keeping the view's own sort
+func (t *Table) sync() {
+	if t.version == t.parent.version {
+		return
+	}
+
+	fresh := t.refilter()
+	t.order, t.original = fresh.order, fresh.original
+	t.TotalRows = len(t.order)
+	t.version = fresh.version
+	t.PageBreaks = nil
+	t.footer = nil
+	if !slices.Equal(t.SortKeys, fresh.SortKeys) {
+		t.sortView()
+	}
+}
+
+// shiftPositions moves positions at or after from by n, for a row inserted
+// into the parent
+func shiftPositions(positions []int32, from int32, n int32) {
+	for i, pos := range positions {
+		if pos >= from {
+			positions[i] = pos + n
+		}
+	}
+}
+
+// dropPosition removes the position of a row deleted from the parent and
+// moves the positions after it back by one
+func dropPosition(positions []int32, deleted int32) []int32 {
+	kept := positions[:0]
+	for _, pos := range positions {
+		switch {
+		case pos == deleted:
+		case pos > deleted:
+			kept = append(kept, pos-1)
+		default:
+			kept = append(kept, pos)
+		}
+	}
+	return kept
+}
+
+// countBefore returns how many positions come before pos in the parent
+func countBefore(positions []int32, pos int32) int {
+	n := 0
+	for _, other := range positions {
+		if other < pos {
+			n++
+		}
+	}
+	return n
+}
+
+// viewInsertAt returns where a row of the parent goes in a view's current
+// order: in sorted position, or by its position in the parent when the view
+// is not sorted
+func (t *Table) viewInsertAt(row Row, pos int32) int {
+	if len(t.SortKeys) == 0 {
+		return countBefore(t.order, pos)
+	}
+	base := t.parent.UnsortedOrder
+	return sort.Search(len(t.order), func(i int) bool {
+		return compareRows(base[t.order[i]], row, t.SortKeys) > 0
+	})
+}
+
+// viewMove moves the updated row at a position in a view's current order if
+// it is no longer in sorted position
+func (t *Table) viewMove(at int, row Row) {
+	keys := t.SortKeys
+	base := t.parent.UnsortedOrder
+	inPlace := len(keys) == 0 ||
+		(at == 0 || compareRows(base[t.order[at-1]], row, keys) <= 0) &&
+			(at == len(t.order)-1 || compareRows(row, base[t.order[at+1]], keys) <= 0)
+	if inPlace {
+		return
+	}
+
+	pos := t.order[at]
+	t.order = slices.Delete(t.order, at, at+1)
+	t.order = slices.Insert(t.order, t.viewInsertAt(row, pos), pos)
+}
+
+// rowPositions maps row IDs to positions in a table's UnsortedOrder. IDs
+// that are small non-negative numbers, as they are for tables built with
+// SetData and AddRow, index a slice; other IDs use a map. Row mutations
+// keep it up to date (see insert and remove).
+type rowPositions struct {
+	dense  []int32 // Position + 1 of each ID, or 0 for none
+	sparse map[int]int
+}
+
+// rowPositions returns the table's position lookup, building it on first
+// use. It returns nil if row IDs repeat.
+func (t *Table) rowPositions() *rowPositions {
+	if t.positions == nil {
+		t.positions = newRowPositions(t.UnsortedOrder)
+	}
+	if t.positions.dense == nil && t.positions.sparse == nil {
+		return nil
+	}
+	return t.positions
+}
+
+// newRowPositions indexes rows by ID, leaving the lookup empty if IDs repeat
+func newRowPositions(rows []Row) *rowPositions {
+	limit := 2 * len(rows)
+	dense := make([]int32, limit)
+	for pos, row := range rows {
+		if row.ID < 0 || row.ID >= limit {
+			dense = nil
+			break
+		}
+		if dense[row.ID] != 0 {
+			return &rowPositions{}
+		}
+		dense[row.ID] = int32(pos + 1)
+	}
+	if dense != nil {
+		return &rowPositions{dense: dense}
+	}
+
+	sparse := make(map[int]int, len(rows))
+	for pos, row := range rows {
+		if _, ok := sparse[row.ID]; ok {
+			return &rowPositions{}
+		}
+		sparse[row.ID] = pos
+	}
+	return &rowPositions{sparse: sparse}
+}
+
+// get returns the position of the row with an ID
+func (p *rowPositions) get(id int) (int, bool) {
+	if p.dense != nil {
+		if id < 0 || id >= len(p.dense) || p.dense[id] == 0 {
+			return 0, false
+		}
+		return int(p.dense[id]) - 1, true
+	}
+	pos, ok := p.sparse[id]
+	return pos, ok
+}
+
+// insert records a row inserted at a position, moving later rows up. rows
+// is the number of rows after the insert.
+func (p *rowPositions) insert(id, pos, rows int) {
+	if p.dense == nil && p.sparse == nil {
+		return // IDs repeat
+	}
+	if p.dense != nil && (id < 0 || id >= len(p.dense)) {
+		if id >= 0 && id < 2*rows {
+			p.dense = append(p.dense, make([]int32, 2*rows-len(p.dense))...)
+		} else {
+			p.makeSparse()
+		}
+	}
+
+	if p.dense != nil {
+		if pos < rows-1 {
+			for other, stored := range p.dense {
+				if int(stored) > pos {
+					p.dense[other] = stored + 1
+				}
+			}
+		}
+		p.dense[id] = int32(pos + 1)
+		return
+	}
+	if pos < rows-1 {
+		for other, stored := range p.sparse {
+			if stored >= pos {
+				p.sparse[other] = stored + 1
+			}
+		}
+	}
+	p.sparse[id] = pos
+}
+
+// remove forgets a row deleted from a position, moving later rows down
+func (p *rowPositions) remove(id, pos int) {
+	if p.dense == nil && p.sparse == nil {
+		return // IDs repeat
+	}
+	if p.dense != nil {
+		p.dense[id] = 0
+		for other, stored := range p.dense {
+			if int(stored) > pos+1 {
+				p.dense[other] = stored - 1
+			}
+		}
+		return
+	}
+	delete(p.sparse, id)
+	for other, stored := range p.sparse {
+		if stored > pos {
+			p.sparse[other] = stored - 1
+		}
+	}
+}
+
+// makeSparse moves the positions from the slice to a map, for an ID the
+// slice cannot hold
+func (p *rowPositions) makeSparse() {
+	p.sparse = make(map[int]int, len(p.dense))
+	for id, stored := range p.dense {
+		if stored != 0 {
+			p.sparse[id] = int(stored) - 1
+		}
+	}
+	p.dense = nil
+}
@@ -0,0 +1,67 @@
+package table
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestViewReadsRowsFromItsTable(t *testing.T) {
+	table := stockTable()
+	view := table.Filter("qty>2")
+
+	if view.Rows != nil || view.UnsortedOrder != nil {
+		t.Error("Expected a filtered view to hold positions rather than rows")
+	}
+	if got := columnValues(view.GetPage(0), 0); !slices.Equal(got, []interface{}{"a", "c"}) {
+		t.Errorf("Expected the view's page sorted by quantity, got %v", got)
+	}
+	if got := columnValues(view.RowRange(1, 9), 0); !slices.Equal(got, []interface{}{"c"}) {
+		t.Errorf("Expected RowRange to clamp to the view's rows, got %v", got)
+	}
+	if got := view.GetCellValue(1, 1); got != "9" {
+		t.Errorf("Expected the cell of the second row, got %q", got)
+	}
+}
+
+func TestViewFiltersAgainWhenStale(t *testing.T) {
+	table := stockTable()
+	view := table.Filter("qty>2")
+
+	// Without ApplyMutation, the view filters its table again when read
+	table.AddRow("d", 7)
+	if got := columnValues(view.CurrentRows(), 0); !slices.Equal(got, []interface{}{"a", "d", "c"}) {
+		t.Errorf("Expected the new row in the view, got %v", got)
+	}
+	if view.TotalRows != 3 {
+		t.Errorf("Expected 3 rows, got %d", view.TotalRows)
+	}
+
+	// The view keeps its own sort when it filters again
+	view.SortByColumn(1, true)
+	table.UpdateRow(1, "b", 8)
+	if got := columnValues(view.CurrentRows(), 0); !slices.Equal(got, []interface{}{"c", "b", "d", "a"}) {
+		t.Errorf("Expected the view sorted by descending quantity, got %v", got)
+	}
+	if got := columnValues(table.Rows, 0); !slices.Equal(got, []interface{}{"a", "d", "b", "c"}) {
+		t.Errorf("Expected sorting the view to leave its table alone, got %v", got)
+	}
+
+	view.ClearSort()
+	if got := columnValues(view.CurrentRows(), 0); !slices.Equal(got, []interface{}{"a", "b", "c", "d"}) {
+		t.Errorf("Expected the view in its table's original order, got %v", got)
+	}
+}
+
+func TestViewOfFuzzyView(t *testing.T) {
+	table := New()
+	table.SetData([]Stock{{"bab", 1}, {"ab", 2}, {"b", 3}})
+	ranked := table.FuzzyFilter("ab")
+	narrowed := ranked.Filter("qty<3")
+
+	if got := columnValues(narrowed.CurrentRows(), 0); !slices.Equal(got, []interface{}{"ab", "bab"}) {
+		t.Errorf("Expected the narrowed view to keep the ranking, got %v", got)
+	}
+	if narrowed.parent != table {
+		t.Error("Expected a view of a view to read rows from the first table")
+	}
+}